@@ -34,6 +34,7 @@ var headerREADME = `
 - [Custom resource definitions](custom-resources.md)
 - [Annotations](annotations.md)
 - [Prometheus](prometheus.md)
+- [Configuration errors](configuration-errors.md)
 
 ### Lifecycle
 
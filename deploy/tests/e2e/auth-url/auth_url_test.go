@@ -0,0 +1,86 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e_parallel
+
+package authurl
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/e2e"
+)
+
+func (suite *AuthURLSuite) Test_AuthURL() {
+	for _, tc := range []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{"no-credentials", "", http.StatusUnauthorized},
+		{"forbidden", "Bearer forbidden", http.StatusForbidden},
+		{"allowed", "Bearer alice", http.StatusOK},
+	} {
+		suite.Run(tc.name, func() {
+			suite.Eventually(func() bool {
+				suite.client.Req.Header = map[string][]string{}
+				if tc.authorization != "" {
+					suite.client.Req.Header.Set("Authorization", tc.authorization)
+				}
+				res, cls, err := suite.client.Do()
+				if res == nil {
+					suite.T().Log(err)
+					return false
+				}
+				defer cls()
+				return res.StatusCode == tc.status
+			}, e2e.WaitDuration, e2e.TickDuration)
+		})
+	}
+}
+
+func (suite *AuthURLSuite) Test_AuthURL_Response_Headers() {
+	suite.Eventually(func() bool {
+		suite.client.Req.Header = map[string][]string{
+			"Authorization": {"Bearer alice"},
+			// spoofed header, must be replaced by the one of the authentication service
+			"X-User": {"mallory"},
+		}
+		res, cls, err := suite.client.Do()
+		if res == nil {
+			suite.T().Log(err)
+			return false
+		}
+		defer cls()
+		if res.StatusCode != http.StatusOK {
+			return false
+		}
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return false
+		}
+		type echo struct {
+			HTTP struct {
+				Headers map[string]string `json:"headers"`
+			} `json:"http"`
+		}
+		e := &echo{}
+		if err := json.Unmarshal(b, e); err != nil {
+			return false
+		}
+		return e.HTTP.Headers["X-User"] == "alice"
+	}, e2e.WaitDuration, e2e.TickDuration)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e_parallel
+
+package cache
+
+import (
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/e2e"
+)
+
+// Responses served from the cache carry an Age header.
+func (suite *CacheSuite) Test_Cache() {
+	for _, tc := range []struct {
+		name   string
+		enable string
+		cached bool
+	}{
+		{"enabled", "true", true},
+		{"disabled", "false", false},
+	} {
+		suite.Run(tc.name, func() {
+			suite.tmplData.IngAnnotations = []struct{ Key, Value string }{
+				{"cache-enable", tc.enable},
+				{"cache-max-age", "3600"},
+			}
+			suite.Require().NoError(suite.test.Apply("config/ingress.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+			suite.Eventually(func() bool {
+				var cached bool
+				for i := 0; i < 2; i++ {
+					res, cls, err := suite.client.Do()
+					if res == nil {
+						suite.T().Log(err)
+						return false
+					}
+					defer cls()
+					if res.StatusCode != 200 {
+						return false
+					}
+					cached = res.Header.Get("Age") != ""
+				}
+				return cached == tc.cached
+			}, e2e.WaitDuration, e2e.TickDuration)
+		})
+	}
+}
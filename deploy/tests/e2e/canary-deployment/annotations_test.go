@@ -0,0 +1,71 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e_parallel
+
+package canarydeployment
+
+import (
+	"io"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/e2e"
+)
+
+func (suite *CanaryDeploymentSuite) Test_Canary_Annotations() {
+	// a canary ingress with weight 0 never receives traffic, it does not interfere with other tests.
+	defer func() {
+		suite.tmplData.CanaryAnnotations = []struct{ Key, Value string }{{"canary-weight", "0"}}
+		suite.NoError(suite.test.Apply("config/canary-annotations.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+	}()
+	for _, tc := range []struct {
+		name        string
+		annotations []struct{ Key, Value string }
+		header      map[string][]string
+		staging     bool
+	}{
+		{"weight", []struct{ Key, Value string }{{"canary-weight", "100"}}, nil, true},
+		{"header-match", []struct{ Key, Value string }{{"canary-by-header", "X-Canary"}, {"canary-by-header-value", "v2"}}, map[string][]string{"X-Canary": {"v2"}}, true},
+		{"header-no-match", []struct{ Key, Value string }{{"canary-by-header", "X-Canary"}, {"canary-by-header-value", "v2"}}, map[string][]string{"X-Canary": {"v1"}}, false},
+		{"cookie", []struct{ Key, Value string }{{"canary-by-cookie", "canary"}}, map[string][]string{"Cookie": {"canary=always"}}, true},
+		{"invalid", []struct{ Key, Value string }{{"canary-weight", "150"}}, nil, false},
+	} {
+		suite.Run(tc.name, func() {
+			suite.tmplData.CanaryAnnotations = tc.annotations
+			suite.Require().NoError(suite.test.Apply("config/canary-annotations.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+			suite.Eventually(func() bool {
+				for i := 0; i < 5; i++ {
+					suite.client.Req.Header = map[string][]string{}
+					for k, v := range tc.header {
+						suite.client.Req.Header[k] = v
+					}
+					res, cls, err := suite.client.Do()
+					if res == nil {
+						suite.T().Log(err)
+						return false
+					}
+					defer cls()
+					if res.StatusCode != 200 {
+						return false
+					}
+					body, _ := io.ReadAll(res.Body)
+					if strings.HasPrefix(string(body), "http-echo-staging") != tc.staging {
+						return false
+					}
+				}
+				return true
+			}, e2e.WaitDuration, e2e.TickDuration)
+		})
+	}
+}
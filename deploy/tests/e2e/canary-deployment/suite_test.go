@@ -34,8 +34,9 @@ type CanaryDeploymentSuite struct {
 }
 
 type tmplData struct {
-	Host            string
-	StagingRouteACL string
+	Host              string
+	StagingRouteACL   string
+	CanaryAnnotations []struct{ Key, Value string }
 }
 
 func (suite *CanaryDeploymentSuite) SetupSuite() {
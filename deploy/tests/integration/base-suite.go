@@ -57,6 +57,13 @@ mode http
 monitor-uri /healthz
 option dontlog-normal
 
+frontend mirror
+mode http
+option dontlog-normal
+http-request set-var(txn.mirror_backend) req.hdr(x-haproxy-mirror)
+http-request del-header x-haproxy-mirror
+use_backend %[var(txn.mirror_backend)]
+
 frontend stats
   mode http
   stats enable
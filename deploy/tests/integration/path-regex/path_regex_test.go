@@ -0,0 +1,41 @@
+// Copyright 2023 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathregex
+
+import (
+	"os"
+	"path/filepath"
+
+	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
+)
+
+func (suite *PathRegexSuite) TestPathRegex() {
+	suite.StartController()
+	ing := newAppIngress("/api/v[0-9]+/users", "/invalid/(")
+	ing.Annotations["path-regex"] = "true"
+	suite.fixture(
+		k8ssync.SyncDataEvent{SyncType: k8ssync.SERVICE, Namespace: appNs, Name: serviceName, Data: newAppSvc()},
+		k8ssync.SyncDataEvent{SyncType: k8ssync.INGRESS, Namespace: appNs, Name: ingressName, Data: ing},
+	)
+	suite.StopController()
+
+	testController := suite.TestControllers[suite.T().Name()]
+	content, err := os.ReadFile(filepath.Join(testController.TempDir, "maps", "path-regex.map"))
+	suite.Require().NoError(err)
+	// the invalid regular expression is not routed
+	suite.Equal("^example\\.com/api/v[0-9]+/users\t\t\tappNs_svc_appSvcName_http\n", string(content))
+	// regex paths are looked up with and without the route-match key in both frontends
+	suite.ExpectHaproxyConfigContains("map_reg(", 4)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathregex
+
+import (
+	"os"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/integration"
+	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+var (
+	appNs       = "appNs"
+	serviceName = "appSvcName"
+	ingressName = "appIngName"
+)
+
+type PathRegexSuite struct {
+	integration.BaseSuite
+}
+
+func TestPathRegex(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PathRegexSuite))
+}
+
+func (suite *PathRegexSuite) BeforeTest(suiteName, testName string) {
+	suite.BaseSuite.BeforeTest(suiteName, testName)
+	os.Unsetenv("POD_NAME")
+	os.Unsetenv("POD_NAMESPACE")
+}
+
+func (suite *PathRegexSuite) fixture(events ...k8ssync.SyncDataEvent) {
+	testController := suite.TestControllers[suite.T().Name()]
+
+	ns := store.Namespace{Name: appNs, Status: store.ADDED}
+	testController.EventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.NAMESPACE, Namespace: ns.Name, Data: &ns}
+	for _, e := range events {
+		testController.EventChan <- e
+	}
+	testController.EventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.COMMAND}
+	controllerHasWorked := make(chan struct{})
+	testController.EventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.COMMAND, EventProcessed: controllerHasWorked}
+	<-controllerHasWorked
+}
+
+func newAppSvc() *store.Service {
+	return &store.Service{
+		Annotations: map[string]string{},
+		Name:        serviceName,
+		Namespace:   appNs,
+		Ports: []store.ServicePort{
+			{
+				Name:     "http",
+				Protocol: "TCP",
+				Port:     8080,
+				Status:   store.ADDED,
+			},
+		},
+		Status: store.ADDED,
+	}
+}
+
+func newAppIngress(paths ...string) *store.Ingress {
+	ingressPaths := map[string]*store.IngressPath{}
+	for _, path := range paths {
+		ingressPaths[string(networkingv1.PathTypeImplementationSpecific)+"-"+path] = &store.IngressPath{
+			Path:          path,
+			PathTypeMatch: string(networkingv1.PathTypeImplementationSpecific),
+			SvcNamespace:  appNs,
+			SvcPortString: "http",
+			SvcName:       serviceName,
+		}
+	}
+	return &store.Ingress{
+		IngressCore: store.IngressCore{
+			APIVersion:  store.NETWORKINGV1,
+			Name:        ingressName,
+			Namespace:   appNs,
+			Annotations: map[string]string{},
+			Rules: map[string]*store.IngressRule{
+				"example.com": {
+					Host:  "example.com",
+					Paths: ingressPaths,
+				},
+			},
+		},
+		Status: store.ADDED,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/stretchr/testify/require"
+
+	c "github.com/haproxytech/kubernetes-ingress/pkg/controller"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+var haproxyConfig = `global
+daemon
+master-worker
+pidfile /var/run/haproxy.pid
+stats socket /var/run/haproxy-runtime-api.sock level admin expose-fd listeners
+default-path config
+
+peers localinstance
+ peer local 127.0.0.1:10000
+
+frontend https
+mode http
+
+frontend http
+mode http
+
+frontend healthz
+mode http
+monitor-uri /healthz
+
+frontend mirror
+mode http
+
+frontend stats
+  mode http
+  stats enable
+`
+
+func TestRender(t *testing.T) {
+	outputDir := t.TempDir()
+	var osArgs utils.OSArgs
+	_, err := flags.NewParser(&osArgs, flags.IgnoreUnknown).ParseArgs([]string{
+		"--render-manifests=testdata",
+		"--render-output-dir=" + outputDir,
+		"--configmap=haproxy-controller/haproxy-kubernetes-ingress",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Render(osArgs, []byte(haproxyConfig)))
+
+	cfg, err := os.ReadFile(filepath.Join(outputDir, "haproxy.cfg"))
+	require.NoError(t, err)
+	require.Contains(t, string(cfg), "maxconn 1234")
+	require.Contains(t, string(cfg), "backend app_svc_web_http")
+	require.Contains(t, string(cfg), "timeout server 42000")
+	require.Contains(t, string(cfg), "server SRV_1 10.0.0.1:8080 enabled")
+	require.Contains(t, string(cfg), "server SRV_2 10.0.0.2:8080 enabled")
+
+	hosts, err := os.ReadFile(filepath.Join(outputDir, "maps", "host.map"))
+	require.NoError(t, err)
+	require.Contains(t, string(hosts), "web.example.com")
+}
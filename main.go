@@ -89,7 +89,11 @@ func main() {
 		logger.Infof("Build from: %s", version.GitRepo)
 		logger.Infof("Build date: %s\n", version.GitCommitDate)
 
-		err := job.CRDRefresh(logger, osArgs)
+		crdRefresh := job.CRDRefresh
+		if osArgs.LeaderElection {
+			crdRefresh = job.CRDRefreshWithLease
+		}
+		err := crdRefresh(logger, osArgs)
 		if err != nil {
 			logger.Error(err)
 			os.Exit(1)
@@ -144,6 +148,15 @@ func main() {
 	annotations.SetDefaultValue("default-backend-port", strconv.Itoa(osArgs.DefaultBackendPort))
 	annotations.SetDefaultValue("ssl-certificate", defaultCertificate)
 
+	if len(osArgs.RenderManifests) > 0 {
+		if err = controller.Render(osArgs, haproxyConf); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		// exit, this is just a rendering
+		os.Exit(0)
+	}
+
 	// Start Controller
 	var chanSize int64 = int64(watch.DefaultChanSize * 6)
 	if osArgs.ChannelSize > 0 {
@@ -173,11 +186,11 @@ func main() {
 		WithRestClientSet(k.GetRestClientset()).
 		WithArgs(osArgs).Build()
 
-	isGatewayAPIInstalled := k.IsGatewayAPIInstalled(osArgs.GatewayControllerName)
+	gatewayAPIVersions := k.GetGatewayAPIVersions(osArgs.GatewayControllerName)
 
-	c.SetGatewayAPIInstalled(isGatewayAPIInstalled)
+	c.SetGatewayAPIVersions(gatewayAPIVersions)
 
-	go k.MonitorChanges(eventChan, stop, osArgs, isGatewayAPIInstalled)
+	go k.MonitorChanges(eventChan, stop, osArgs, gatewayAPIVersions)
 	go c.Start()
 	// Catch QUIT signals
 	signalC := make(chan os.Signal, 1)
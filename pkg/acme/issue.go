@@ -0,0 +1,310 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+)
+
+const (
+	// retryDelay is the delay before retrying to issue a certificate which failed.
+	retryDelay = time.Hour
+	// issuedDelay leaves time to the secret of an issued certificate to be synced before checking it again.
+	issuedDelay = 10 * time.Minute
+	// renewalCheckInterval is the interval between renewal checks of the certificates when there is no sync.
+	renewalCheckInterval = time.Hour
+)
+
+func (m *Manager) worker() {
+	ticker := time.NewTicker(renewalCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case j := <-m.jobs:
+			m.run(j)
+		case <-ticker.C:
+			m.checkRenewals()
+		}
+	}
+}
+
+// checkRenewals issues the certificates of the last sync which need to be renewed,
+// their secrets are read from the API server as the store is only accessed by syncs.
+func (m *Manager) checkRenewals() {
+	m.mu.Lock()
+	j := m.renewals
+	m.mu.Unlock()
+	if j.key == nil || !m.leaderElector.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	var certificates []certificate
+	for _, c := range j.certificates {
+		var crt []byte
+		secret, err := m.client.CoreV1().Secrets(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+		if err == nil {
+			crt = secret.Data[corev1.TLSCertKey]
+		} else if !k8serrors.IsNotFound(err) {
+			logger.Errorf("ACME certificate '%s/%s': %s", c.namespace, c.name, err)
+			continue
+		}
+		if NeedsRenewal(crt, c.hosts, j.cfg.renewBefore, time.Now()) {
+			certificates = append(certificates, c)
+		}
+	}
+	if len(certificates) == 0 {
+		return
+	}
+	j.certificates = certificates
+	m.run(j)
+}
+
+func (m *Manager) run(j job) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	if j.key == nil {
+		// certificates are issued once the account secret is synced and challenges are answered
+		logger.Error(m.createAccountKey(ctx, j.cfg))
+		return
+	}
+	client := &acme.Client{
+		Key:          j.key,
+		DirectoryURL: j.cfg.directory,
+		HTTPClient:   httpClient(j.cfg.directoryCA),
+		UserAgent:    "haproxy-kubernetes-ingress",
+	}
+	var contact []string
+	if j.cfg.email != "" {
+		contact = []string{"mailto:" + j.cfg.email}
+	}
+	_, err := client.Register(ctx, &acme.Account{Contact: contact}, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		logger.Errorf("ACME account registration: %s", err)
+		return
+	}
+	thumbprint, err := acme.JWKThumbprint(j.key.Public())
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	for _, c := range j.certificates {
+		id := fmt.Sprintf("%s/%s %s", c.namespace, c.name, strings.Join(c.hosts, ","))
+		if time.Now().Before(m.failures[id]) {
+			continue
+		}
+		if err = m.issue(ctx, client, thumbprint, c); err != nil {
+			logger.Errorf("ACME certificate '%s/%s': %s", c.namespace, c.name, err)
+			m.failures[id] = time.Now().Add(retryDelay)
+			continue
+		}
+		logger.Infof("ACME certificate '%s/%s' issued for %s", c.namespace, c.name, strings.Join(c.hosts, ", "))
+		m.failures[id] = time.Now().Add(issuedDelay)
+	}
+}
+
+// issue orders a certificate for the hosts, answers the challenges and writes the certificate in its secret.
+func (m *Manager) issue(ctx context.Context, client *acme.Client, thumbprint string, c certificate) error {
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(c.hosts...))
+	if err != nil {
+		return err
+	}
+	for _, u := range order.AuthzURLs {
+		z, err := client.GetAuthorization(ctx, u)
+		if err != nil {
+			return err
+		}
+		if z.Status == acme.StatusValid {
+			continue
+		}
+		var chal *acme.Challenge
+		for _, ch := range z.Challenges {
+			if ch.Type == "http-01" {
+				chal = ch
+			}
+		}
+		if chal == nil {
+			return fmt.Errorf("no HTTP-01 challenge offered for '%s'", z.Identifier.Value)
+		}
+		if err = m.checkChallenge(ctx, z.Identifier.Value, chal.Token, thumbprint); err != nil {
+			return err
+		}
+		if _, err = client.Accept(ctx, chal); err != nil {
+			return err
+		}
+		if _, err = client.WaitAuthorization(ctx, z.URI); err != nil {
+			return err
+		}
+	}
+	if order, err = client.WaitOrder(ctx, order.URI); err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: c.hosts[0]},
+		DNSNames: c.hosts,
+	}, key)
+	if err != nil {
+		return err
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return err
+	}
+	var crt []byte
+	for _, der := range chain {
+		crt = append(crt, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return m.storeCertificate(ctx, c, crt, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// checkChallenge waits for the HTTP frontend to answer the challenge before it is accepted,
+// the ACME server would otherwise fail the authorization.
+func (m *Manager) checkChallenge(ctx context.Context, host, token, thumbprint string) error {
+	want := token + "." + thumbprint
+	url := "http://" + m.checkAddr + rules.ACMEChallengePath + token
+	var body []byte
+	for range 30 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			body, err = io.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			if err == nil && string(body) == want {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+	return fmt.Errorf("challenge for '%s' not answered by the HTTP frontend, got '%s'", host, body)
+}
+
+// storeCertificate writes the certificate in its TLS secret, secrets not created by the controller are not overwritten.
+func (m *Manager) storeCertificate(ctx context.Context, c certificate, crt, key []byte) error {
+	secrets := m.client.CoreV1().Secrets(c.namespace)
+	data := map[string][]byte{
+		corev1.TLSCertKey:       crt,
+		corev1.TLSPrivateKeyKey: key,
+	}
+	secret, err := secrets.Get(ctx, c.name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        c.name,
+				Namespace:   c.namespace,
+				Annotations: map[string]string{ManagedByAnnotation: "true"},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if secret.Annotations[ManagedByAnnotation] != "true" {
+		return fmt.Errorf("secret is not managed by the controller, add the '%s: \"true\"' annotation to let it be overwritten", ManagedByAnnotation)
+	}
+	secret.Data = data
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// createAccountKey creates the secret holding the private key of the ACME account.
+func (m *Manager) createAccountKey(ctx context.Context, cfg config) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = m.client.CoreV1().Secrets(cfg.accountNs).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfg.accountName,
+			Namespace:   cfg.accountNs,
+			Annotations: map[string]string{ManagedByAnnotation: "true"},
+		},
+		Data: map[string][]byte{AccountKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})},
+	}, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err == nil {
+		logger.Infof("ACME account key created in secret '%s/%s'", cfg.accountNs, cfg.accountName)
+	}
+	return err
+}
+
+// parseKey parses a PEM encoded private key.
+func parseKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM private key in '%s'", AccountKey)
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
+
+// httpClient returns the client used to reach the ACME server, trusting ca in addition to the system CAs.
+func httpClient(ca []byte) *http.Client {
+	if len(ca) == 0 {
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(ca)
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+}
@@ -0,0 +1,470 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+)
+
+// acmeServer is an ACME server stand-in: it validates HTTP-01 challenges on the HTTP frontend
+// and issues certificates signed by its own CA. Request signatures are not checked.
+type acmeServer struct {
+	*httptest.Server
+	t *testing.T
+	// frontend is the address of the HTTP frontend the challenges are validated on
+	frontend string
+	// failValidation makes the server fail to validate the challenges
+	failValidation bool
+	// failFinalize makes the server reject the finalization of the order
+	failFinalize bool
+	ca           *x509.Certificate
+	caKey        *ecdsa.PrivateKey
+	mu           sync.Mutex
+	nonce        int
+	thumbprint   string
+	hosts        []string
+	authz        map[string]string
+	accepted     []string
+	orders       int
+	certificate  []byte
+}
+
+func newACMEServer(t *testing.T, frontend string) *acmeServer {
+	t.Helper()
+	s := &acmeServer{t: t, frontend: frontend}
+	var err error
+	s.caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ACME test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "ACME test CA"}}, &s.caKey.PublicKey, s.caKey)
+	require.NoError(t, err)
+	s.ca, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *acmeServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", s.nonce))
+	if r.URL.Path == "/directory" {
+		s.reply(w, http.StatusOK, map[string]string{
+			"newNonce":   s.URL + "/nonce",
+			"newAccount": s.URL + "/account",
+			"newOrder":   s.URL + "/order",
+			"revokeCert": s.URL + "/revoke",
+			"keyChange":  s.URL + "/key-change",
+		})
+		return
+	}
+	if r.URL.Path == "/nonce" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	var msg struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+	}
+	body, _ := io.ReadAll(r.Body)
+	require.NoError(s.t, json.Unmarshal(body, &msg))
+	payload, err := base64.RawURLEncoding.DecodeString(msg.Payload)
+	require.NoError(s.t, err)
+
+	switch path := r.URL.Path; {
+	case path == "/account":
+		w.Header().Set("Location", s.URL+"/account/1")
+		s.reply(w, http.StatusCreated, map[string]string{"status": "valid"})
+	case path == "/order":
+		var req struct {
+			Identifiers []acme.AuthzID `json:"identifiers"`
+		}
+		require.NoError(s.t, json.Unmarshal(payload, &req))
+		s.orders++
+		s.hosts = nil
+		s.authz = map[string]string{}
+		s.certificate = nil
+		for _, id := range req.Identifiers {
+			s.hosts = append(s.hosts, id.Value)
+			s.authz[id.Value] = acme.StatusPending
+		}
+		s.replyOrder(w, http.StatusCreated)
+	case path == "/order/1":
+		s.replyOrder(w, http.StatusOK)
+	case strings.HasPrefix(path, "/authz/"):
+		host := strings.TrimPrefix(path, "/authz/")
+		s.reply(w, http.StatusOK, map[string]any{
+			"status":     s.authz[host],
+			"identifier": acme.AuthzID{Type: "dns", Value: host},
+			"challenges": []map[string]string{
+				{"type": "dns-01", "url": s.URL + "/challenge/dns/" + host, "token": "dns-" + token(host), "status": acme.StatusPending},
+				s.challenge(host),
+			},
+		})
+	case strings.HasPrefix(path, "/challenge/http/"):
+		host := strings.TrimPrefix(path, "/challenge/http/")
+		s.accepted = append(s.accepted, host)
+		s.authz[host] = acme.StatusInvalid
+		if !s.failValidation && s.validate(host) {
+			s.authz[host] = acme.StatusValid
+		}
+		s.reply(w, http.StatusOK, s.challenge(host))
+	case path == "/finalize/1":
+		if s.failFinalize {
+			s.reply(w, http.StatusForbidden, map[string]any{"type": "urn:ietf:params:acme:error:rejectedIdentifier", "detail": "identifier rejected by policy", "status": http.StatusForbidden})
+			return
+		}
+		var req struct {
+			CSR string `json:"csr"`
+		}
+		require.NoError(s.t, json.Unmarshal(payload, &req))
+		s.issue(req.CSR)
+		s.replyOrder(w, http.StatusOK)
+	case path == "/certificate/1":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(s.certificate)
+	default:
+		s.reply(w, http.StatusNotFound, map[string]any{"type": "urn:ietf:params:acme:error:malformed", "status": http.StatusNotFound})
+	}
+}
+
+// setAccountKey sets the key of the account, the key authorization of a challenge ends with its thumbprint.
+func (s *acmeServer) setAccountKey(key crypto.Signer) {
+	thumbprint, err := acme.JWKThumbprint(key.Public())
+	require.NoError(s.t, err)
+	s.thumbprint = thumbprint
+}
+
+func (s *acmeServer) reply(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if status >= http.StatusBadRequest {
+		w.Header().Set("Content-Type", "application/problem+json")
+	}
+	w.WriteHeader(status)
+	require.NoError(s.t, json.NewEncoder(w).Encode(v))
+}
+
+func (s *acmeServer) replyOrder(w http.ResponseWriter, status int) {
+	order := map[string]any{
+		"status":   s.orderStatus(),
+		"finalize": s.URL + "/finalize/1",
+	}
+	var identifiers []acme.AuthzID
+	var authorizations []string
+	for _, host := range s.hosts {
+		identifiers = append(identifiers, acme.AuthzID{Type: "dns", Value: host})
+		authorizations = append(authorizations, s.URL+"/authz/"+host)
+	}
+	order["identifiers"] = identifiers
+	order["authorizations"] = authorizations
+	if s.certificate != nil {
+		order["certificate"] = s.URL + "/certificate/1"
+	}
+	w.Header().Set("Location", s.URL+"/order/1")
+	s.reply(w, status, order)
+}
+
+func (s *acmeServer) orderStatus() string {
+	if s.certificate != nil {
+		return acme.StatusValid
+	}
+	status := acme.StatusReady
+	for _, authz := range s.authz {
+		switch authz {
+		case acme.StatusInvalid:
+			return acme.StatusInvalid
+		case acme.StatusPending:
+			status = acme.StatusPending
+		}
+	}
+	return status
+}
+
+func (s *acmeServer) challenge(host string) map[string]string {
+	status := acme.StatusPending
+	if s.authz[host] != acme.StatusPending {
+		status = s.authz[host]
+	}
+	return map[string]string{"type": "http-01", "url": s.URL + "/challenge/http/" + host, "token": token(host), "status": status}
+}
+
+// validate fetches the key authorization of the challenge of host on the HTTP frontend, like an ACME server would.
+func (s *acmeServer) validate(host string) bool {
+	req, err := http.NewRequest(http.MethodGet, "http://"+s.frontend+rules.ACMEChallengePath+token(host), nil)
+	require.NoError(s.t, err)
+	req.Host = host
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode == http.StatusOK && string(body) == token(host)+"."+s.thumbprint
+}
+
+// issue signs the certificate of the CSR with the CA of the server.
+func (s *acmeServer) issue(encodedCSR string) {
+	der, err := base64.RawURLEncoding.DecodeString(encodedCSR)
+	require.NoError(s.t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(s.t, err)
+	require.NoError(s.t, csr.CheckSignature())
+	assert.ElementsMatch(s.t, s.hosts, csr.DNSNames)
+	crt, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, s.ca, csr.PublicKey, s.caKey)
+	require.NoError(s.t, err)
+	s.certificate = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.ca.Raw})...)
+}
+
+func token(host string) string {
+	return strings.ReplaceAll(host, ".", "-")
+}
+
+// newFrontend returns an HTTP frontend stand-in answering the challenges of hosts like the ReqACMEChallenge rule.
+func newFrontend(t *testing.T, key crypto.Signer, hosts ...string) *httptest.Server {
+	t.Helper()
+	thumbprint, err := acme.JWKThumbprint(key.Public())
+	require.NoError(t, err)
+	frontend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, host := range hosts {
+			if r.Host == host && r.URL.Path == rules.ACMEChallengePath+token(host) {
+				_, _ = w.Write([]byte(token(host) + "." + thumbprint))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(frontend.Close)
+	return frontend
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// newTestIssue returns a manager checking challenges on frontend and the client of an account registered on server.
+func newTestIssue(t *testing.T, server *acmeServer, frontend *httptest.Server, key crypto.Signer) (*Manager, *acme.Client, string) {
+	t.Helper()
+	m := &Manager{
+		client:    fake.NewSimpleClientset(),
+		checkAddr: strings.TrimPrefix(frontend.URL, "http://"),
+		failures:  map[string]time.Time{},
+	}
+	client := &acme.Client{Key: key, DirectoryURL: server.URL + "/directory"}
+	_, err := client.Register(context.Background(), &acme.Account{}, acme.AcceptTOS)
+	require.NoError(t, err)
+	thumbprint, err := acme.JWKThumbprint(key.Public())
+	require.NoError(t, err)
+	return m, client, thumbprint
+}
+
+func TestRunIssuesCertificate(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com", "www.example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	m, _, _ := newTestIssue(t, server, frontend, key)
+	c := certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com", "www.example.com"}}
+
+	m.run(job{cfg: config{directory: server.URL + "/directory", email: "admin@example.com"}, key: key, certificates: []certificate{c}})
+
+	assert.ElementsMatch(t, []string{"example.com", "www.example.com"}, server.accepted)
+	secret, err := m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, "true", secret.Annotations[ManagedByAnnotation])
+	assert.Equal(t, server.certificate, secret.Data[corev1.TLSCertKey])
+	assert.False(t, NeedsRenewal(secret.Data[corev1.TLSCertKey], c.hosts, 30*24*time.Hour, time.Now()))
+	// the private key is the key of the certificate
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	crt, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	crtKey, err := parseKey(secret.Data[corev1.TLSPrivateKeyKey])
+	require.NoError(t, err)
+	assert.True(t, crtKey.Public().(*ecdsa.PublicKey).Equal(crt.PublicKey))
+	// the certificate is not ordered again before its secret is synced
+	id := "default/example-tls example.com,www.example.com"
+	assert.WithinDuration(t, time.Now().Add(issuedDelay), m.failures[id], time.Minute)
+	m.run(job{cfg: config{directory: server.URL + "/directory"}, key: key, certificates: []certificate{c}})
+	assert.Equal(t, 1, server.orders)
+}
+
+func TestRunRetriesFailedCertificates(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	server.failFinalize = true
+	m, _, _ := newTestIssue(t, server, frontend, key)
+	c := certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com"}}
+
+	m.run(job{cfg: config{directory: server.URL + "/directory"}, key: key, certificates: []certificate{c}})
+	_, err := m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	assert.Error(t, err)
+	assert.WithinDuration(t, time.Now().Add(retryDelay), m.failures["default/example-tls example.com"], time.Minute)
+
+	// the failed certificate is retried after retryDelay only
+	server.failFinalize = false
+	m.run(job{cfg: config{directory: server.URL + "/directory"}, key: key, certificates: []certificate{c}})
+	assert.Equal(t, 1, server.orders)
+	m.failures["default/example-tls example.com"] = time.Now()
+	m.run(job{cfg: config{directory: server.URL + "/directory"}, key: key, certificates: []certificate{c}})
+	assert.Equal(t, 2, server.orders)
+	_, err = m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+// fakeElector reports the replica as leader or not.
+type fakeElector bool
+
+func (e fakeElector) IsLeader() bool { return bool(e) }
+
+func (fakeElector) Run(stop chan struct{}) {}
+
+func TestCheckRenewals(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	m, _, _ := newTestIssue(t, server, frontend, key)
+	cfg := config{directory: server.URL + "/directory", renewBefore: 30 * 24 * time.Hour}
+	m.renewals = job{cfg: cfg, key: key, certificates: []certificate{{namespace: "default", name: "example-tls", hosts: []string{"example.com"}}}}
+
+	// only the leader issues certificates
+	m.leaderElector = fakeElector(false)
+	m.checkRenewals()
+	assert.Equal(t, 0, server.orders)
+
+	// the missing certificate is issued without a sync
+	m.leaderElector = fakeElector(true)
+	m.checkRenewals()
+	assert.Equal(t, 1, server.orders)
+	_, err := m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// the issued certificate is only renewed once it expires within the renewal period
+	clear(m.failures)
+	m.checkRenewals()
+	assert.Equal(t, 1, server.orders)
+	m.renewals.cfg.renewBefore = 100 * 24 * time.Hour
+	m.checkRenewals()
+	assert.Equal(t, 2, server.orders)
+}
+
+func TestIssueChallengeNotAnswered(t *testing.T) {
+	key := newTestKey(t)
+	// the frontend doesn't answer the challenge of www.example.com
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	m, client, thumbprint := newTestIssue(t, server, frontend, key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := m.issue(ctx, client, thumbprint, certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com", "www.example.com"}})
+	require.Error(t, err)
+	// challenges are not accepted before the frontend answers them
+	assert.NotContains(t, server.accepted, "www.example.com")
+	_, err = m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestIssueValidationFailed(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	server.failValidation = true
+	m, client, thumbprint := newTestIssue(t, server, frontend, key)
+
+	err := m.issue(context.Background(), client, thumbprint, certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com"}})
+	var authzErr *acme.AuthorizationError
+	require.ErrorAs(t, err, &authzErr)
+	assert.Equal(t, []string{"example.com"}, server.accepted)
+	_, err = m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestIssueFinalizeRejected(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	server.failFinalize = true
+	m, client, thumbprint := newTestIssue(t, server, frontend, key)
+
+	err := m.issue(context.Background(), client, thumbprint, certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com"}})
+	var acmeErr *acme.Error
+	require.ErrorAs(t, err, &acmeErr)
+	assert.Equal(t, http.StatusForbidden, acmeErr.StatusCode)
+	_, err = m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestIssueSecretNotManaged(t *testing.T) {
+	key := newTestKey(t)
+	frontend := newFrontend(t, key, "example.com")
+	server := newACMEServer(t, strings.TrimPrefix(frontend.URL, "http://"))
+	server.setAccountKey(key)
+	m, client, thumbprint := newTestIssue(t, server, frontend, key)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-tls"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("user certificate")},
+	}
+	_, err := m.client.CoreV1().Secrets("default").Create(context.Background(), secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = m.issue(context.Background(), client, thumbprint, certificate{namespace: "default", name: "example-tls", hosts: []string{"example.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ManagedByAnnotation)
+	secret, err = m.client.CoreV1().Secrets("default").Get(context.Background(), "example-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("user certificate"), secret.Data[corev1.TLSCertKey])
+}
@@ -0,0 +1,275 @@
+package acme
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+var logger = utils.GetLogger()
+
+const (
+	// AccountKey is the secret key holding the PEM encoded private key of the ACME account.
+	AccountKey = "tls.key"
+	// ManagedByAnnotation marks the secrets written by the controller, other secrets are never overwritten.
+	ManagedByAnnotation = "haproxy.org/acme-managed"
+)
+
+// Manager obtains and renews the certificates of Ingress hosts from an ACME server.
+// HTTP-01 challenges are answered by the HTTP frontend of every replica with the ReqACMEChallenge rule.
+// Certificates are issued by the leader in the background and stored in the TLS secrets of the Ingresses,
+// they are then loaded by HAProxy through the runtime API like any other certificate secret.
+// Certificates are checked for renewal on each sync and, between syncs, every renewalCheckInterval by the worker.
+type Manager struct {
+	client        kubernetes.Interface
+	leaderElector leader.Elector
+	podNs         string
+	// checkAddr is the address of the HTTP frontend used to check challenges before accepting them
+	checkAddr string
+	ingresses []*store.Ingress
+	jobs      chan job
+	failures  map[string]time.Time
+	// renewals are the certificates of the last sync, checked for renewal by the worker
+	renewals job
+	mu       sync.Mutex
+	running  bool
+}
+
+type config struct {
+	directory   string
+	email       string
+	directoryCA []byte
+	accountNs   string
+	accountName string
+	renewBefore time.Duration
+}
+
+// certificate is a TLS secret to issue for hosts.
+type certificate struct {
+	namespace string
+	name      string
+	hosts     []string
+}
+
+type job struct {
+	cfg          config
+	key          crypto.Signer
+	certificates []certificate
+}
+
+// New returns the ACME manager, certificates are only issued when leaderElector reports the replica as leader.
+func New(client kubernetes.Interface, leaderElector leader.Elector, podNs string, osArgs utils.OSArgs) *Manager {
+	m := &Manager{
+		client:        client,
+		leaderElector: leaderElector,
+		podNs:         podNs,
+		jobs:          make(chan job, 1),
+		failures:      make(map[string]time.Time),
+	}
+	if !osArgs.DisableHTTP {
+		addr := "127.0.0.1"
+		if osArgs.DisableIPV4 {
+			addr = "::1"
+		} else if osArgs.IPV4BindAddr != "" && osArgs.IPV4BindAddr != "0.0.0.0" {
+			addr = osArgs.IPV4BindAddr
+		}
+		m.checkAddr = net.JoinHostPort(addr, strconv.FormatInt(osArgs.HTTPBindPort, 10))
+	}
+	return m
+}
+
+// AddIngress registers an Ingress handled by the controller in the current sync.
+func (m *Manager) AddIngress(ing *store.Ingress) {
+	if ing.Status == store.DELETED {
+		return
+	}
+	m.ingresses = append(m.ingresses, ing)
+}
+
+func (m *Manager) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
+	ingresses := m.ingresses
+	m.ingresses = nil
+	m.setRenewals(job{})
+	cfg, err := m.config(k)
+	if err != nil || cfg.directory == "" {
+		return err
+	}
+	var key crypto.Signer
+	secret, errSecret := k.GetSecret(cfg.accountNs, cfg.accountName)
+	if errSecret == nil {
+		if key, err = parseKey(secret.Data[AccountKey]); err != nil {
+			return fmt.Errorf("ACME account secret '%s/%s': %w", cfg.accountNs, cfg.accountName, err)
+		}
+		thumbprint, errThumb := acme.JWKThumbprint(key.Public())
+		if errThumb != nil {
+			return errThumb
+		}
+		err = h.AddRule(h.FrontHTTP, rules.ReqACMEChallenge{Thumbprint: thumbprint}, false)
+		if err != nil {
+			return err
+		}
+	}
+	if m.client == nil || !m.leaderElector.IsLeader() {
+		return nil
+	}
+	if m.checkAddr == "" {
+		return errors.New("ACME certificates can't be issued, the HTTP frontend is disabled")
+	}
+	var managed, certificates []certificate
+	for _, ing := range ingresses {
+		managed = append(managed, m.certificates(k, ing)...)
+	}
+	for _, c := range managed {
+		var crt []byte
+		if secret, _ := k.GetSecret(c.namespace, c.name); secret != nil {
+			crt = secret.Data["tls.crt"]
+		}
+		if NeedsRenewal(crt, c.hosts, cfg.renewBefore, time.Now()) {
+			certificates = append(certificates, c)
+		}
+	}
+	if key != nil {
+		m.setRenewals(job{cfg: cfg, key: key, certificates: managed})
+	}
+	if key != nil && len(certificates) == 0 {
+		return nil
+	}
+	m.schedule(job{cfg: cfg, key: key, certificates: certificates})
+	return nil
+}
+
+// config reads the ACME settings of the ConfigMap.
+func (m *Manager) config(k store.K8s) (cfg config, err error) {
+	cm := k.ConfigMaps.Main.Annotations
+	cfg.directory = common.GetValue("acme-directory", cm)
+	if cfg.directory == "" {
+		return cfg, nil
+	}
+	cfg.email = common.GetValue("acme-email", cm)
+	cfg.renewBefore = 30 * 24 * time.Hour
+	if renewBefore := common.GetValue("acme-renew-before", cm); renewBefore != "" {
+		if cfg.renewBefore, err = time.ParseDuration(renewBefore); err != nil || cfg.renewBefore <= 0 {
+			return cfg, fmt.Errorf("annotation 'acme-renew-before': invalid duration '%s'", renewBefore)
+		}
+	}
+	cfg.accountNs, cfg.accountName = m.podNs, "haproxy-ingress-acme-account"
+	if common.GetValue("acme-account-secret", cm) != "" {
+		var ns string
+		if ns, cfg.accountName, err = common.GetK8sPath("acme-account-secret", cm); err != nil {
+			return cfg, fmt.Errorf("annotation 'acme-account-secret': %w", err)
+		}
+		if ns != "" {
+			cfg.accountNs = ns
+		}
+	}
+	if cfg.accountNs == "" {
+		return cfg, errors.New("annotation 'acme-account-secret': namespace is required")
+	}
+	secret, err := annotations.Secret("acme-directory-ca", m.podNs, k, cm)
+	if err != nil {
+		return cfg, err
+	}
+	if secret != nil {
+		cfg.directoryCA = secret.Data["ca.crt"]
+		if cfg.directoryCA == nil {
+			cfg.directoryCA = secret.Data["tls.crt"]
+		}
+	}
+	return cfg, nil
+}
+
+// certificates returns the TLS secrets of an Ingress enabling ACME with their hosts.
+func (m *Manager) certificates(k store.K8s, ing *store.Ingress) (certificates []certificate) {
+	enabled, err := annotations.Bool("acme-enable", ing.Annotations, k.ConfigMaps.Main.Annotations)
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': %s", ing.Namespace, ing.Name, err)
+		return nil
+	}
+	if !enabled {
+		return nil
+	}
+	hosts := map[string][]string{}
+	for _, tls := range ing.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		if strings.HasPrefix(tls.Host, "*.") {
+			logger.Warningf("Ingress '%s/%s': ACME HTTP-01 challenges can't validate wildcard host '%s'", ing.Namespace, ing.Name, tls.Host)
+			continue
+		}
+		hosts[tls.SecretName] = append(hosts[tls.SecretName], tls.Host)
+	}
+	for name, secretHosts := range hosts {
+		slices.Sort(secretHosts)
+		certificates = append(certificates, certificate{namespace: ing.Namespace, name: name, hosts: secretHosts})
+	}
+	return certificates
+}
+
+// NeedsRenewal returns true if the PEM encoded certificate is missing, doesn't cover all hosts
+// or expires within renewBefore.
+func NeedsRenewal(crt []byte, hosts []string, renewBefore time.Duration, now time.Time) bool {
+	block, _ := pem.Decode(crt)
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			return true
+		}
+	}
+	return cert.NotAfter.Add(-renewBefore).Before(now)
+}
+
+// schedule replaces the pending job and starts the worker if needed.
+func (m *Manager) schedule(j job) {
+	select {
+	case <-m.jobs:
+	default:
+	}
+	m.jobs <- j
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.start()
+}
+
+// setRenewals replaces the certificates checked for renewal by the worker and starts it if needed.
+func (m *Manager) setRenewals(j job) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.renewals = j
+	if len(j.certificates) > 0 {
+		m.start()
+	}
+}
+
+// start starts the worker if it is not running, m.mu must be held.
+func (m *Manager) start() {
+	if !m.running {
+		m.running = true
+		go m.worker()
+	}
+}
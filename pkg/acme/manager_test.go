@@ -0,0 +1,51 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NeedsRenewal(t *testing.T) {
+	now := time.Now()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(60 * 24 * time.Hour),
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"}}, &key.PublicKey, key)
+	require.NoError(t, err)
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	month := 30 * 24 * time.Hour
+
+	assert.True(t, NeedsRenewal(nil, []string{"example.com"}, month, now), "missing certificate")
+	assert.True(t, NeedsRenewal([]byte("invalid"), []string{"example.com"}, month, now), "invalid certificate")
+	assert.False(t, NeedsRenewal(crt, []string{"example.com", "www.example.com"}, month, now), "valid certificate")
+	assert.True(t, NeedsRenewal(crt, []string{"example.com", "api.example.com"}, month, now), "host not covered")
+	assert.True(t, NeedsRenewal(crt, []string{"example.com"}, month, now.Add(31*24*time.Hour)), "expiring certificate")
+}
+
+func Test_ParseKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	parsed, err := parseKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsed.Public()))
+
+	_, err = parseKey([]byte("invalid"))
+	assert.Error(t, err)
+}
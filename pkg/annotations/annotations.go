@@ -29,7 +29,13 @@ type Annotations interface {
 	Global(g *models.Global, l *models.LogTargets) []Annotation
 	Defaults(d *models.Defaults) []Annotation
 	Backend(b *models.Backend, s store.K8s, c certs.Certificates) []Annotation
-	Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annotation
+	Frontend(i *store.Ingress, r *rules.List, m maps.Maps, c certs.Certificates) []Annotation
+	Canary(acls *[]string) []Annotation
+	RouteMatch(acls *[]string) []Annotation
+	ClientAuth(auth *certs.ClientAuth, i *store.Ingress, r *rules.List, c certs.Certificates) []Annotation
+	TLSPolicy(policy *certs.TLSPolicy) []Annotation
+	SecurityHeaders(r *rules.List) []Annotation
+	Cache(b *models.Backend, c *models.Cache) []Annotation
 	Secret(name, defaultNs string, k store.K8s, annotations ...map[string]string) (secret *store.Secret, err error)
 	Timeout(name string, annotations ...map[string]string) (out *int64, err error)
 	String(name string, annotations ...map[string]string) string
@@ -103,11 +109,13 @@ func (a annImpl) Defaults(d *models.Defaults) []Annotation {
 	}
 }
 
-func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annotation {
+func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps, c certs.Certificates) []Annotation {
 	reqRateLimit := ingress.NewReqRateLimit(r)
 	httpsRedirect := ingress.NewHTTPSRedirect(r, i)
 	hostRedirect := ingress.NewHostRedirect(r)
 	reqAuth := ingress.NewReqAuth(r, i)
+	reqAuthRequest := ingress.NewReqAuthRequest(r)
+	reqJWT := ingress.NewReqJWT(r, i, c)
 	reqCapture := ingress.NewReqCapture(r)
 	resSetCORS := ingress.NewResSetCORS(r)
 	return []Annotation{
@@ -132,6 +140,19 @@ func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annota
 		reqAuth.NewAnnotation("auth-type"),
 		reqAuth.NewAnnotation("auth-realm"),
 		reqAuth.NewAnnotation("auth-secret"),
+		reqAuthRequest.NewAnnotation("auth-url"),
+		reqAuthRequest.NewAnnotation("auth-signin"),
+		reqAuthRequest.NewAnnotation("auth-request-headers"),
+		reqAuthRequest.NewAnnotation("auth-response-headers"),
+		// always put jwt-secret and jwt-jwks annotations before other jwt annotations
+		reqJWT.NewAnnotation("jwt-secret"),
+		reqJWT.NewAnnotation("jwt-jwks"),
+		reqJWT.NewAnnotation("jwt-algorithms"),
+		reqJWT.NewAnnotation("jwt-issuer"),
+		reqJWT.NewAnnotation("jwt-audience"),
+		reqJWT.NewAnnotation("jwt-required-claims"),
+		reqJWT.NewAnnotation("jwt-claim-headers"),
+		reqJWT.NewAnnotation("jwt-deny-status"),
 		reqCapture.NewAnnotation("request-capture"),
 		reqCapture.NewAnnotation("request-capture-len"),
 		// always put cors-enable annotation before any oth
@@ -145,7 +166,80 @@ func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annota
 	}
 }
 
+// RouteMatch returns the annotations adding match conditions to the routes of an Ingress.
+func (a annImpl) RouteMatch(acls *[]string) []Annotation {
+	routeMatch := ingress.NewRouteMatch(acls)
+	return []Annotation{
+		routeMatch.NewAnnotation("route-match-header"),
+		routeMatch.NewAnnotation("route-match-query"),
+		routeMatch.NewAnnotation("route-match-method"),
+		routeMatch.NewAnnotation("route-match-cookie"),
+	}
+}
+
+// ClientAuth returns the annotations requiring client certificates on the hosts of an Ingress, client-ca is processed last.
+func (a annImpl) ClientAuth(auth *certs.ClientAuth, i *store.Ingress, r *rules.List, c certs.Certificates) []Annotation {
+	clientAuth := ingress.NewClientAuth(auth, r, i, c)
+	return []Annotation{
+		clientAuth.NewAnnotation("client-crt-optional"),
+		clientAuth.NewAnnotation("client-crl"),
+		clientAuth.NewAnnotation("client-ca"),
+	}
+}
+
+// TLSPolicy returns the annotations setting the TLS parameters of the hosts of an Ingress.
+func (a annImpl) TLSPolicy(policy *certs.TLSPolicy) []Annotation {
+	tlsPolicy := ingress.NewTLSPolicy(policy)
+	return []Annotation{
+		tlsPolicy.NewAnnotation("ssl-min-ver"),
+		tlsPolicy.NewAnnotation("ssl-ciphers"),
+		tlsPolicy.NewAnnotation("ssl-ciphersuites"),
+		tlsPolicy.NewAnnotation("ssl-curves"),
+		tlsPolicy.NewAnnotation("tls-alpn"),
+	}
+}
+
+// SecurityHeaders returns the annotations setting security headers on HTTPS responses, hsts is processed after its parameters.
+func (a annImpl) SecurityHeaders(r *rules.List) []Annotation {
+	securityHeaders := ingress.NewSecurityHeaders(r)
+	return []Annotation{
+		securityHeaders.NewAnnotation("hsts-max-age"),
+		securityHeaders.NewAnnotation("hsts-include-subdomains"),
+		securityHeaders.NewAnnotation("hsts-preload"),
+		securityHeaders.NewAnnotation("hsts"),
+		securityHeaders.NewAnnotation("x-frame-options"),
+		securityHeaders.NewAnnotation("x-content-type-options"),
+		securityHeaders.NewAnnotation("referrer-policy"),
+		securityHeaders.NewAnnotation("content-security-policy"),
+	}
+}
+
+func (a annImpl) Canary(acls *[]string) []Annotation {
+	canary := ingress.NewCanary(acls)
+	return []Annotation{
+		canary.NewAnnotation("canary-weight"),
+		canary.NewAnnotation("canary-by-header"),
+		canary.NewAnnotation("canary-by-header-value"),
+		canary.NewAnnotation("canary-by-header-pattern"),
+		canary.NewAnnotation("canary-by-cookie"),
+	}
+}
+
+// Cache returns the annotations of the backend cache, cache-enable is processed last.
+func (a annImpl) Cache(b *models.Backend, c *models.Cache) []Annotation {
+	cache := service.NewCache(b, c)
+	return []Annotation{
+		cache.NewAnnotation("cache-size"),
+		cache.NewAnnotation("cache-max-object-size"),
+		cache.NewAnnotation("cache-max-age"),
+		cache.NewAnnotation("cache-vary"),
+		cache.NewAnnotation("cache-enable"),
+	}
+}
+
 func (a annImpl) Backend(b *models.Backend, s store.K8s, c certs.Certificates) []Annotation {
+	retry := service.NewRetry(b)
+	observe := service.NewObserve(b)
 	annotations := []Annotation{
 		service.NewAbortOnClose("abortonclose", b),
 		service.NewTimeoutCheck("timeout-check", b),
@@ -153,19 +247,38 @@ func (a annImpl) Backend(b *models.Backend, s store.K8s, c certs.Certificates) [
 		service.NewLoadBalance("load-balance", b),
 		service.NewCheck("check", b),
 		service.NewCheckInter("check-interval", b),
+		observe.NewAnnotation("error-limit"),
+		observe.NewAnnotation("on-error"),
+		// always put observe annotation after check and the other observe annotations
+		observe.NewAnnotation("observe"),
 		service.NewCookie("cookie-persistence", b),
 		service.NewMaxconn("pod-maxconn", b),
+		service.NewMaxqueue("pod-maxqueue", b),
 		service.NewSendProxy("send-proxy-protocol", b),
 		// Order is important for ssl annotations so they don't conflict
 		service.NewSSL("server-ssl", b),
 		service.NewCrt("server-crt", c, b),
 		service.NewCA("server-ca", c, b),
 		service.NewProto("server-proto", b),
+		retry.NewAnnotation("retries"),
+		// retry-non-idempotent is used by retry-on
+		retry.NewAnnotation("retry-non-idempotent"),
+		retry.NewAnnotation("retry-on"),
+		// redispatch-interval is used by redispatch
+		retry.NewAnnotation("redispatch-interval"),
+		retry.NewAnnotation("redispatch"),
 	}
 	if b.Mode == "http" {
+		compression := service.NewCompression(b)
 		annotations = append(annotations,
 			service.NewCheckHTTP("check-http", b),
 			service.NewForwardedFor("forwarded-for", b),
+			compression.NewAnnotation("compression-algorithms"),
+			compression.NewAnnotation("compression-types"),
+			compression.NewAnnotation("compression-min-size"),
+			compression.NewAnnotation("compression-offload"),
+			// always put compression-enable annotation after other compression annotations
+			compression.NewAnnotation("compression-enable"),
 		)
 	}
 	return annotations
@@ -249,35 +362,73 @@ func Timeout(name string, annotations ...map[string]string) (out *int64, err err
 // SpecificAnnotations is a set of annotations that uses rules to produce specific configuration with rule ID in configuration file.
 // These annotations in an ingress can't be merged with other ingresses annotations when these ingresses point to the same service because specific paths must be treated specifically.
 var SpecificAnnotations = map[string]struct{}{
-	"backend-config-snippet":  {},
-	"deny-list":               {},
-	"blacklist":               {},
-	"allow-list":              {},
-	"whitelist":               {},
-	"src-ip-header":           {},
-	"auth-type":               {},
-	"auth-realm":              {},
-	"auth-secret":             {},
-	"ssl-redirect":            {},
-	"ssl-redirect-port":       {},
-	"ssl-redirect-code":       {},
-	"request-redirect":        {},
-	"request-redirect-code":   {},
-	"request-capture":         {},
-	"request-capture-len":     {},
-	"path-rewrite":            {},
-	"rate-limit-requests":     {},
-	"rate-limit-period":       {},
-	"rate-limit-size":         {},
-	"rate-limit-status-code":  {},
-	"request-set-header":      {},
-	"response-set-header":     {},
-	"set-host":                {},
-	"cors-enable":             {},
-	"cors-allow-origin":       {},
-	"cors-allow-methods":      {},
-	"cors-allow-headers":      {},
-	"cors-max-age":            {},
-	"cors-allow-credentials":  {},
-	"cors-respond-to-options": {},
+	"backend-config-snippet":   {},
+	"deny-list":                {},
+	"blacklist":                {},
+	"allow-list":               {},
+	"whitelist":                {},
+	"src-ip-header":            {},
+	"auth-type":                {},
+	"auth-realm":               {},
+	"auth-secret":              {},
+	"auth-url":                 {},
+	"auth-signin":              {},
+	"auth-request-headers":     {},
+	"auth-response-headers":    {},
+	"jwt-secret":               {},
+	"jwt-jwks":                 {},
+	"jwt-algorithms":           {},
+	"jwt-issuer":               {},
+	"jwt-audience":             {},
+	"jwt-required-claims":      {},
+	"jwt-claim-headers":        {},
+	"jwt-deny-status":          {},
+	"canary-weight":            {},
+	"canary-by-header":         {},
+	"canary-by-header-value":   {},
+	"canary-by-header-pattern": {},
+	"canary-by-cookie":         {},
+	"route-match-header":       {},
+	"route-match-query":        {},
+	"route-match-method":       {},
+	"route-match-cookie":       {},
+	"path-regex":               {},
+	"client-ca":                {},
+	"client-crt-optional":      {},
+	"client-crl":               {},
+	"ssl-min-ver":              {},
+	"ssl-ciphers":              {},
+	"ssl-ciphersuites":         {},
+	"ssl-curves":               {},
+	"tls-alpn":                 {},
+	"hsts":                     {},
+	"hsts-max-age":             {},
+	"hsts-include-subdomains":  {},
+	"hsts-preload":             {},
+	"x-frame-options":          {},
+	"x-content-type-options":   {},
+	"referrer-policy":          {},
+	"content-security-policy":  {},
+	"ssl-redirect":             {},
+	"ssl-redirect-port":        {},
+	"ssl-redirect-code":        {},
+	"request-redirect":         {},
+	"request-redirect-code":    {},
+	"request-capture":          {},
+	"request-capture-len":      {},
+	"path-rewrite":             {},
+	"rate-limit-requests":      {},
+	"rate-limit-period":        {},
+	"rate-limit-size":          {},
+	"rate-limit-status-code":   {},
+	"request-set-header":       {},
+	"response-set-header":      {},
+	"set-host":                 {},
+	"cors-enable":              {},
+	"cors-allow-origin":        {},
+	"cors-allow-methods":       {},
+	"cors-allow-headers":       {},
+	"cors-max-age":             {},
+	"cors-allow-credentials":   {},
+	"cors-respond-to-options":  {},
 }
@@ -1,11 +1,15 @@
 package annotations
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
@@ -16,7 +20,7 @@ func (h ConfigSnippetHandler) Update(k store.K8s, api haproxy.HAProxy, ann Annot
 	// We get the configmap configsnippet value
 	configmapCfgSnippetValue := getConfigmapConfigSnippet(k.BackendsWithNoConfigSnippets, api)
 	// We pass the configmap config snippet value to be inserted at top of the comment section for every config snippet section
-	return updateConfigSnippet(api, configmapCfgSnippetValue)
+	return updateConfigSnippet(k, api, configmapCfgSnippetValue)
 }
 
 func getConfigmapConfigSnippet(backendsWithNoConfigSnippets map[string]struct{}, api api.HAProxyClient) []string {
@@ -43,7 +47,29 @@ func getConfigmapConfigSnippet(backendsWithNoConfigSnippets map[string]struct{},
 	return configmapCfgSnippetValue
 }
 
-func updateConfigSnippet(api api.HAProxyClient, configmapCfgSnippetValue []string) (err error) {
+// reportDisabledCfgSnippet reports a config snippet disabled after an HAProxy error on the resource it comes from.
+func reportDisabledCfgSnippet(k store.K8s, backend, origin string) {
+	var owner rc.Owner
+	switch {
+	case origin == "configmap":
+		owner = k.ConfigMaps.Main.Owner()
+	case strings.HasPrefix(origin, INGRESS_NAME_PREFIX):
+		namespace, name, _ := strings.Cut(strings.TrimPrefix(origin, INGRESS_NAME_PREFIX), "/")
+		owner = rc.NewOwner(rc.INGRESS, namespace, name)
+	case strings.HasPrefix(origin, SERVICE_NAME_PREFIX):
+		namespace, name, _ := strings.Cut(strings.TrimPrefix(origin, SERVICE_NAME_PREFIX), "/")
+		owner = rc.NewOwner(rc.SERVICE, namespace, name)
+	default:
+		return
+	}
+	err := fmt.Errorf("backend-config-snippet disabled in backend '%s' as HAProxy rejected it, it is enabled again once modified", backend)
+	if origin == "configmap" {
+		err = errors.New("backend-config-snippet disabled as HAProxy rejected it, it is enabled again once modified")
+	}
+	events.Error(owner, events.REASON_CONFIG_SNIPPET_DISABLED, err)
+}
+
+func updateConfigSnippet(k store.K8s, api api.HAProxyClient, configmapCfgSnippetValue []string) (err error) {
 	errs := utils.Errors{}
 	// Then we iterate over each backend
 	for backend, cfgDataByOrigin := range cfgSnippet.backends {
@@ -72,6 +98,13 @@ func updateConfigSnippet(api api.HAProxyClient, configmapCfgSnippetValue []strin
 				instance.ReloadIf(
 					cfgData.status == store.ADDED || cfgData.status == store.MODIFIED,
 					"config snippet from %s has been disabled", origin)
+				// The disabled configsnippet has not been reseen so delete it.
+				if cfgData.status == store.DELETED {
+					delete(cfgSnippet.backends[backend], origin)
+					continue
+				}
+				reportDisabledCfgSnippet(k, backend, origin)
+				cfgData.status = store.DELETED
 				continue
 			}
 			instance.ReloadIf(cfgData.status != store.EMPTY,
@@ -40,7 +40,12 @@ func GetK8sPath(annotationName string, annotations ...map[string]string) (ns, na
 
 var DefaultValues = map[string]string{
 	"auth-realm":             "Protected Content",
+	"auth-request-headers":   "Authorization,Cookie",
+	"cache-max-age":          "60",
+	"cache-size":             "64",
 	"check":                  "true",
+	"compression-algorithms": "gzip",
+	"compression-types":      "text/html text/plain text/css text/javascript application/javascript application/json image/svg+xml",
 	"cors-allow-origin":      "*",
 	"cors-allow-methods":     "*",
 	"cors-allow-headers":     "*",
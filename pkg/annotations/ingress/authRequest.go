@@ -0,0 +1,101 @@
+package ingress
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+type ReqAuthRequest struct {
+	authRule *rules.ReqAuthRequest
+	rules    *rules.List
+}
+
+type ReqAuthRequestAnn struct {
+	parent *ReqAuthRequest
+	name   string
+}
+
+func NewReqAuthRequest(rules *rules.List) *ReqAuthRequest {
+	return &ReqAuthRequest{rules: rules}
+}
+
+func (p *ReqAuthRequest) NewAnnotation(n string) ReqAuthRequestAnn {
+	return ReqAuthRequestAnn{name: n, parent: p}
+}
+
+func (a ReqAuthRequestAnn) GetName() string {
+	return a.name
+}
+
+func (a ReqAuthRequestAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "auth-url":
+		if err = validateAuthURL(input); err != nil {
+			return err
+		}
+		a.parent.authRule = &rules.ReqAuthRequest{URL: input}
+		a.parent.rules.Add(a.parent.authRule)
+	case "auth-signin":
+		if a.parent.authRule == nil {
+			return err
+		}
+		if err = validateAuthURL(input); err != nil {
+			return err
+		}
+		a.parent.authRule.SigninURL = input
+	case "auth-request-headers":
+		if a.parent.authRule == nil {
+			return err
+		}
+		a.parent.authRule.RequestHeaders, err = headerNames(input)
+	case "auth-response-headers":
+		if a.parent.authRule == nil {
+			return err
+		}
+		a.parent.authRule.ResponseHeaders, err = headerNames(input)
+	default:
+		err = fmt.Errorf("unknown auth-request annotation '%s'", a.name)
+	}
+	return err
+}
+
+// validateAuthURL checks that the input is an absolute http(s) URL usable as a HAProxy argument.
+func validateAuthURL(input string) error {
+	if strings.ContainsAny(input, " \t\r\n") {
+		return fmt.Errorf("invalid URL '%s': whitespaces are not allowed", input)
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return fmt.Errorf("invalid URL '%s': %w", input, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL '%s': an absolute http or https URL is expected", input)
+	}
+	return nil
+}
+
+// headerNames parses a comma separated list of header names.
+func headerNames(input string) ([]string, error) {
+	names := []string{}
+	for _, name := range strings.Split(input, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !tokenRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid header name '%s'", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
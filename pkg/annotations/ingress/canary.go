@@ -0,0 +1,124 @@
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// CanaryAlways is the header or cookie value sending the request to the canary Ingress
+// when no header value or pattern is provided.
+const CanaryAlways = "always"
+
+// tokenRegexp matches valid HTTP header and cookie names (RFC 7230 token), as well as the header values
+// sending requests to the canary Ingress, which are inserted as is in the ACLs.
+var tokenRegexp = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// Canary gathers the canary annotations of an Ingress into the ACLs selecting the requests sent to it.
+// ACLs are alternatives: a request matching one of them is routed to the canary Ingress.
+// If the Ingress has canary annotations but none of them is valid, ACLs is set to an empty, non nil, slice
+// so that the canary Ingress is not routed at all rather than receiving all the traffic.
+type Canary struct {
+	acls          *[]string
+	weight        *int
+	header        string
+	headerValue   string
+	headerPattern string
+	cookie        string
+	enabled       bool
+}
+
+type CanaryAnn struct {
+	parent *Canary
+	name   string
+}
+
+func NewCanary(acls *[]string) *Canary {
+	return &Canary{acls: acls}
+}
+
+func (p *Canary) NewAnnotation(n string) CanaryAnn {
+	return CanaryAnn{
+		name:   n,
+		parent: p,
+	}
+}
+
+func (a CanaryAnn) GetName() string {
+	return a.name
+}
+
+func (a CanaryAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+	a.parent.enabled = true
+	defer a.parent.update()
+
+	switch a.name {
+	case "canary-weight":
+		var weight int
+		weight, err = strconv.Atoi(input)
+		if err != nil || weight < 0 || weight > 100 {
+			return fmt.Errorf("invalid weight '%s': an integer between 0 and 100 is expected", input)
+		}
+		a.parent.weight = &weight
+	case "canary-by-header":
+		if !tokenRegexp.MatchString(input) {
+			return fmt.Errorf("invalid header name '%s'", input)
+		}
+		a.parent.header = input
+	case "canary-by-header-value":
+		if !tokenRegexp.MatchString(input) {
+			return fmt.Errorf("invalid header value '%s': only letters, digits and !#$%%&'*+.^_`|~- are allowed", input)
+		}
+		a.parent.headerValue = input
+	case "canary-by-header-pattern":
+		if strings.ContainsAny(input, " \t\r\n#\"'\\") {
+			return fmt.Errorf("invalid header pattern '%s': whitespaces and #\"'\\ are not allowed", input)
+		}
+		if _, err = regexp.Compile(input); err != nil {
+			return fmt.Errorf("invalid header pattern '%s': %w", input, err)
+		}
+		a.parent.headerPattern = input
+	case "canary-by-cookie":
+		if !tokenRegexp.MatchString(input) {
+			return fmt.Errorf("invalid cookie name '%s'", input)
+		}
+		a.parent.cookie = input
+	default:
+		err = fmt.Errorf("unknown canary annotation '%s'", a.name)
+	}
+	return err
+}
+
+// update rebuilds the canary ACLs from the valid annotations processed so far.
+// A header pattern takes precedence over a header value, which defaults to CanaryAlways.
+func (p *Canary) update() {
+	if !p.enabled {
+		return
+	}
+	acls := []string{}
+	if p.header != "" {
+		switch {
+		case p.headerPattern != "":
+			acls = append(acls, fmt.Sprintf("req.hdr(%s) -m reg %s", p.header, p.headerPattern))
+		case p.headerValue != "":
+			acls = append(acls, fmt.Sprintf("req.hdr(%s) -m str %s", p.header, p.headerValue))
+		default:
+			acls = append(acls, fmt.Sprintf("req.hdr(%s) -m str %s", p.header, CanaryAlways))
+		}
+	}
+	if p.cookie != "" {
+		acls = append(acls, fmt.Sprintf("req.cook(%s) -m str %s", p.cookie, CanaryAlways))
+	}
+	if p.weight != nil {
+		acls = append(acls, fmt.Sprintf("rand(100) lt %d", *p.weight))
+	}
+	*p.acls = acls
+}
@@ -0,0 +1,111 @@
+package ingress
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ClientAuth requires client certificates on the hosts of an Ingress.
+// The client-crt-optional and client-crl annotations are gathered first, the client-ca annotation,
+// processed last, sets the client certificate options of the Ingress TLS certificates and adds the
+// rule checking client certificates.
+// If client authentication can't be configured, the rule still requires a client certificate
+// so that requests are denied rather than let through.
+type ClientAuth struct {
+	auth    *certs.ClientAuth
+	rules   *rules.List
+	ingress *store.Ingress
+	certs   certs.Certificates
+	verify  string
+	crlFile string
+	failed  bool
+}
+
+type ClientAuthAnn struct {
+	parent *ClientAuth
+	name   string
+}
+
+func NewClientAuth(auth *certs.ClientAuth, rules *rules.List, i *store.Ingress, c certs.Certificates) *ClientAuth {
+	return &ClientAuth{auth: auth, rules: rules, ingress: i, certs: c, verify: "required"}
+}
+
+func (p *ClientAuth) NewAnnotation(n string) ClientAuthAnn {
+	return ClientAuthAnn{name: n, parent: p}
+}
+
+func (a ClientAuthAnn) GetName() string {
+	return a.name
+}
+
+func (a ClientAuthAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "client-crt-optional":
+		var optional bool
+		if optional, err = utils.GetBoolValue(input, a.name); err != nil {
+			a.parent.failed = true
+			return err
+		}
+		if optional {
+			a.parent.verify = "optional"
+		}
+	case "client-crl":
+		var secret *store.Secret
+		if secret, err = a.secret(k, annotations...); err == nil {
+			a.parent.crlFile, err = a.parent.certs.AddSecret(secret, certs.CRL_FILE)
+		}
+		a.parent.failed = a.parent.failed || err != nil
+	case "client-ca":
+		rule := &rules.ReqClientAuth{Optional: a.parent.verify == "optional"}
+		defer a.parent.rules.Add(rule)
+		if a.parent.failed {
+			rule.Optional = false
+			return errors.New("client certificates can't be verified, requests are denied")
+		}
+		var secret *store.Secret
+		var caFile string
+		if secret, err = a.secret(k, annotations...); err == nil {
+			caFile, err = a.parent.certs.AddSecret(secret, certs.CA_CERT)
+		}
+		if err != nil {
+			rule.Optional = false
+			return err
+		}
+		a.parent.certs.AddReference(certs.CA_CERT, secret.Namespace, secret.Name, fmt.Sprintf("Ingress %s/%s", a.parent.ingress.Namespace, a.parent.ingress.Name))
+		if a.parent.crlFile == "" {
+			// the CRL of the CA secret is used unless a client-crl annotation is set
+			a.parent.crlFile = a.parent.certs.CRLFile(caFile)
+		}
+		*a.parent.auth = certs.ClientAuth{
+			CAFile:  caFile,
+			CRLFile: a.parent.crlFile,
+			Verify:  a.parent.verify,
+		}
+	default:
+		err = fmt.Errorf("unknown client auth annotation '%s'", a.name)
+	}
+	return err
+}
+
+// secret returns the secret referenced by the annotation, its namespace defaults to the one of the Ingress.
+func (a ClientAuthAnn) secret(k store.K8s, annotations ...map[string]string) (*store.Secret, error) {
+	ns, name, err := common.GetK8sPath(a.name, annotations...)
+	if err != nil {
+		return nil, err
+	}
+	if ns == "" {
+		ns = a.parent.ingress.Namespace
+	}
+	return k.GetSecret(ns, name)
+}
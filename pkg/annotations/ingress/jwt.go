@@ -0,0 +1,324 @@
+package ingress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// claimRegexp matches claim names usable in a JSON path, nested claims are separated by dots.
+var claimRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// keyNameRegexp matches characters allowed in JWT key file names.
+var keyNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+type ReqJWT struct {
+	jwtRule      *rules.ReqJWT
+	rules        *rules.List
+	ingress      *store.Ingress
+	haproxyCerts certs.Certificates
+}
+
+type ReqJWTAnn struct {
+	parent *ReqJWT
+	name   string
+}
+
+func NewReqJWT(rules *rules.List, i *store.Ingress, c certs.Certificates) *ReqJWT {
+	return &ReqJWT{rules: rules, ingress: i, haproxyCerts: c}
+}
+
+func (p *ReqJWT) NewAnnotation(n string) ReqJWTAnn {
+	return ReqJWTAnn{name: n, parent: p}
+}
+
+func (a ReqJWTAnn) GetName() string {
+	return a.name
+}
+
+func (a ReqJWTAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	// The rule is created as soon as keys are configured, even if they can't be loaded,
+	// so that requests are denied rather than let through.
+	case "jwt-secret":
+		rule := a.parent.getRule()
+		var ns, name string
+		if ns, name, err = a.k8sPath(annotations...); err != nil {
+			return err
+		}
+		secret, _ := k.GetSecret(ns, name)
+		if secret == nil {
+			return fmt.Errorf("secret '%s/%s' not found", ns, name)
+		}
+		var errs utils.Errors
+		for _, dataKey := range slices.Sorted(maps.Keys(secret.Data)) {
+			key, errKey := a.parent.addKey(fmt.Sprintf("%s_%s_%s", ns, name, dataKey), secret.Data[dataKey])
+			if errKey != nil {
+				errs.Add(fmt.Errorf("secret '%s/%s' entry '%s': %w", ns, name, dataKey, errKey))
+				continue
+			}
+			rule.Keys = append(rule.Keys, key)
+		}
+		err = errs.Result()
+	case "jwt-jwks":
+		rule := a.parent.getRule()
+		var ns, name string
+		if ns, name, err = a.k8sPath(annotations...); err != nil {
+			return err
+		}
+		var configmap *store.ConfigMap
+		if namespace, ok := k.Namespaces[ns]; ok {
+			configmap = namespace.JWKS[name]
+		}
+		if configmap == nil {
+			return fmt.Errorf("configmap '%s/%s' with a '%s' entry not found", ns, name, store.JWKSKey)
+		}
+		var keys []rules.JWTKey
+		keys, err = a.parent.addJWKS(fmt.Sprintf("%s_%s", ns, name), configmap.Annotations[store.JWKSKey])
+		rule.Keys = append(rule.Keys, keys...)
+		if err != nil {
+			err = fmt.Errorf("configmap '%s/%s': %w", ns, name, err)
+		}
+	case "jwt-algorithms":
+		if a.parent.jwtRule == nil {
+			return err
+		}
+		algorithms := []string{}
+		for _, alg := range strings.Split(input, ",") {
+			alg = strings.TrimSpace(alg)
+			if !slices.Contains(rules.JWTAlgorithms, alg) {
+				return fmt.Errorf("unsupported algorithm '%s', supported algorithms are %s", alg, strings.Join(rules.JWTAlgorithms, ","))
+			}
+			algorithms = append(algorithms, alg)
+		}
+		a.parent.jwtRule.Algorithms = algorithms
+	case "jwt-issuer", "jwt-audience":
+		if a.parent.jwtRule == nil {
+			return err
+		}
+		if strings.ContainsAny(input, rules.JWTForbiddenChars) {
+			return fmt.Errorf("invalid value '%s': whitespaces, quotes, # and \\ are not allowed", input)
+		}
+		if a.name == "jwt-issuer" {
+			a.parent.jwtRule.Issuer = input
+		} else {
+			a.parent.jwtRule.Audience = input
+		}
+	case "jwt-required-claims":
+		if a.parent.jwtRule == nil {
+			return err
+		}
+		claims := []rules.JWTClaim{}
+		for _, claim := range strings.Split(input, ",") {
+			name, value, found := strings.Cut(strings.TrimSpace(claim), "=")
+			if !found || !claimRegexp.MatchString(name) || value == "" || strings.ContainsAny(value, rules.JWTForbiddenChars) {
+				return fmt.Errorf("invalid required claim '%s': 'name=value' is expected", claim)
+			}
+			claims = append(claims, rules.JWTClaim{Name: name, Value: value})
+		}
+		a.parent.jwtRule.RequiredClaims = claims
+	case "jwt-claim-headers":
+		if a.parent.jwtRule == nil {
+			return err
+		}
+		headers := []rules.JWTClaimHeader{}
+		for _, mapping := range strings.Split(input, ",") {
+			claim, header, found := strings.Cut(strings.TrimSpace(mapping), ":")
+			if !found || !claimRegexp.MatchString(claim) || !tokenRegexp.MatchString(header) {
+				return fmt.Errorf("invalid claim header '%s': 'claim:header' is expected", mapping)
+			}
+			headers = append(headers, rules.JWTClaimHeader{Claim: claim, Header: header})
+		}
+		a.parent.jwtRule.ClaimHeaders = headers
+	case "jwt-deny-status":
+		if a.parent.jwtRule == nil {
+			return err
+		}
+		var status int64
+		status, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || status < 400 || status > 599 {
+			return fmt.Errorf("invalid deny status '%s': an HTTP error status is expected", input)
+		}
+		a.parent.jwtRule.DenyStatus = status
+	default:
+		err = fmt.Errorf("unknown jwt annotation '%s'", a.name)
+	}
+	return err
+}
+
+func (p *ReqJWT) getRule() *rules.ReqJWT {
+	if p.jwtRule == nil {
+		p.jwtRule = &rules.ReqJWT{}
+		p.rules.Add(p.jwtRule)
+	}
+	return p.jwtRule
+}
+
+// k8sPath returns the namespace and name of the resource referenced by the annotation,
+// the namespace defaults to the one of the Ingress.
+func (a ReqJWTAnn) k8sPath(annotations ...map[string]string) (ns, name string, err error) {
+	ns, name, err = common.GetK8sPath(a.name, annotations...)
+	if err != nil {
+		return ns, name, err
+	}
+	if ns == "" {
+		if a.parent.ingress == nil {
+			return ns, name, fmt.Errorf("namespace of '%s' is required", name)
+		}
+		ns = a.parent.ingress.Namespace
+	}
+	return ns, name, nil
+}
+
+// addKey writes a PEM encoded public key or certificate and returns the matching JWT key.
+func (p *ReqJWT) addKey(name string, data []byte) (key rules.JWTKey, err error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return key, errors.New("no PEM data found")
+	}
+	var publicKey any
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var crt *x509.Certificate
+		if crt, err = x509.ParseCertificate(block.Bytes); err == nil {
+			publicKey = crt.PublicKey
+		}
+	default:
+		err = fmt.Errorf("unsupported PEM block '%s'", block.Type)
+	}
+	if err != nil {
+		return key, err
+	}
+	return p.writeKey(name, publicKey, "")
+}
+
+// addJWKS writes the signature keys of a JSON Web Key Set and returns the matching JWT keys.
+func (p *ReqJWT) addJWKS(name, data string) (keys []rules.JWTKey, err error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err = json.Unmarshal([]byte(data), &jwks); err != nil {
+		return nil, err
+	}
+	var errs utils.Errors
+	for i, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if strings.ContainsAny(jwk.Kid, rules.JWTForbiddenChars) {
+			errs.Add(fmt.Errorf("key %d: invalid kid '%s'", i, jwk.Kid))
+			continue
+		}
+		var publicKey any
+		switch jwk.Kty {
+		case "RSA":
+			var n, e []byte
+			n, err = base64.RawURLEncoding.DecodeString(jwk.N)
+			if err == nil {
+				e, err = base64.RawURLEncoding.DecodeString(jwk.E)
+			}
+			if err == nil && (len(n) == 0 || len(e) == 0) {
+				err = errors.New("missing RSA modulus or exponent")
+			}
+			publicKey = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+			curve, ok := curves[jwk.Crv]
+			if !ok {
+				err = fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+				break
+			}
+			var x, y []byte
+			x, err = base64.RawURLEncoding.DecodeString(jwk.X)
+			if err == nil {
+				y, err = base64.RawURLEncoding.DecodeString(jwk.Y)
+			}
+			publicKey = &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		default:
+			err = fmt.Errorf("unsupported key type '%s'", jwk.Kty)
+		}
+		if err != nil {
+			errs.Add(fmt.Errorf("key %d: %w", i, err))
+			continue
+		}
+		keyName := name + "_" + strconv.Itoa(i)
+		if jwk.Kid != "" {
+			keyName = name + "_" + jwk.Kid
+		}
+		key, errKey := p.writeKey(keyName, publicKey, jwk.Alg)
+		if errKey != nil {
+			errs.Add(fmt.Errorf("key %d: %w", i, errKey))
+			continue
+		}
+		key.Kid = jwk.Kid
+		keys = append(keys, key)
+	}
+	return keys, errs.Result()
+}
+
+// writeKey writes the public key in PEM format and returns the JWT key with the algorithms it can verify.
+// When alg is set, the key is restricted to this algorithm.
+func (p *ReqJWT) writeKey(name string, publicKey any, alg string) (key rules.JWTKey, err error) {
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		key.Algorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+	case *ecdsa.PublicKey:
+		if _, err = k.ECDH(); err != nil {
+			return key, err
+		}
+		algorithms := map[string]string{"P-256": "ES256", "P-384": "ES384", "P-521": "ES512"}
+		key.Algorithms = []string{algorithms[k.Curve.Params().Name]}
+	default:
+		return key, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	if alg != "" {
+		if !slices.Contains(key.Algorithms, alg) {
+			return key, fmt.Errorf("algorithm '%s' does not match the key type", alg)
+		}
+		key.Algorithms = []string{alg}
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return key, err
+	}
+	content := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	key.Path, err = p.haproxyCerts.AddJWTKey(keyNameRegexp.ReplaceAllString(name, "_"), content)
+	return key, err
+}
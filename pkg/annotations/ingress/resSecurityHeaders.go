@@ -0,0 +1,133 @@
+package ingress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// hstsPreloadMinAge is the minimum max-age required by HSTS preload lists
+const hstsPreloadMinAge = 31536000
+
+var referrerPolicies = map[string]struct{}{
+	"no-referrer":                     {},
+	"no-referrer-when-downgrade":      {},
+	"origin":                          {},
+	"origin-when-cross-origin":        {},
+	"same-origin":                     {},
+	"strict-origin":                   {},
+	"strict-origin-when-cross-origin": {},
+	"unsafe-url":                      {},
+}
+
+// SecurityHeaders sets security headers on the HTTPS responses of an Ingress.
+// Values are taken from the Ingress then from the ConfigMap, an empty Ingress value
+// disables a header set in the ConfigMap.
+// hsts is processed after the annotations setting the Strict-Transport-Security parameters.
+type SecurityHeaders struct {
+	rules                 *rules.List
+	hstsMaxAge            int64
+	hstsIncludeSubdomains bool
+	hstsPreload           bool
+}
+
+type SecurityHeadersAnn struct {
+	parent *SecurityHeaders
+	name   string
+}
+
+func NewSecurityHeaders(r *rules.List) *SecurityHeaders {
+	return &SecurityHeaders{rules: r, hstsMaxAge: hstsPreloadMinAge}
+}
+
+func (p *SecurityHeaders) NewAnnotation(n string) SecurityHeadersAnn {
+	return SecurityHeadersAnn{name: n, parent: p}
+}
+
+func (a SecurityHeadersAnn) GetName() string {
+	return a.name
+}
+
+func (a SecurityHeadersAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := strings.TrimSpace(common.GetValue(a.GetName(), annotations...))
+	if input == "" {
+		return err
+	}
+
+	var hdrName, hdrValue string
+	switch a.name {
+	case "hsts-max-age":
+		a.parent.hstsMaxAge, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || a.parent.hstsMaxAge < 0 {
+			a.parent.hstsMaxAge = hstsPreloadMinAge
+			return fmt.Errorf("invalid max-age '%s'", input)
+		}
+		return err
+	case "hsts-include-subdomains":
+		a.parent.hstsIncludeSubdomains, err = utils.GetBoolValue(input, a.name)
+		return err
+	case "hsts-preload":
+		a.parent.hstsPreload, err = utils.GetBoolValue(input, a.name)
+		return err
+	case "hsts":
+		var enabled bool
+		if enabled, err = utils.GetBoolValue(input, a.name); err != nil || !enabled {
+			return err
+		}
+		hdrName = "Strict-Transport-Security"
+		hdrValue = fmt.Sprintf("max-age=%d", a.parent.hstsMaxAge)
+		if a.parent.hstsIncludeSubdomains {
+			hdrValue += "; includeSubDomains"
+		}
+		if a.parent.hstsPreload {
+			if !a.parent.hstsIncludeSubdomains || a.parent.hstsMaxAge < hstsPreloadMinAge {
+				err = fmt.Errorf("preload requires includeSubDomains and a max-age of at least %d, preload not set", hstsPreloadMinAge)
+			} else {
+				hdrValue += "; preload"
+			}
+		}
+	case "x-frame-options":
+		hdrName = "X-Frame-Options"
+		hdrValue = strings.ToUpper(input)
+		if hdrValue != "DENY" && hdrValue != "SAMEORIGIN" {
+			return fmt.Errorf("invalid value '%s', expected DENY or SAMEORIGIN", input)
+		}
+	case "x-content-type-options":
+		hdrName = "X-Content-Type-Options"
+		hdrValue = strings.ToLower(input)
+		if hdrValue != "nosniff" {
+			return fmt.Errorf("invalid value '%s', expected nosniff", input)
+		}
+	case "referrer-policy":
+		hdrName = "Referrer-Policy"
+		policies := strings.Split(input, ",")
+		for i, policy := range policies {
+			policies[i] = strings.ToLower(strings.TrimSpace(policy))
+			if _, ok := referrerPolicies[policies[i]]; !ok {
+				return fmt.Errorf("invalid referrer policy '%s'", policy)
+			}
+		}
+		hdrValue = strings.Join(policies, ", ")
+	case "content-security-policy":
+		hdrName = "Content-Security-Policy"
+		if strings.ContainsAny(input, "\"\\\r\n") {
+			return fmt.Errorf("invalid policy '%s'", input)
+		}
+		// a literal % must be escaped in HAProxy log-format strings
+		hdrValue = strings.ReplaceAll(input, "%", "%%")
+	default:
+		return fmt.Errorf("unknown security header annotation '%s'", a.name)
+	}
+	a.parent.rules.Add(&rules.SetHdr{
+		HdrName:   hdrName,
+		HdrFormat: common.EnsureQuoted(hdrValue),
+		Response:  true,
+		HTTPSOnly: true,
+	})
+	return err
+}
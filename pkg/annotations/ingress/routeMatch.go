@@ -0,0 +1,122 @@
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// methodRegexp matches HTTP method names.
+var methodRegexp = regexp.MustCompile("^[A-Z]+$")
+
+// RouteMatch gathers the route-match annotations of an Ingress into the ACLs a request must all match
+// to be routed to the Ingress paths.
+// If a route-match annotation is invalid, ACLs is set to a condition that never matches
+// so that the Ingress is not routed at all rather than receiving more traffic than intended.
+type RouteMatch struct {
+	acls   *[]string
+	failed bool
+}
+
+type RouteMatchAnn struct {
+	parent *RouteMatch
+	name   string
+}
+
+func NewRouteMatch(acls *[]string) *RouteMatch {
+	return &RouteMatch{acls: acls}
+}
+
+func (p *RouteMatch) NewAnnotation(n string) RouteMatchAnn {
+	return RouteMatchAnn{
+		name:   n,
+		parent: p,
+	}
+}
+
+func (a RouteMatchAnn) GetName() string {
+	return a.name
+}
+
+func (a RouteMatchAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+	var acls []string
+	defer func() {
+		a.parent.update(acls, err)
+	}()
+
+	switch a.name {
+	case "route-match-header":
+		acls, err = matchPairs(input, "header", "req.hdr")
+	case "route-match-query":
+		acls, err = matchPairs(input, "query parameter", "urlp")
+	case "route-match-cookie":
+		acls, err = matchPairs(input, "cookie", "req.cook")
+	case "route-match-method":
+		methods := strings.FieldsFunc(input, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+		for _, method := range methods {
+			if !methodRegexp.MatchString(method) {
+				return fmt.Errorf("invalid method '%s'", method)
+			}
+		}
+		if len(methods) == 0 {
+			return errors.New("no method provided")
+		}
+		acls = []string{"method " + strings.Join(methods, " ")}
+	default:
+		err = fmt.Errorf("unknown route-match annotation '%s'", a.name)
+	}
+	return err
+}
+
+// update adds the ACLs of a route-match annotation, or makes the route unreachable if the annotation is invalid.
+func (p *RouteMatch) update(acls []string, err error) {
+	if p.failed {
+		return
+	}
+	if err != nil {
+		p.failed = true
+		*p.acls = []string{"always_false"}
+		return
+	}
+	*p.acls = append(*p.acls, acls...)
+}
+
+// matchPairs parses a comma separated list of "name=value" or "name" items into ACLs
+// matching the value, or the presence, of the fetch of the same name.
+func matchPairs(input, kind, fetch string) ([]string, error) {
+	acls := []string{}
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if !tokenRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid %s name '%s'", kind, name)
+		}
+		switch {
+		case !hasValue:
+			acls = append(acls, fmt.Sprintf("%s(%s) -m found", fetch, name))
+		case value == "" || strings.ContainsAny(value, " \t\r\n#\"'\\{}"):
+			return nil, fmt.Errorf("invalid %s value '%s': whitespaces, quotes, braces, '#' and '\\' are not allowed", kind, value)
+		default:
+			acls = append(acls, fmt.Sprintf("%s(%s) -m str %s", fetch, name, value))
+		}
+	}
+	if len(acls) == 0 {
+		return nil, fmt.Errorf("no %s provided", kind)
+	}
+	return acls, nil
+}
@@ -0,0 +1,79 @@
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+var (
+	// cipherListRegexp matches OpenSSL cipher, ciphersuite and curve lists
+	cipherListRegexp = regexp.MustCompile(`^[A-Za-z0-9_+!@=.:-]+$`)
+	// alpnRegexp matches comma separated ALPN protocol names
+	alpnRegexp = regexp.MustCompile(`^[A-Za-z0-9./_-]+(,[A-Za-z0-9./_-]+)*$`)
+)
+
+// TLSPolicy sets the TLS parameters of the hosts of an Ingress.
+// They are applied to the Ingress TLS certificates in the SNI crt-list, invalid values are ignored.
+type TLSPolicy struct {
+	policy *certs.TLSPolicy
+}
+
+type TLSPolicyAnn struct {
+	parent *TLSPolicy
+	name   string
+}
+
+func NewTLSPolicy(policy *certs.TLSPolicy) *TLSPolicy {
+	return &TLSPolicy{policy: policy}
+}
+
+func (p *TLSPolicy) NewAnnotation(n string) TLSPolicyAnn {
+	return TLSPolicyAnn{name: n, parent: p}
+}
+
+func (a TLSPolicyAnn) GetName() string {
+	return a.name
+}
+
+func (a TLSPolicyAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "ssl-min-ver":
+		switch input {
+		case "SSLv3", "TLSv1.0", "TLSv1.1", "TLSv1.2", "TLSv1.3":
+			a.parent.policy.MinVer = input
+		default:
+			err = fmt.Errorf("invalid TLS version '%s'", input)
+		}
+	case "ssl-ciphers":
+		a.parent.policy.Ciphers, err = cipherList(input)
+	case "ssl-ciphersuites":
+		a.parent.policy.Ciphersuites, err = cipherList(input)
+	case "ssl-curves":
+		a.parent.policy.Curves, err = cipherList(input)
+	case "tls-alpn":
+		if !alpnRegexp.MatchString(input) {
+			err = fmt.Errorf("invalid ALPN protocol list '%s'", input)
+			break
+		}
+		a.parent.policy.ALPN = input
+	default:
+		err = fmt.Errorf("unknown TLS policy annotation '%s'", a.name)
+	}
+	return err
+}
+
+func cipherList(input string) (string, error) {
+	if !cipherListRegexp.MatchString(input) {
+		return "", fmt.Errorf("invalid list '%s'", input)
+	}
+	return input, nil
+}
@@ -37,6 +37,7 @@ func (a *CA) Process(k store.K8s, annotations ...map[string]string) error {
 	if secret == nil {
 		if a.backend.DefaultServer != nil {
 			a.backend.DefaultServer.SslCafile = ""
+			a.backend.DefaultServer.CrlFile = ""
 			// Other values from serverSSL annotation are kept
 		}
 		return nil
@@ -52,5 +53,6 @@ func (a *CA) Process(k store.K8s, annotations ...map[string]string) error {
 	a.backend.DefaultServer.Alpn = "h2,http/1.1"
 	a.backend.DefaultServer.Verify = "required"
 	a.backend.DefaultServer.SslCafile = caFile
+	a.backend.DefaultServer.CrlFile = a.haproxyCerts.CRLFile(caFile)
 	return nil
 }
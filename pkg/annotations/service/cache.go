@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// Cache configures a cache section named after the backend and the rules using it.
+// The cache parameters are gathered first, the cache-enable annotation, processed last,
+// adds the cache filter and the cache-use/cache-store rules to the backend.
+type Cache struct {
+	backend *models.Backend
+	cache   *models.Cache
+}
+
+type CacheAnn struct {
+	parent *Cache
+	name   string
+}
+
+func NewCache(b *models.Backend, c *models.Cache) *Cache {
+	return &Cache{backend: b, cache: c}
+}
+
+func (p *Cache) NewAnnotation(n string) CacheAnn {
+	return CacheAnn{name: n, parent: p}
+}
+
+func (a CacheAnn) GetName() string {
+	return a.name
+}
+
+func (a CacheAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "cache-size":
+		var size int64
+		size, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || size < 1 || size > 4095 {
+			return fmt.Errorf("invalid size '%s': a number of megabytes between 1 and 4095 is expected", input)
+		}
+		a.parent.cache.TotalMaxSize = size
+	case "cache-max-object-size":
+		var size int64
+		size, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || size < 1 {
+			return fmt.Errorf("invalid object size '%s': a positive number of bytes is expected", input)
+		}
+		a.parent.cache.MaxObjectSize = size
+	case "cache-max-age":
+		var age int64
+		age, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || age < 1 {
+			return fmt.Errorf("invalid max age '%s': a positive number of seconds is expected", input)
+		}
+		a.parent.cache.MaxAge = age
+	case "cache-vary":
+		var vary bool
+		if vary, err = utils.GetBoolValue(input, a.name); err != nil {
+			return err
+		}
+		a.parent.cache.ProcessVary = &vary
+	case "cache-enable":
+		var enabled bool
+		if enabled, err = utils.GetBoolValue(input, a.name); err != nil || !enabled {
+			return err
+		}
+		if a.parent.cache.TotalMaxSize == 0 {
+			return errors.New("cache not enabled without a valid cache size")
+		}
+		// HAProxy refuses objects bigger than half of the cache
+		if a.parent.cache.MaxObjectSize > a.parent.cache.TotalMaxSize*1024*1024/2 {
+			return fmt.Errorf("max object size %d can't exceed half of the cache size", a.parent.cache.MaxObjectSize)
+		}
+		name := a.parent.backend.Name
+		a.parent.cache.Name = &name
+		// the cache filter is declared before the other filters, such as compression
+		a.parent.backend.FilterList = append(models.Filters{{
+			Type:      "cache",
+			CacheName: name,
+		}}, a.parent.backend.FilterList...)
+		a.parent.backend.HTTPRequestRuleList = append(a.parent.backend.HTTPRequestRuleList, &models.HTTPRequestRule{
+			Type:      "cache-use",
+			CacheName: name,
+		})
+		a.parent.backend.HTTPResponseRuleList = append(a.parent.backend.HTTPResponseRuleList, &models.HTTPResponseRule{
+			Type:      "cache-store",
+			CacheName: name,
+		})
+	default:
+		err = fmt.Errorf("unknown cache annotation '%s'", a.name)
+	}
+	return err
+}
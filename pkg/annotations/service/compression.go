@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// compressionAlgorithms lists the response compression algorithms supported by HAProxy.
+var compressionAlgorithms = []string{"gzip", "deflate", "raw-deflate", "identity"}
+
+// Compression configures the compression filter of the backend.
+// The compression parameters are gathered first, the compression-enable annotation, processed last,
+// sets them in the backend with the compression filter unless one of them is invalid.
+type Compression struct {
+	backend     *models.Backend
+	compression models.Compression
+	invalid     []string
+}
+
+type CompressionAnn struct {
+	parent *Compression
+	name   string
+}
+
+func NewCompression(b *models.Backend) *Compression {
+	return &Compression{backend: b}
+}
+
+func (p *Compression) NewAnnotation(n string) CompressionAnn {
+	return CompressionAnn{name: n, parent: p}
+}
+
+func (a CompressionAnn) GetName() string {
+	return a.name
+}
+
+func (a CompressionAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	defer func() {
+		if err != nil && a.name != "compression-enable" {
+			a.parent.invalid = append(a.parent.invalid, a.name)
+		}
+	}()
+
+	switch a.name {
+	case "compression-algorithms":
+		algorithms := fields(input)
+		for _, algo := range algorithms {
+			if !slices.Contains(compressionAlgorithms, algo) {
+				return fmt.Errorf("unsupported algorithm '%s', supported algorithms are %s", algo, strings.Join(compressionAlgorithms, ","))
+			}
+		}
+		a.parent.compression.Algorithms = algorithms
+	case "compression-types":
+		types := fields(input)
+		for _, t := range types {
+			if _, _, err = mime.ParseMediaType(t); err != nil {
+				return fmt.Errorf("invalid MIME type '%s': %w", t, err)
+			}
+		}
+		a.parent.compression.Types = types
+	case "compression-min-size":
+		var size int64
+		size, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || size < 0 {
+			return fmt.Errorf("invalid minimum size '%s': a number of bytes is expected", input)
+		}
+		a.parent.compression.MinsizeRes = size
+	case "compression-offload":
+		a.parent.compression.Offload, err = utils.GetBoolValue(input, a.name)
+	case "compression-enable":
+		var enabled bool
+		if enabled, err = utils.GetBoolValue(input, a.name); err != nil || !enabled {
+			return err
+		}
+		if len(a.parent.invalid) > 0 {
+			return fmt.Errorf("compression not enabled: invalid %s annotation", strings.Join(a.parent.invalid, ","))
+		}
+		compression := a.parent.compression
+		a.parent.backend.Compression = &compression
+		a.parent.backend.FilterList = append(a.parent.backend.FilterList, &models.Filter{
+			Type: models.FilterTypeCompression,
+		})
+	default:
+		err = fmt.Errorf("unknown compression annotation '%s'", a.name)
+	}
+	return err
+}
+
+// fields splits a list separated by commas or spaces.
+func fields(input string) []string {
+	return strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
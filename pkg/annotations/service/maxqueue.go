@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+type Maxqueue struct {
+	backend *models.Backend
+	name    string
+}
+
+func NewMaxqueue(n string, b *models.Backend) *Maxqueue {
+	return &Maxqueue{name: n, backend: b}
+}
+
+func (a *Maxqueue) GetName() string {
+	return a.name
+}
+
+func (a *Maxqueue) Process(k store.K8s, annotations ...map[string]string) error {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		if a.backend.DefaultServer != nil {
+			a.backend.DefaultServer.Maxqueue = nil
+		}
+		return nil
+	}
+	v, err := strconv.ParseInt(input, 10, 64)
+	if err != nil || v < 1 {
+		return fmt.Errorf("invalid max queue '%s': a positive number is expected", input)
+	}
+	if a.backend.DefaultServer == nil {
+		a.backend.DefaultServer = &models.DefaultServer{}
+	}
+	a.backend.DefaultServer.Maxqueue = &v
+	return nil
+}
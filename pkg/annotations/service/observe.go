@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+var logger = utils.GetLogger()
+
+// onErrorActions lists the actions HAProxy can take when a server reaches the error limit.
+var onErrorActions = []string{"fastinter", "fail-check", "sudden-death", "mark-down"}
+
+// Observe configures passive health checks: servers are marked down according to the errors
+// observed on live traffic. error-limit and on-error are gathered first, the observe annotation,
+// processed last, sets them in the default server.
+// As HAProxy adjusts health check results, observe requires health checks to be enabled,
+// it is ignored with a warning on backends without them.
+type Observe struct {
+	backend    *models.Backend
+	errorLimit int64
+	onError    string
+}
+
+type ObserveAnn struct {
+	parent *Observe
+	name   string
+}
+
+func NewObserve(b *models.Backend) *Observe {
+	return &Observe{backend: b}
+}
+
+func (p *Observe) NewAnnotation(n string) ObserveAnn {
+	return ObserveAnn{name: n, parent: p}
+}
+
+func (a ObserveAnn) GetName() string {
+	return a.name
+}
+
+func (a ObserveAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "error-limit":
+		var limit int64
+		limit, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || limit < 1 {
+			return fmt.Errorf("invalid error limit '%s': a positive number is expected", input)
+		}
+		a.parent.errorLimit = limit
+	case "on-error":
+		if !slices.Contains(onErrorActions, input) {
+			return fmt.Errorf("unsupported action '%s', supported actions are %s", input, strings.Join(onErrorActions, ","))
+		}
+		a.parent.onError = input
+	case "observe":
+		if input != "layer4" && input != "layer7" {
+			return fmt.Errorf("invalid mode '%s': layer4 or layer7 is expected", input)
+		}
+		if input == "layer7" && a.parent.backend.Mode != "http" {
+			return errors.New("layer7 can only be observed in HTTP mode")
+		}
+		if a.parent.backend.DefaultServer == nil || a.parent.backend.DefaultServer.Check != "enabled" {
+			logger.Warningf("annotation %s ignored on backend '%s': health checks must be enabled with the check annotation", a.name, a.parent.backend.Name)
+			return nil
+		}
+		a.parent.backend.DefaultServer.Observe = input
+		a.parent.backend.DefaultServer.ErrorLimit = a.parent.errorLimit
+		a.parent.backend.DefaultServer.OnError = a.parent.onError
+	default:
+		err = fmt.Errorf("unknown observe annotation '%s'", a.name)
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// retryConditions lists the retry-on keywords supported by HAProxy, HTTP status codes excepted.
+var retryConditions = []string{"none", "conn-failure", "empty-response", "junk-response", "response-timeout", "0rtt-rejected", "all-retryable-errors"}
+
+// retryStatuses lists the HTTP status codes a request can be retried on.
+var retryStatuses = []string{"401", "403", "404", "408", "425", "500", "501", "502", "503", "504"}
+
+// Retry configures the retry and redispatch policy of the backend.
+// retry-non-idempotent and redispatch-interval are processed before the annotations they modify.
+// TCP backends only retry on connection failures, retry-non-idempotent has no effect on them.
+type Retry struct {
+	backend            *models.Backend
+	nonIdempotent      bool
+	redispatchInterval *int64
+}
+
+type RetryAnn struct {
+	parent *Retry
+	name   string
+}
+
+func NewRetry(b *models.Backend) *Retry {
+	return &Retry{backend: b}
+}
+
+func (p *Retry) NewAnnotation(n string) RetryAnn {
+	return RetryAnn{name: n, parent: p}
+}
+
+func (a RetryAnn) GetName() string {
+	return a.name
+}
+
+func (a RetryAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+
+	switch a.name {
+	case "retries":
+		var retries int64
+		retries, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || retries < 0 {
+			return fmt.Errorf("invalid retries '%s': a number greater than or equal to 0 is expected", input)
+		}
+		a.parent.backend.Retries = &retries
+	case "retry-non-idempotent":
+		a.parent.nonIdempotent, err = utils.GetBoolValue(input, a.name)
+	case "retry-on":
+		conditions := fields(input)
+		l7 := false
+		for _, cond := range conditions {
+			if !slices.Contains(retryConditions, cond) && !slices.Contains(retryStatuses, cond) {
+				return fmt.Errorf("unsupported retry condition '%s', supported conditions are %s and the status codes %s", cond, strings.Join(retryConditions, ","), strings.Join(retryStatuses, ","))
+			}
+			l7 = l7 || (cond != "none" && cond != "conn-failure")
+		}
+		if len(conditions) > 1 && slices.Contains(conditions, "none") {
+			return errors.New("retry condition 'none' can't be combined with other conditions")
+		}
+		if l7 && a.parent.backend.Mode != "http" {
+			return fmt.Errorf("retry conditions '%s' need an HTTP backend, TCP backends only support none and conn-failure", input)
+		}
+		a.parent.backend.RetryOn = strings.Join(conditions, " ")
+		// a request may have been processed by the server even if its response failed, so unless
+		// retry-non-idempotent is set only idempotent requests are retried after a connection is established.
+		if l7 && !a.parent.nonIdempotent {
+			a.parent.backend.HTTPRequestRuleList = append(a.parent.backend.HTTPRequestRuleList, &models.HTTPRequestRule{
+				Type:     "disable-l7-retry",
+				Cond:     "if",
+				CondTest: "!{ method GET HEAD OPTIONS PUT DELETE TRACE }",
+			})
+		}
+	case "redispatch-interval":
+		var interval int64
+		interval, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || interval == 0 {
+			return fmt.Errorf("invalid redispatch interval '%s': a non zero number is expected", input)
+		}
+		a.parent.redispatchInterval = &interval
+	case "redispatch":
+		var enabled bool
+		if enabled, err = utils.GetBoolValue(input, a.name); err != nil {
+			return err
+		}
+		redispatch := &models.Redispatch{Enabled: utils.PtrString("disabled")}
+		if enabled {
+			redispatch.Enabled = utils.PtrString("enabled")
+			redispatch.Interval = a.parent.redispatchInterval
+		}
+		a.parent.backend.Redispatch = redispatch
+	default:
+		err = fmt.Errorf("unknown retry annotation '%s'", a.name)
+	}
+	return err
+}
@@ -32,8 +32,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/haproxytech/kubernetes-ingress/pkg/acme"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
 	"github.com/haproxytech/kubernetes-ingress/pkg/controller/constants"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	gateway "github.com/haproxytech/kubernetes-ingress/pkg/gateways"
 	"github.com/haproxytech/kubernetes-ingress/pkg/handler"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
@@ -42,11 +44,13 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/process"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
 	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
 	"github.com/haproxytech/kubernetes-ingress/pkg/status"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/haproxytech/kubernetes-ingress/pkg/webhook"
 )
 
 type Builder struct {
@@ -58,6 +62,7 @@ type Builder struct {
 	haproxyRules             rules.Rules
 	restClientSet            client.Client
 	updateStatusManager      status.UpdateStatusManager
+	leaderElector            leader.Elector
 	updatePublishServiceFunc func(ingresses []*ingress.Ingress, publishServiceAddresses []string)
 	eventChan                chan k8ssync.SyncDataEvent
 	clientSet                *kubernetes.Clientset
@@ -153,6 +158,11 @@ func (builder *Builder) WithUpdateStatusManager(updateStatusManager status.Updat
 	return builder
 }
 
+func (builder *Builder) WithLeaderElector(leaderElector leader.Elector) *Builder {
+	builder.leaderElector = leaderElector
+	return builder
+}
+
 func (builder *Builder) Build() *HAProxyController {
 	if builder.haproxyCfgFile == nil {
 		logger.Panic(errors.New("no HAProxy Config file provided"))
@@ -164,24 +174,43 @@ func (builder *Builder) Build() *HAProxyController {
 		addControllerMetricData(builder, chShutdown)
 	}
 
-	if builder.osArgs.DefaultBackendService.String() == "" {
+	// rendered configurations use the local default service but nothing is served when rendering
+	if builder.osArgs.DefaultBackendService.String() == "" && len(builder.osArgs.RenderManifests) == 0 {
 		addLocalDefaultService(builder, chShutdown)
 	}
 
 	haproxy, err := haproxy.New(builder.osArgs, builder.haproxyEnv, builder.haproxyCfgFile, builder.haproxyProcess, builder.haproxyClient, builder.haproxyRules)
 	logger.Panic(err)
+	if builder.osArgs.PrometheusEnabled {
+		metrics.New().SetCacheStatsSource(haproxy.CacheStats)
+	}
 
 	prefix, errPrefix := utils.GetPodPrefix(os.Getenv("POD_NAME"))
 	logger.Error(errPrefix)
 
+	var clientSet kubernetes.Interface
+	if builder.clientSet != nil {
+		clientSet = builder.clientSet
+	}
+	if builder.osArgs.WebhookPort != 0 {
+		webhook.New(clientSet, builder.annotations, haproxy.Env, builder.osArgs).Start(chShutdown)
+	}
+	leaderElector := builder.leaderElector
+	if leaderElector == nil {
+		leaderElector = leader.New(clientSet, builder.osArgs)
+	}
 	builder.store.GatewayControllerName = builder.osArgs.GatewayControllerName
 	gatewayManager := builder.gatewayManager
 	if gatewayManager == nil {
-		gatewayManager = gateway.New(builder.store, haproxy.HAProxyClient, builder.osArgs, builder.restClientSet)
+		gatewayManager = gateway.New(builder.store, haproxy.HAProxyClient, haproxy.Certificates, builder.osArgs, builder.restClientSet, leaderElector)
 	}
 	updateStatusManager := builder.updateStatusManager
 	if updateStatusManager == nil {
-		updateStatusManager = status.New(builder.clientSet, builder.osArgs.IngressClass, builder.osArgs.EmptyIngressClass)
+		updateStatusManager = status.New(builder.clientSet, builder.osArgs.IngressClass, builder.osArgs.EmptyIngressClass, leaderElector)
+	}
+	var eventEmitter events.Emitter
+	if clientSet != nil {
+		eventEmitter = events.NewEmitter(clientSet, leaderElector)
 	}
 	hostname, _ := os.Hostname()
 	podIP := utils.GetIP()
@@ -200,6 +229,10 @@ func (builder *Builder) Build() *HAProxyController {
 		updatePublishServiceFunc: builder.updatePublishServiceFunc,
 		gatewayManager:           gatewayManager,
 		updateStatusManager:      updateStatusManager,
+		acmeManager:              acme.New(clientSet, leaderElector, os.Getenv("POD_NAMESPACE"), builder.osArgs),
+		leaderElector:            leaderElector,
+		eventEmitter:             eventEmitter,
+		isLeader:                 leaderElector.IsLeader(),
 		prometheusMetricsManager: metrics.New(),
 		PodIP:                    podIP,
 		Hostname:                 hostname,
@@ -216,6 +249,8 @@ func addControllerMetricData(builder *Builder, chShutdown chan struct{}) {
 	var runningServices string
 	if builder.osArgs.PprofEnabled {
 		rtr.GET("/debug/pprof/{profile:*}", pprofhandler.PprofHandler)
+		rtr.GET(handler.CERTIFICATES_URL_PATH, handler.CertificatesInventoryHandler)
+		rtr.GET(handler.CONDITIONS_URL_PATH, handler.ConditionsHandler)
 		runningServices += " pprof"
 	}
 	if builder.osArgs.PrometheusEnabled {
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-test/deep"
@@ -25,7 +26,9 @@ import (
 	maps0 "maps"
 
 	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/acme"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
 	gateway "github.com/haproxytech/kubernetes-ingress/pkg/gateways"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
@@ -33,6 +36,8 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
 	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
 	"github.com/haproxytech/kubernetes-ingress/pkg/route"
@@ -50,6 +55,9 @@ type HAProxyController struct {
 	gatewayManager           gateway.GatewayManager
 	annotations              annotations.Annotations
 	updateStatusManager      status.UpdateStatusManager
+	acmeManager              *acme.Manager
+	leaderElector            leader.Elector
+	eventEmitter             events.Emitter
 	eventChan                chan k8ssync.SyncDataEvent
 	updatePublishServiceFunc func(ingresses []*ingress.Ingress, publishServiceAddresses []string)
 	chShutdown               chan struct{}
@@ -63,6 +71,7 @@ type HAProxyController struct {
 	osArgs                   utils.OSArgs
 	auxCfgModTime            int64
 	ready                    bool
+	isLeader                 bool
 	processIngress           func()
 }
 
@@ -106,6 +115,7 @@ func (c *HAProxyController) Start() {
 	_, errStart := (c.haproxy.Service("start"))
 	logger.Panic(errStart)
 
+	go c.leaderElector.Run(c.chShutdown)
 	c.SyncData()
 }
 
@@ -117,6 +127,15 @@ func (c *HAProxyController) Stop() {
 	logger.Error(errStop)
 }
 
+// leadershipAcquired returns true the first time it is called after the replica became leader,
+// so that a sync is triggered for status writers to catch up.
+func (c *HAProxyController) leadershipAcquired() bool {
+	isLeader := c.leaderElector.IsLeader()
+	acquired := isLeader && !c.isLeader
+	c.isLeader = isLeader
+	return acquired
+}
+
 // updateHAProxy is the control loop syncing HAProxy configuration
 func (c *HAProxyController) updateHAProxy() {
 	var err error
@@ -136,6 +155,9 @@ func (c *HAProxyController) updateHAProxy() {
 	// All subsequent log line will contain the "transactionID" field.
 	logger.Trace("HAProxy config sync transaction started")
 
+	// owners of the backends are added again while processing the resources
+	c.store.BackendRC.Clear()
+
 	c.handleGlobalConfig()
 
 	if len(route.CustomRoutes) != 0 {
@@ -181,6 +203,7 @@ func (c *HAProxyController) updateHAProxy() {
 		c.prometheusMetricsManager.SetUnableSyncGauge()
 		logger.Error("unable to Sync HAProxy configuration !!")
 		logger.Error(err)
+		c.reportConfigError(events.REASON_SYNC_FAILED, err, filepath.Join(c.haproxy.Env.CfgDir, "failed"))
 		rerun, errCfgSnippet := annotations.CheckBackendConfigSnippetError(err, c.haproxy.Env.CfgDir)
 		logger.Error(errCfgSnippet)
 		c.clean(true)
@@ -194,6 +217,7 @@ func (c *HAProxyController) updateHAProxy() {
 		}
 		// If any error not from config snippet then pop the previous state of backends
 		logger.Error(c.haproxy.PopPreviousBackends())
+		events.Publish(c.eventEmitter)
 		return
 	}
 
@@ -212,6 +236,7 @@ func (c *HAProxyController) updateHAProxy() {
 			}
 
 			c.prometheusMetricsManager.SetUnableSyncGauge()
+			c.reportConfigError(events.REASON_RELOAD_FAILED, errors.New(msg), c.haproxy.Env.CfgDir)
 			rerun, errCfgSnippet := annotations.CheckBackendConfigSnippetErrorOnReload(errors.New(msg), c.haproxy.Env.CfgDir)
 			logger.Error(errCfgSnippet)
 			c.clean(true)
@@ -237,6 +262,7 @@ func (c *HAProxyController) updateHAProxy() {
 	c.clean(false)
 	// If transaction succeeds thenpush backends state for any future recover.
 	logger.Error(c.haproxy.PushPreviousBackends())
+	events.Publish(c.eventEmitter)
 	logger.Trace("HAProxy config sync ended")
 }
 
@@ -273,6 +299,16 @@ func (c *HAProxyController) setToReady() {
 		},
 	))
 
+	// requests copied by the mirror Lua action are routed to their backend by the mirror frontend
+	logger.Panic(c.haproxy.FrontendBindCreate("mirror",
+		models.Bind{
+			BindParams: models.BindParams{
+				Name: "mirror",
+			},
+			Address: "unix@" + c.haproxy.Env.MirrorSocket,
+		},
+	))
+
 	logger.Debugf("healthz frontend exposed for readiness probe")
 	cm := c.store.ConfigMaps.Main
 	if cm.Name != "" && !cm.Loaded {
@@ -320,10 +356,43 @@ func (c *HAProxyController) setupHAProxyRules() error {
 				Expression: fmt.Sprintf("var(txn.host),regsub(^[^.]*,,),map(%s,'')", maps.GetPath(route.HOST)),
 				CondTest:   "!{ var(txn.host_match) -m found }",
 			}, false),
+			// routes with match conditions take precedence, their keys are prefixed with the match key of the request
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.match_key),concat(%s,txn.host_match,),concat(,txn.path,),map(%s)", route.MATCH_KEY_SEPARATOR, maps.GetPath(route.PATH_EXACT)),
+				CondTest:   "{ var(txn.match_key) -m found }",
+			}, false),
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.match_key),concat(%s,txn.host_match,),concat(,txn.path,),map_reg(%s)", route.MATCH_KEY_SEPARATOR, maps.GetPath(route.PATH_REGEX)),
+				CondTest:   "{ var(txn.match_key) -m found } !{ var(txn.path_match) -m found }",
+			}, false),
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.match_key),concat(%s,txn.host_match,),concat(,txn.path,),map(%s)", route.MATCH_KEY_SEPARATOR, maps.GetPath(route.PATH_PREFIX_EXACT)),
+				CondTest:   "{ var(txn.match_key) -m found } !{ var(txn.path_match) -m found }",
+			}, false),
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.match_key),concat(%s,txn.host_match,),concat(,txn.path,),map_beg(%s)", route.MATCH_KEY_SEPARATOR, maps.GetPath(route.PATH_PREFIX)),
+				CondTest:   "{ var(txn.match_key) -m found } !{ var(txn.path_match) -m found }",
+			}, false),
 			c.haproxy.AddRule(frontend, rules.ReqSetVar{
 				Name:       "path_match",
 				Scope:      "txn",
 				Expression: fmt.Sprintf("var(txn.host_match),concat(,txn.path,),map(%s)", maps.GetPath(route.PATH_EXACT)),
+				CondTest:   "!{ var(txn.path_match) -m found }",
+			}, false),
+			// regex paths take precedence over prefix paths
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.host_match),concat(,txn.path,),map_reg(%s)", maps.GetPath(route.PATH_REGEX)),
+				CondTest:   "!{ var(txn.path_match) -m found }",
 			}, false),
 			c.haproxy.AddRule(frontend, rules.ReqSetVar{
 				Name:       "path_match",
@@ -353,8 +422,8 @@ func (c *HAProxyController) clean(failedSync bool) {
 	}
 }
 
-func (c *HAProxyController) SetGatewayAPIInstalled(gatewayAPIInstalled bool) {
-	c.gatewayManager.SetGatewayAPIInstalled(gatewayAPIInstalled)
+func (c *HAProxyController) SetGatewayAPIVersions(gatewayAPIVersions k8s.GatewayAPIVersions) {
+	c.gatewayManager.SetGatewayAPIVersions(gatewayAPIVersions)
 }
 
 func (c *HAProxyController) manageIngress(ing *store.Ingress) {
@@ -363,6 +432,7 @@ func (c *HAProxyController) manageIngress(ing *store.Ingress) {
 		logger.Debugf("ingress '%s/%s' ignored: no matching", ing.Namespace, ing.Name)
 	} else {
 		i.Update(c.store, c.haproxy, c.annotations)
+		c.acmeManager.AddIngress(ing)
 	}
 	if ing.Status == store.ADDED || ing.ClassUpdated {
 		c.updateStatusManager.AddIngress(i)
@@ -0,0 +1,116 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
+)
+
+// configErrorLocation matches the location of a configuration error, like "[/etc/haproxy/haproxy.cfg:42]"
+var configErrorLocation = regexp.MustCompile(`\[([^\[\]]+):(\d+)\]`)
+
+// configMapAnnotationError logs the error of a ConfigMap annotation and reports it on the ConfigMap.
+func (c *HAProxyController) configMapAnnotationError(name string, err error) {
+	logger.Errorf("annotation %s: %s", name, err)
+	c.reportConfigMapError(name, err)
+}
+
+// reportConfigMapError reports the error of an annotation on the ConfigMap if it is set there,
+// default values come from the controller arguments.
+func (c *HAProxyController) reportConfigMapError(name string, err error) {
+	if _, ok := c.store.ConfigMaps.Main.Annotations[name]; !ok {
+		return
+	}
+	events.Error(c.store.ConfigMaps.Main.Owner(), events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation %s: %w", name, err))
+}
+
+// reportConfigError reports the errors of a configuration rejected by HAProxy on the resources
+// owning the sections of the lines in error, the configuration files are read from dir.
+func (c *HAProxyController) reportConfigError(reason string, configErr error, dir string) {
+	contents := map[string][]string{}
+	for _, msg := range strings.Split(configErr.Error(), "\n") {
+		location := configErrorLocation.FindStringSubmatch(msg)
+		if location == nil {
+			continue
+		}
+		file := filepath.Join(dir, filepath.Base(location[1]))
+		lines, ok := contents[file]
+		if !ok {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				logger.Debugf("unable to find the resources of configuration error: %s", err)
+			}
+			lines = strings.Split(string(data), "\n")
+			contents[file] = lines
+		}
+		lineNumber, _ := strconv.Atoi(location[2])
+		for _, owner := range c.sectionOwners(lines, lineNumber) {
+			events.Error(owner, reason, errors.New(strings.TrimSpace(msg)))
+		}
+	}
+}
+
+// sectionOwners returns the resources owning the configuration section of a line, lines start at 1.
+func (c *HAProxyController) sectionOwners(lines []string, lineNumber int) []rc.Owner {
+	if lineNumber > len(lines) {
+		return nil
+	}
+	for i := lineNumber - 1; i >= 0; i-- {
+		line := lines[i]
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "backend":
+			if len(fields) > 1 {
+				return ownersOf(c.store.BackendRC, fields[1])
+			}
+		case "frontend":
+			if len(fields) < 2 {
+				return nil
+			}
+			owners := ownersOf(c.store.FrontendRC, fields[1])
+			// frontend snippets and settings of the main frontends come from the ConfigMap
+			switch fields[1] {
+			case c.haproxy.FrontHTTP, c.haproxy.FrontHTTPS, "stats":
+				owners = append(owners, c.store.ConfigMaps.Main.Owner())
+			}
+			return owners
+		case "global", "defaults":
+			return []rc.Owner{c.store.ConfigMaps.Main.Owner()}
+		}
+		return nil
+	}
+	return nil
+}
+
+func ownersOf(counter *rc.ResourceCounter, name string) []rc.Owner {
+	keys, _ := counter.GetOwners(rc.HaproxyCfgResourceName(name))
+	owners := make([]rc.Owner, 0, len(keys))
+	for key := range keys {
+		owners = append(owners, key.Owner())
+	}
+	return owners
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
 	"github.com/haproxytech/kubernetes-ingress/pkg/controller/constants"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/env"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
@@ -33,6 +34,9 @@ import (
 )
 
 func (c *HAProxyController) handleGlobalConfig() {
+	if c.store.ConfigMaps.Main.Loaded {
+		events.Processed(c.store.ConfigMaps.Main.Owner())
+	}
 	c.globalCfg()
 	c.defaultsCfg()
 	c.handleDefaultCert()
@@ -61,10 +65,12 @@ func (c *HAProxyController) globalCfg() {
 	newGlobal, err = annotations.ModelGlobal("cr-global", c.podNamespace, c.store, c.store.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Errorf("Global config: %s", err)
+		c.reportConfigMapError("cr-global", err)
 	}
 	newLg, err = annotations.ModelLog("cr-global", c.podNamespace, c.store, c.store.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Errorf("Global logging: %s", err)
+		c.reportConfigMapError("cr-global", err)
 	}
 	if newGlobal == nil {
 		newGlobal = &models.Global{
@@ -78,7 +84,7 @@ func (c *HAProxyController) globalCfg() {
 		for _, a := range c.annotations.Global(newGlobal, &newLg) {
 			err = a.Process(c.store, c.store.ConfigMaps.Main.Annotations)
 			if err != nil {
-				logger.Errorf("annotation %s: %s", a.GetName(), err)
+				c.configMapAnnotationError(a.GetName(), err)
 			}
 		}
 	}
@@ -110,7 +116,7 @@ func (c *HAProxyController) globalCfgSnipp() {
 	for _, a := range c.annotations.GlobalCfgSnipp() {
 		err = a.Process(c.store, c.store.ConfigMaps.Main.Annotations)
 		if err != nil {
-			logger.Errorf("annotation %s: %s", a.GetName(), err)
+			c.configMapAnnotationError(a.GetName(), err)
 		}
 	}
 	updatedSnipp, errSnipp := annotations.UpdateGlobalCfgSnippet(c.haproxy)
@@ -134,12 +140,15 @@ func (c *HAProxyController) defaultsCfg() {
 	newDefaults, err = annotations.ModelDefaults("cr-defaults", c.podNamespace, c.store, c.store.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Errorf("Defaults config: %s", err)
+		c.reportConfigMapError("cr-defaults", err)
 	}
 	if newDefaults == nil {
 		newDefaults = &models.Defaults{}
 		newDefaults.Name = constants.DefaultsSectionName
 		for _, a := range c.annotations.Defaults(newDefaults) {
-			logger.Error(a.Process(c.store, c.store.ConfigMaps.Main.Annotations))
+			if err = a.Process(c.store, c.store.ConfigMaps.Main.Annotations); err != nil {
+				c.configMapAnnotationError(a.GetName(), err)
+			}
 		}
 	}
 	env.SetDefaults(newDefaults)
@@ -161,6 +170,7 @@ func (c *HAProxyController) handleDefaultService() {
 	namespace, name, err := common.GetK8sPath("default-backend-service", c.store.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Errorf("default service: %s", err)
+		c.reportConfigMapError("default-backend-service", err)
 	}
 	if name == "" {
 		return
@@ -175,6 +185,7 @@ func (c *HAProxyController) handleDefaultService() {
 	}
 	if err != nil {
 		logger.Errorf("default service: %s", err)
+		c.reportConfigMapError("default-backend-service", err)
 	}
 }
 
@@ -253,6 +264,7 @@ func (c *HAProxyController) handleDefaultCert() {
 	secret, err := annotations.Secret("ssl-certificate", c.podNamespace, c.store, c.store.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Errorf("default certificate: %s", err)
+		c.reportConfigMapError("ssl-certificate", err)
 		return
 	}
 	if secret == nil {
@@ -260,4 +272,5 @@ func (c *HAProxyController) handleDefaultCert() {
 	}
 	_, err = c.haproxy.AddSecret(secret, certs.FT_DEFAULT_CERT)
 	logger.Error(err)
+	c.haproxy.AddReference(certs.FT_DEFAULT_CERT, secret.Namespace, secret.Name, "ConfigMap ssl-certificate")
 }
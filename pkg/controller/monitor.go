@@ -33,8 +33,9 @@ func (c *HAProxyController) SyncData() {
 		switch job.SyncType {
 		case k8ssync.COMMAND:
 			c.auxCfgManager()
+			syncRequested := instance.SyncRequested()
 			// create a NeedAction function.
-			if hadChanges || instance.NeedReload() {
+			if c.leadershipAcquired() || hadChanges || instance.NeedReload() || syncRequested {
 				c.updateHAProxy()
 				hadChanges = false
 				if job.EventProcessed != nil {
@@ -84,8 +85,12 @@ func (c *HAProxyController) SyncData() {
 			change = c.store.EventGateway(ns, job.Data.(*store.Gateway))
 		case k8ssync.TCPROUTE:
 			change = c.store.EventTCPRoute(ns, job.Data.(*store.TCPRoute))
+		case k8ssync.HTTPROUTE:
+			change = c.store.EventHTTPRoute(ns, job.Data.(*store.HTTPRoute))
 		case k8ssync.REFERENCEGRANT:
 			change = c.store.EventReferenceGrant(ns, job.Data.(*store.ReferenceGrant))
+		case k8ssync.BACKENDTLSPOLICY:
+			change = c.store.EventBackendTLSPolicy(ns, job.Data.(*store.BackendTLSPolicy))
 		case k8ssync.CR_TCP:
 			var data *store.TCPs
 			if job.Data != nil {
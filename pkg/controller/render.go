@@ -0,0 +1,112 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/renameio"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// Render writes in osArgs.RenderOutputDir the HAProxy configuration, maps and certificates
+// the controller would produce for the resources of osArgs.RenderManifests, without cluster nor HAProxy.
+func Render(osArgs utils.OSArgs, haproxyCfgFile []byte) error {
+	events, err := k8s.LoadManifests(osArgs.RenderManifests, osArgs)
+	if err != nil {
+		return err
+	}
+	outputDir, err := filepath.Abs(osArgs.RenderOutputDir)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	// No HAProxy process, runtime API, controller data server or leader election
+	osArgs.Test = true
+	osArgs.External = false
+	osArgs.ControllerPort = 0
+	osArgs.PrometheusEnabled = false
+	osArgs.LeaderElection = false
+
+	haproxyEnv := defaultEnv
+	haproxyEnv.CfgDir = outputDir
+	haproxyEnv.MainCFGFile = filepath.Join(outputDir, "haproxy.cfg")
+	haproxyEnv.StateDir = filepath.Join(outputDir, "state")
+
+	// the configuration file must exist before the client parses it
+	if err = renameio.WriteFile(haproxyEnv.MainCFGFile, haproxyCfgFile, 0o644); err != nil {
+		return fmt.Errorf("failed to write haproxy config file: %w", err)
+	}
+	client, err := api.NewFileOnly(haproxyEnv.CfgDir, haproxyEnv.MainCFGFile, "echo")
+	if err != nil {
+		return fmt.Errorf("failed to initialize haproxy API client: %w", err)
+	}
+
+	eventChan := make(chan k8ssync.SyncDataEvent, len(events))
+	c := NewBuilder().
+		WithHaproxyCfgFile(haproxyCfgFile).
+		WithEventChan(eventChan).
+		WithStore(store.NewK8sStore(osArgs)).
+		WithHaproxyEnv(haproxyEnv).
+		WithHaproxyClient(client).
+		WithUpdateStatusManager(renderStatusManager{}).
+		WithArgs(osArgs).
+		Build()
+	defer close(c.chShutdown)
+
+	for _, event := range events {
+		eventChan <- event
+	}
+	close(eventChan)
+	c.render()
+	logger.Infof("HAProxy configuration of %d resources rendered in %s", len(events), outputDir)
+	return nil
+}
+
+// render runs a single sync of the events of the channel, as Start does with a live cluster,
+// and writes all files on disk as for a reload.
+func (c *HAProxyController) render() {
+	c.initHandlers()
+	logger.Error(c.setupHAProxyRules())
+	c.SyncData()
+	c.auxCfgManager()
+	instance.Reload("offline render")
+	c.updateHAProxy()
+	fs.RunDelayedFuncs()
+}
+
+// renderStatusManager ignores ingress statuses as there is no cluster to update.
+type renderStatusManager struct{}
+
+func (renderStatusManager) AddIngress(*ingress.Ingress) {}
+
+func (renderStatusManager) Update(store.K8s, haproxy.HAProxy, annotations.Annotations) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+const component = "haproxy-ingress-controller"
+
+var logger = utils.GetLogger()
+
+// Emitter emits the Kubernetes Events of the resources.
+type Emitter interface {
+	Emit(object Object, eventType, reason, message string)
+}
+
+type k8sEmitter struct {
+	client        kubernetes.Interface
+	recorder      record.EventRecorder
+	leaderElector leader.Elector
+}
+
+// NewEmitter returns an Emitter creating Events with client, only the leader emits them
+// so that each error is reported once whatever the number of replicas.
+func NewEmitter(client kubernetes.Interface, leaderElector leader.Elector) Emitter { //nolint:ireturn
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return &k8sEmitter{
+		client:        client,
+		recorder:      broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: component}),
+		leaderElector: leaderElector,
+	}
+}
+
+func (e *k8sEmitter) Emit(object Object, eventType, reason, message string) {
+	if !e.leaderElector.IsLeader() {
+		return
+	}
+	go func() {
+		ref := &corev1.ObjectReference{
+			Kind:       object.Kind,
+			APIVersion: object.APIVersion,
+			Namespace:  object.Namespace,
+			Name:       object.Name,
+		}
+		// the UID links the Event to the resource, for instance in kubectl describe
+		uid, err := e.getUID(object)
+		if err != nil {
+			logger.Debugf("event '%s' of %s '%s/%s' not emitted: %s", reason, object.Kind, object.Namespace, object.Name, err)
+			return
+		}
+		ref.UID = uid
+		e.recorder.Event(ref, eventType, reason, message)
+	}()
+}
+
+func (e *k8sEmitter) getUID(object Object) (uid types.UID, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var meta metav1.Object
+	switch object.Kind {
+	case "Ingress":
+		meta, err = e.client.NetworkingV1().Ingresses(object.Namespace).Get(ctx, object.Name, metav1.GetOptions{})
+	case "Service":
+		meta, err = e.client.CoreV1().Services(object.Namespace).Get(ctx, object.Name, metav1.GetOptions{})
+	case "ConfigMap":
+		meta, err = e.client.CoreV1().ConfigMaps(object.Namespace).Get(ctx, object.Name, metav1.GetOptions{})
+	default:
+		// custom resources are not in the clientset, their Events are only listed by name
+		return uid, nil
+	}
+	if err != nil {
+		return uid, err
+	}
+	return meta.GetUID(), nil
+}
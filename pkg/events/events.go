@@ -0,0 +1,219 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
+)
+
+//nolint:golint,stylecheck
+const (
+	CONDITION_CONFIGURED = "Configured"
+	// Reasons of events and conditions
+	REASON_CONFIGURED              = "Configured"
+	REASON_INVALID_ANNOTATION      = "InvalidAnnotation"
+	REASON_INVALID_RESOURCE        = "InvalidResource"
+	REASON_INVALID_PATH            = "InvalidPath"
+	REASON_CONFIG_SNIPPET_DISABLED = "ConfigSnippetDisabled"
+	REASON_SYNC_FAILED             = "SyncFailed"
+	REASON_RELOAD_FAILED           = "ReloadFailed"
+)
+
+var DefaultRecorder = NewRecorder()
+
+// Processed records that the resource of owner has been applied in the running sync.
+func Processed(owner rc.Owner) {
+	DefaultRecorder.Processed(owner)
+}
+
+// Error records an error of the resource of owner in the running sync.
+func Error(owner rc.Owner, reason string, err error) {
+	DefaultRecorder.Error(owner, reason, err)
+}
+
+// Publish ends the running sync, see Recorder.Publish.
+func Publish(emitter Emitter) {
+	DefaultRecorder.Publish(emitter)
+}
+
+// Conditions returns the Configured condition of the resources.
+func Conditions() []ObjectCondition {
+	return DefaultRecorder.Conditions()
+}
+
+// Object is a Kubernetes resource configured by the controller.
+type Object struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ObjectCondition is the Configured condition of a resource.
+type ObjectCondition struct {
+	Object
+	Condition metav1.Condition `json:"condition"`
+}
+
+type objectError struct {
+	reason  string
+	message string
+}
+
+// Recorder collects the resources applied and their errors during a sync, they are published
+// at the end of the sync as Kubernetes Events and kept as the Configured condition of each resource.
+// Conditions are only kept in memory, they are not written to the status of the resources.
+type Recorder struct {
+	processed  map[Object]struct{}
+	errors     map[Object][]objectError
+	conditions map[Object]metav1.Condition
+	mu         sync.Mutex
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		processed:  map[Object]struct{}{},
+		errors:     map[Object][]objectError{},
+		conditions: map[Object]metav1.Condition{},
+	}
+}
+
+func (r *Recorder) Processed(owner rc.Owner) {
+	object, ok := objectOf(owner)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed[object] = struct{}{}
+}
+
+func (r *Recorder) Error(owner rc.Owner, reason string, err error) {
+	object, ok := objectOf(owner)
+	if !ok || err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	message := strings.TrimSpace(err.Error())
+	for _, e := range r.errors[object] {
+		if e.reason == reason && e.message == message {
+			return
+		}
+	}
+	r.errors[object] = append(r.errors[object], objectError{reason: reason, message: message})
+}
+
+// Publish updates the Configured condition of the resources of the sync and emits an Event when it changes,
+// a Warning per error or a Normal event once the errors are fixed. The conditions of resources which have
+// not been applied are removed as the resources have been deleted or are not handled by the controller anymore.
+// emitter can be nil to only update the conditions.
+func (r *Recorder) Publish(emitter Emitter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := metav1.Now()
+	for object := range r.errors {
+		r.processed[object] = struct{}{}
+	}
+	for object := range r.processed {
+		condition := metav1.Condition{
+			Type:    CONDITION_CONFIGURED,
+			Status:  metav1.ConditionTrue,
+			Reason:  REASON_CONFIGURED,
+			Message: "HAProxy configuration applied",
+		}
+		errs := r.errors[object]
+		if len(errs) > 0 {
+			messages := make([]string, 0, len(errs))
+			for _, e := range errs {
+				messages = append(messages, e.message)
+			}
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = errs[0].reason
+			condition.Message = strings.Join(messages, "; ")
+		}
+		previous, exists := r.conditions[object]
+		condition.LastTransitionTime = now
+		if exists && previous.Status == condition.Status {
+			condition.LastTransitionTime = previous.LastTransitionTime
+		}
+		r.conditions[object] = condition
+		if emitter == nil || (exists && previous.Status == condition.Status && previous.Message == condition.Message) {
+			continue
+		}
+		switch {
+		case len(errs) > 0:
+			for _, e := range errs {
+				emitter.Emit(object, corev1.EventTypeWarning, e.reason, e.message)
+			}
+		case exists:
+			emitter.Emit(object, corev1.EventTypeNormal, REASON_CONFIGURED, "configuration errors fixed, HAProxy configuration applied")
+		}
+	}
+	for object := range r.conditions {
+		if _, ok := r.processed[object]; !ok {
+			delete(r.conditions, object)
+		}
+	}
+	r.processed = map[Object]struct{}{}
+	r.errors = map[Object][]objectError{}
+}
+
+func (r *Recorder) Conditions() []ObjectCondition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conditions := make([]ObjectCondition, 0, len(r.conditions))
+	for object, condition := range r.conditions {
+		conditions = append(conditions, ObjectCondition{Object: object, Condition: condition})
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		a, b := conditions[i].Object, conditions[j].Object
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return conditions
+}
+
+// objectOf returns the Kubernetes resource of an owner.
+func objectOf(owner rc.Owner) (object Object, ok bool) {
+	object = Object{Namespace: owner.Namespace(), Name: owner.Name()}
+	switch owner.Type() {
+	case rc.INGRESS:
+		object.Kind, object.APIVersion = "Ingress", "networking.k8s.io/v1"
+	case rc.SERVICE:
+		object.Kind, object.APIVersion = "Service", "v1"
+	case rc.CONFIGMAP, rc.TCP_CONFIGMAP:
+		object.Kind, object.APIVersion = "ConfigMap", "v1"
+	case rc.TCP_CR:
+		// the owners of TCP custom resources are the TCP items, named after their resource
+		object.Kind, object.APIVersion = "TCP", "ingress.v3.haproxy.org/v3"
+		object.Name, _, _ = strings.Cut(object.Name, "/")
+	default:
+		return object, false
+	}
+	return object, object.Name != ""
+}
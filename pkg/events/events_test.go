@@ -0,0 +1,85 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
+)
+
+type event struct {
+	object    Object
+	eventType string
+	reason    string
+}
+
+type fakeEmitter struct {
+	events []event
+}
+
+func (e *fakeEmitter) Emit(object Object, eventType, reason, message string) {
+	e.events = append(e.events, event{object: object, eventType: eventType, reason: reason})
+}
+
+func TestPublish(t *testing.T) {
+	recorder := NewRecorder()
+	emitter := &fakeEmitter{}
+	ingress := rc.NewOwner(rc.INGRESS, "ns", "app")
+	object := Object{Kind: "Ingress", APIVersion: "networking.k8s.io/v1", Namespace: "ns", Name: "app"}
+
+	// errors are reported once per sync as a Warning
+	recorder.Processed(ingress)
+	recorder.Error(ingress, REASON_INVALID_ANNOTATION, errors.New("annotation timeout-server: invalid duration"))
+	recorder.Error(ingress, REASON_INVALID_ANNOTATION, errors.New("annotation timeout-server: invalid duration"))
+	recorder.Publish(emitter)
+	require.Len(t, emitter.events, 1)
+	assert.Equal(t, event{object: object, eventType: corev1.EventTypeWarning, reason: REASON_INVALID_ANNOTATION}, emitter.events[0])
+	conditions := recorder.Conditions()
+	require.Len(t, conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, conditions[0].Condition.Status)
+	assert.Equal(t, "annotation timeout-server: invalid duration", conditions[0].Condition.Message)
+
+	// the same errors are not emitted again
+	recorder.Error(ingress, REASON_INVALID_ANNOTATION, errors.New("annotation timeout-server: invalid duration"))
+	recorder.Publish(emitter)
+	assert.Len(t, emitter.events, 1)
+
+	// fixing the errors is reported once
+	recorder.Processed(ingress)
+	recorder.Publish(emitter)
+	require.Len(t, emitter.events, 2)
+	assert.Equal(t, event{object: object, eventType: corev1.EventTypeNormal, reason: REASON_CONFIGURED}, emitter.events[1])
+	conditions = recorder.Conditions()
+	require.Len(t, conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, conditions[0].Condition.Status)
+
+	// resources not processed anymore lose their condition
+	recorder.Publish(emitter)
+	assert.Empty(t, recorder.Conditions())
+	assert.Len(t, emitter.events, 2)
+}
+
+func TestObjectOfTCP(t *testing.T) {
+	object, ok := objectOf(rc.NewOwner(rc.TCP_CR, "ns", "tcp-1/tcp-http"))
+	require.True(t, ok)
+	assert.Equal(t, Object{Kind: "TCP", APIVersion: "ingress.v3.haproxy.org/v3", Namespace: "ns", Name: "tcp-1"}, object)
+}
@@ -17,19 +17,21 @@ package gateway
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/haproxytech/client-native/v6/models"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
 	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 	networkingv1 "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/gateway-api/apis/v1alpha2"
-	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 var logger = utils.GetLogger()
@@ -40,47 +42,55 @@ var logger = utils.GetLogger()
 const (
 	K8S_CORE_GROUP       = ""
 	K8S_NETWORKING_GROUP = networkingv1.GroupName
-	K8S_GATEWAY_GROUP    = v1beta1.GroupName
+	K8S_GATEWAY_GROUP    = gatewayv1.GroupName
 	K8S_TCPROUTE_KIND    = "TCPRoute"
+	K8S_HTTPROUTE_KIND   = "HTTPRoute"
 	K8S_SERVICE_KIND     = "Service"
 )
 
 //nolint:golint
 type GatewayManager interface {
 	ManageGateway()
-	SetGatewayAPIInstalled(bool)
+	SetGatewayAPIVersions(k8s.GatewayAPIVersions)
 }
 
 func New(k8sStore store.K8s,
 	haproxyClient api.HAProxyClient,
+	certificates certs.Certificates,
 	osArgs utils.OSArgs,
 	k8sRestClient client.Client,
+	leaderElector leader.Elector,
 ) GatewayManager {
 	return &GatewayManagerImpl{
 		k8sStore:         k8sStore,
 		haproxyClient:    haproxyClient,
+		certificates:     certificates,
 		osArgs:           osArgs,
 		frontends:        map[string]struct{}{},
 		gateways:         map[string]struct{}{},
-		statusManager:    NewStatusManager(k8sRestClient, k8sStore.GatewayControllerName),
+		statusManager:    NewStatusManager(k8sRestClient, k8sStore.GatewayControllerName, leaderElector),
 		listenersByRoute: make(map[string][]store.Listener),
 		backends:         map[string]struct{}{},
 		serversByBackend: map[string][]string{},
+		rulesByFrontend:  map[string][]string{},
 	}
 }
 
 //nolint:golint
 type GatewayManagerImpl struct {
 	haproxyClient       api.HAProxyClient
+	certificates        certs.Certificates
 	statusManager       StatusManager
 	frontends           map[string]struct{}
 	gateways            map[string]struct{}
 	listenersByRoute    map[string][]store.Listener
 	backends            map[string]struct{}
 	serversByBackend    map[string][]string
+	rulesByFrontend     map[string][]string
 	k8sStore            store.K8s
 	osArgs              utils.OSArgs
 	gatewayAPIInstalled bool
+	httpRouteInstalled  bool
 }
 
 func (gm GatewayManagerImpl) ManageGateway() {
@@ -88,15 +98,34 @@ func (gm GatewayManagerImpl) ManageGateway() {
 		return
 	}
 	gm.clean()
+	gm.statusManager.SetAddresses(gm.publishServiceAddresses())
 	gm.manageGatewayClass()
 
 	gm.manageListeners()
 	gm.manageTCPRoutes()
+	gm.manageHTTPRoutes()
 
 	gm.statusManager.ProcessStatuses()
 	gm.resetStatuses()
 }
 
+// publishServiceAddresses returns the addresses of the publish service, they are mirrored into gateways statuses.
+func (gm GatewayManagerImpl) publishServiceAddresses() []string {
+	namespace, name, found := strings.Cut(gm.osArgs.PublishService, "/")
+	if !found {
+		return nil
+	}
+	ns, ok := gm.k8sStore.Namespaces[namespace]
+	if !ok {
+		return nil
+	}
+	service, ok := ns.Services[name]
+	if !ok {
+		return nil
+	}
+	return service.Addresses
+}
+
 // clean deletes the frontends created by the gateway controller
 // We recreate all frontends on each round but we reload only if a frontend has been added, modified or deleted.
 func (gm GatewayManagerImpl) clean() {
@@ -169,7 +198,7 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 			gm.statusManager.PrepareTCPRouteStatusRecord(*tcproute)
 
 			// Get the list of listeners (frontends) this tcproute (set of backends) wants to be attached to.
-			listeners, errListeners := gm.getOurListenersFromTCPRoute(*tcproute)
+			listeners, errListeners := gm.getOurListeners(K8S_TCPROUTE_KIND, tcproute.Namespace, tcproute.Name, tcproute.ParentRefs)
 			logger.Error(errListeners)
 			for _, listener := range listeners {
 				frontendName := getFrontendName(listener)
@@ -216,7 +245,7 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 			instance.ReloadIf(!backendExists, "modification in backend for tcproute '%s/%s'", tcproute.Namespace, tcproute.Name)
 			gm.backends[tcpRouteBackendName] = struct{}{}
 			// Adds the servers to the backends
-			reloadServers, errServers := gm.addServersToRoute(*tcproute)
+			reloadServers, errServers := gm.addServersToBackend(tcpRouteBackendName, K8S_TCPROUTE_KIND, tcproute.Namespace, tcproute.Name, tcproute.BackendRefs, listeners)
 			instance.ReloadIf(reloadServers, "modification in servers of backend '%s' from tcproute '%s/%s'", tcpRouteBackendName, tcproute.Namespace, tcproute.Name)
 			logger.Error(errServers)
 		}
@@ -232,25 +261,28 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 	}
 }
 
-// createAllListeners creates all TCP frontends from gateway and their bindings.
+// createAllListeners creates all frontends from gateway and their bindings.
+// TCP and TLS listeners get TCP frontends for TCPRoutes, HTTP and HTTPS listeners get HTTP frontends for HTTPRoutes.
 func (gm GatewayManagerImpl) createAllListeners(gateway store.Gateway) error {
 	var errs utils.Errors
 MAIN_LOOP:
 	for _, listener := range gateway.Listeners {
 		gm.statusManager.PrepareListenerStatus(listener)
-		if listener.Protocol != store.TCPProtocolType {
+		terminateTLS := isTLSTerminated(listener)
+		if !gm.isListenerProtocolSupported(listener) {
 			gm.statusManager.SetListenerReasonUnsupportedProtocol(fmt.Sprintf("Listener protocol '%s' is not supported", listener.Protocol))
 			continue
 		}
+		routeKind := getListenerRouteKind(listener)
 		if listener.AllowedRoutes != nil {
 			validRGK := []store.RouteGroupKind{}
 			for _, kind := range listener.AllowedRoutes.Kinds {
-				if (kind.Group == nil || *kind.Group == v1alpha2.GroupName) && kind.Kind == K8S_TCPROUTE_KIND {
+				if (kind.Group == nil || *kind.Group == gatewayv1.GroupName) && kind.Kind == routeKind {
 					validRGK = append(validRGK, kind)
 				}
 			}
 			if len(validRGK) != len(listener.AllowedRoutes.Kinds) {
-				gm.statusManager.SetListenerReasonInvalidRouteKinds("Invalid Group/Kind in allowedRoutes: only gateway.networking.k8s.io or empty group and TCPRoute kind are supported", validRGK)
+				gm.statusManager.SetListenerReasonInvalidRouteKinds(fmt.Sprintf("Invalid Group/Kind in allowedRoutes: only gateway.networking.k8s.io or empty group and %s kind are supported", routeKind), validRGK)
 			}
 			if len(validRGK) == 0 && len(listener.AllowedRoutes.Kinds) != 0 {
 				continue MAIN_LOOP
@@ -258,11 +290,30 @@ MAIN_LOOP:
 		}
 
 		frontendName := getFrontendName(listener)
-		errFrontendCreate := gm.haproxyClient.FrontendCreate(models.FrontendBase{
+		var bindParams models.BindParams
+		if terminateTLS {
+			crtList, ok := gm.writeListenerCertificates(listener, frontendName)
+			if !ok {
+				continue
+			}
+			bindParams = models.BindParams{
+				Ssl:            true,
+				SslCertificate: crtList,
+			}
+		}
+		frontend := models.FrontendBase{
 			Name:   frontendName,
 			Mode:   "tcp",
 			Tcplog: true,
-		})
+		}
+		if routeKind == K8S_HTTPROUTE_KIND {
+			frontend = models.FrontendBase{
+				Name:    frontendName,
+				Mode:    "http",
+				Httplog: true,
+			}
+		}
+		errFrontendCreate := gm.haproxyClient.FrontendCreate(frontend)
 		if errFrontendCreate != nil {
 			errs.Add(errFrontendCreate)
 			continue
@@ -279,7 +330,7 @@ MAIN_LOOP:
 						}
 						return "0.0.0.0"
 					}(),
-					BindParams: models.BindParams{Name: "v4"},
+					BindParams: withBindName(bindParams, "v4"),
 				})
 			if errBinCreate != nil {
 				errs.Add(errBinCreate)
@@ -296,7 +347,7 @@ MAIN_LOOP:
 						}
 						return ":::"
 					}(),
-					BindParams: models.BindParams{Name: "v6"},
+					BindParams: withBindName(bindParams, "v6"),
 				})
 			if errBinCreate != nil {
 				errs.Add(errBinCreate)
@@ -327,7 +378,7 @@ func (gm GatewayManagerImpl) isBackendRefValid(backendRef store.BackendRef) bool
 
 // isNamespaceGranted checks that backendref can refer to a resource.
 // This check depends on cross namespace reference and authorization to do so by referenceGrant if necessary.
-func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef store.BackendRef) (granted bool) {
+func (gm GatewayManagerImpl) isNamespaceGranted(kind, namespace string, backendRef store.BackendRef) (granted bool) {
 	// If namespace of backendRef is specified ...
 	if backendRef.Namespace != nil && *backendRef.Namespace != namespace {
 		ns, found := gm.k8sStore.Namespaces[*backendRef.Namespace]
@@ -335,27 +386,8 @@ func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef sto
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", utils.PointerDefaultValueIfNil(backendRef.Namespace), backendRef.Name))
 			return granted
 		}
-		// .. we iterate over referenceGrants in this namespace.
-		for _, referenceGrant := range ns.ReferenceGrants {
-			fromGranted := false
-			// If referenceGrant allows tcproutes from their namespace to be origin to attach ...
-			for _, from := range referenceGrant.From {
-				if from.Group == K8S_GATEWAY_GROUP && from.Kind == K8S_TCPROUTE_KIND && from.Namespace == namespace {
-					fromGranted = true
-					break
-				}
-			}
-			// ... then check if it allows the target backendRef which must be a service potentially named.
-			if fromGranted {
-				for _, to := range referenceGrant.To {
-					if to.Group == K8S_CORE_GROUP && to.Kind == K8S_SERVICE_KIND &&
-						(to.Name == nil || *to.Name == backendRef.Name) {
-						granted = true
-						break
-					}
-				}
-			}
-		}
+		// ... a referencegrant must allow routes of this kind from their namespace to refer to the service.
+		granted = gm.isReferenceGranted(namespace, kind, *backendRef.Namespace, K8S_SERVICE_KIND, backendRef.Name)
 		if !granted {
 			gm.statusManager.SetRouteReasonRefNotPermitted(fmt.Sprintf("backendref '%s/%s' not allowed by any referencegrant",
 				*backendRef.Namespace, backendRef.Name))
@@ -365,9 +397,38 @@ func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef sto
 	return true
 }
 
-// addServersToRoute adds all the servers from the backendrefs from tcproute according validation rules.
-func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload bool, err error) {
-	backendName := getBackendName(route)
+// isReferenceGranted checks that a referencegrant of the target namespace allows a gateway api resource of kind fromKind
+// in fromNamespace to refer to the core resource of kind toKind named toName.
+func (gm GatewayManagerImpl) isReferenceGranted(fromNamespace, fromKind, toNamespace, toKind, toName string) bool {
+	ns, found := gm.k8sStore.Namespaces[toNamespace]
+	if !found || !ns.Relevant {
+		return false
+	}
+	for _, referenceGrant := range ns.ReferenceGrants {
+		fromGranted := false
+		for _, from := range referenceGrant.From {
+			if from.Group == K8S_GATEWAY_GROUP && from.Kind == fromKind && from.Namespace == fromNamespace {
+				fromGranted = true
+				break
+			}
+		}
+		if !fromGranted {
+			continue
+		}
+		for _, to := range referenceGrant.To {
+			if to.Group == K8S_CORE_GROUP && to.Kind == toKind &&
+				(to.Name == nil || *to.Name == toName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addServersToBackend adds to the backend all the servers from the backendrefs of a route of the provided kind according validation rules.
+// The listeners the route is attached to are the ancestors reported in the status of the backendtlspolicies.
+func (gm GatewayManagerImpl) addServersToBackend(backendName, kind, routeNamespace, routeName string, backendRefs []store.BackendRef, listeners []store.Listener) (reload bool, err error) {
+	routeKind := strings.ToLower(kind)
 	_ = gm.haproxyClient.BackendServerDeleteAll(backendName)
 	i := 0
 	var servers []string
@@ -376,30 +437,30 @@ func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload boo
 		reload = reload || !utils.EqualSliceStringsWithoutOrder(servers, previousServers)
 		gm.serversByBackend[backendName] = servers
 	}()
-	for id, backendRef := range route.BackendRefs {
+	for id, backendRef := range backendRefs {
 		if !gm.isBackendRefValid(backendRef) {
 			continue
 		}
 
-		if !gm.isNamespaceGranted(route.Namespace, backendRef) {
+		if !gm.isNamespaceGranted(kind, routeNamespace, backendRef) {
 			gm.statusManager.SetRouteReasonRefNotPermitted(fmt.Sprintf("backend '%s/%s' reference not allowed", utils.PointerDefaultValueIfNil(backendRef.Namespace), backendRef.Name))
 			continue
 		}
 
 		nsBackendRef := backendRef.Namespace
 		if nsBackendRef == nil {
-			nsBackendRef = &route.Namespace
+			nsBackendRef = &routeNamespace
 		}
 		ns, found := gm.k8sStore.Namespaces[*nsBackendRef]
 		if !found {
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting namespace '%s' for backendRef number '%d' from tcp route '%s/%s'", *nsBackendRef, id, route.Namespace, route.Name)
+			logger.Errorf("gwapi: unexisting namespace '%s' for backendRef number '%d' from %s '%s/%s'", *nsBackendRef, id, routeKind, routeNamespace, routeName)
 			continue
 		}
 		service, found := ns.Services[backendRef.Name]
 		if !found {
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from tcp route '%s/%s'", backendRef.Name, id, route.Namespace, route.Name)
+			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from %s '%s/%s'", backendRef.Name, id, routeKind, routeNamespace, routeName)
 			continue
 		}
 		var portName *string
@@ -413,13 +474,30 @@ func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload boo
 		}
 		if portName == nil {
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend port '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting port '%d' for backendRef '%spkg/gateways/gateways.go' number '%d' from tcp route '%s/%s'", backendRefPort, backendRef.Name, id, route.Namespace, route.Name)
+			logger.Errorf("gwapi: unexisting port '%d' for backendRef '%s' number '%d' from %s '%s/%s'", backendRefPort, backendRef.Name, id, routeKind, routeNamespace, routeName)
 			continue
 		}
+		serverParams := models.ServerParams{Maintenance: "disabled"}
+		// tlsID is part of the server identity so that a change in TLS settings triggers a reload.
+		var tlsID string
+		if policy := getBackendTLSPolicy(ns, backendRef.Name, *portName); policy != nil {
+			ancestors := getPolicyAncestors(listeners)
+			tlsParams, errTLS := gm.getBackendTLSServerParams(*policy)
+			if errTLS != nil {
+				gm.statusManager.SetBackendTLSPolicyStatus(*policy, ancestors, PolicyReasonInvalid, errTLS.Error())
+				gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' has an invalid backendtlspolicy", *nsBackendRef, backendRef.Name))
+				logger.Errorf("gwapi: backendtlspolicy '%s/%s' for backendRef number '%d' from %s '%s/%s': %s", policy.Namespace, policy.Name, id, routeKind, routeNamespace, routeName, errTLS)
+				continue
+			}
+			gm.statusManager.SetBackendTLSPolicyStatus(*policy, ancestors, PolicyReasonAccepted, "")
+			tlsParams.Maintenance = serverParams.Maintenance
+			serverParams = tlsParams
+			tlsID = fmt.Sprintf(" ssl ca-file %s sni %s", tlsParams.SslCafile, tlsParams.Sni)
+		}
 		slice, found := ns.Endpoints[backendRef.Name]
 		if !found {
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from tcp route '%s/%s'", backendRef.Name, id, route.Namespace, route.Name)
+			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from %s '%s/%s'", backendRef.Name, id, routeKind, routeNamespace, routeName)
 			continue
 		}
 
@@ -429,12 +507,12 @@ func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload boo
 			}
 			if port, found := endpoints.Ports[*portName]; found {
 				for address := range port.Addresses {
-					servers = append(servers, fmt.Sprintf("%s:%d", address, port.Port))
+					servers = append(servers, fmt.Sprintf("%s:%d%s", address, port.Port, tlsID))
 					err = gm.haproxyClient.BackendServerCreate(backendName, models.Server{
 						Address:      address,
 						Port:         &port.Port,
 						Name:         fmt.Sprintf("SRV_%d", i+1),
-						ServerParams: models.ServerParams{Maintenance: "disabled"},
+						ServerParams: serverParams,
 					})
 					if err != nil {
 						return reload, err
@@ -447,24 +525,25 @@ func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload boo
 	return reload, err
 }
 
-// getOurListenersFromTCPRoute computes the list of listeners the tcproute can be attached to according matching and authorizations rules.
-func (gm GatewayManagerImpl) getOurListenersFromTCPRoute(tcproute store.TCPRoute) ([]store.Listener, error) {
+// getOurListeners computes the list of listeners the route of the provided kind can be attached to according matching and authorizations rules.
+func (gm GatewayManagerImpl) getOurListeners(kind, routeNamespace, routeName string, parentRefs []store.ParentRef) ([]store.Listener, error) {
+	routeKind := strings.ToLower(kind)
 	var errors utils.Errors
 	listeners := []store.Listener{}
 	// Iterates over parentRefs  which must be a gateway
-	for i, parentRef := range tcproute.ParentRefs {
-		gatewayNs := tcproute.Namespace
+	for i, parentRef := range parentRefs {
+		gatewayNs := routeNamespace
 		if parentRef.Namespace != nil {
 			gatewayNs = *parentRef.Namespace
 		}
 		ns, found := gm.k8sStore.Namespaces[gatewayNs]
 		if !found {
-			errors.Add(fmt.Errorf("gwapi: unexisting namespace '%s' in parentRef number '%d' from tcp route '%s/%s'", gatewayNs, i, tcproute.Namespace, tcproute.Name))
+			errors.Add(fmt.Errorf("gwapi: unexisting namespace '%s' in parentRef number '%d' from %s '%s/%s'", gatewayNs, i, routeKind, routeNamespace, routeName))
 			continue
 		}
 		gw, found := ns.Gateways[parentRef.Name]
 		if !found || gw == nil {
-			errors.Add(fmt.Errorf("gwapi: unexisting gateway in parentRef '%s' from tcp route '%s/%s'", parentRef.Name, tcproute.Namespace, tcproute.Name))
+			errors.Add(fmt.Errorf("gwapi: unexisting gateway in parentRef '%s' from %s '%s/%s'", parentRef.Name, routeKind, routeNamespace, routeName))
 			continue
 		}
 		if !gm.isGatewayManaged(*gw) || gw.Status == store.DELETED {
@@ -479,7 +558,7 @@ func (gm GatewayManagerImpl) getOurListenersFromTCPRoute(tcproute store.TCPRoute
 				continue
 			}
 			// Does listener allow the route to be attached ?
-			if !gm.isTCPRouteAllowedByListener(listener, tcproute.Namespace, gatewayNs, parentRef) {
+			if !gm.isRouteAllowedByListener(kind, listener, routeNamespace, gatewayNs, parentRef) {
 				continue
 			}
 			// Does the listener have the expected name if provided ?
@@ -535,22 +614,27 @@ func (gm GatewayManagerImpl) isGatewayManaged(gateway store.Gateway) bool {
 	return gwc.ControllerName == gm.k8sStore.GatewayControllerName
 }
 
-// isTCPRouteAllowedByListener checks if the tcproute can refer to the listener according listener's authorization rules.
-func (gm GatewayManagerImpl) isTCPRouteAllowedByListener(listener store.Listener, routeNamespace, gatewayNamespace string, parentRef store.ParentRef) (allowed bool) {
+// isRouteAllowedByListener checks if the route of the provided kind can refer to the listener according listener's authorization rules.
+func (gm GatewayManagerImpl) isRouteAllowedByListener(kind string, listener store.Listener, routeNamespace, gatewayNamespace string, parentRef store.ParentRef) (allowed bool) {
 	defer func() {
 		if !allowed {
 			gm.statusManager.SetRouteReasonNotAllowedByListeners(fmt.Sprintf("not allowed by listener '%s/%s/%s'", listener.GwNamespace, listener.GwName, listener.Name), parentRef)
 		}
 	}()
 
+	// The listener protocol determines the only kind of routes it can accept.
+	if getListenerRouteKind(listener) != kind {
+		return false
+	}
+
 	if listener.AllowedRoutes == nil {
 		// If the listener has no restrictions rules simply checks that the route and the listener (gateway) are in the same namespace.
-		return routeNamespace != gatewayNamespace
+		return routeNamespace == gatewayNamespace
 	}
 
 	gkAllowed := len(listener.AllowedRoutes.Kinds) == 0
-	for _, kind := range listener.AllowedRoutes.Kinds {
-		if (kind.Group != nil && *kind.Group != v1alpha2.GroupName) || kind.Kind != K8S_TCPROUTE_KIND {
+	for _, rgk := range listener.AllowedRoutes.Kinds {
+		if (rgk.Group != nil && *rgk.Group != gatewayv1.GroupName) || rgk.Kind != kind {
 			continue
 		}
 		gkAllowed = true
@@ -563,13 +647,13 @@ func (gm GatewayManagerImpl) isTCPRouteAllowedByListener(listener store.Listener
 	if allowedRoutesNamespaces != nil {
 		from := allowedRoutesNamespaces.From
 		if from == nil {
-			v := (string)(v1alpha2.NamespacesFromSame)
+			v := (string)(gatewayv1.NamespacesFromSame)
 			from = &v
 		}
 		if *from == "Same" {
 			return routeNamespace == gatewayNamespace
 		}
-		if *from == (string)(v1alpha2.NamespacesFromSelector) {
+		if *from == (string)(gatewayv1.NamespacesFromSelector) {
 			if allowedRoutesNamespaces.Selector == nil {
 				return false
 			}
@@ -652,9 +736,22 @@ func (gm *GatewayManagerImpl) resetStatuses() {
 				tcproute.Status = store.EMPTY
 			}
 		}
+		for _, httproute := range ns.HTTPRoutes {
+			if httproute.Status == store.ADDED || httproute.Status == store.MODIFIED {
+				httproute.Status = store.EMPTY
+			}
+		}
+		for _, policy := range ns.BackendTLSPolicies {
+			if policy.Status == store.ADDED || policy.Status == store.MODIFIED {
+				policy.Status = store.EMPTY
+			}
+		}
 	}
 }
 
-func (gm *GatewayManagerImpl) SetGatewayAPIInstalled(gatewayAPIInstalled bool) {
-	gm.gatewayAPIInstalled = gatewayAPIInstalled
+// SetGatewayAPIVersions sets the Gateway API versions served by the cluster, Gateway API being disabled if not installed.
+func (gm *GatewayManagerImpl) SetGatewayAPIVersions(gatewayAPIVersions k8s.GatewayAPIVersions) {
+	gm.gatewayAPIInstalled = gatewayAPIVersions.Installed()
+	gm.httpRouteInstalled = gatewayAPIVersions.HTTPRoute != ""
+	gm.statusManager.SetGatewayAPIVersions(gatewayAPIVersions)
 }
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPublishServiceTestStore(addresses []string) store.K8s {
+	k8sStore := store.NewK8sStore(utils.OSArgs{})
+	k8sStore.Namespaces["haproxy-controller"] = &store.Namespace{
+		Name: "haproxy-controller",
+		Services: map[string]*store.Service{
+			"haproxy-kubernetes-ingress": {
+				Namespace: "haproxy-controller",
+				Name:      "haproxy-kubernetes-ingress",
+				Addresses: addresses,
+			},
+		},
+	}
+	return k8sStore
+}
+
+func TestPublishServiceAddresses(t *testing.T) {
+	tests := []struct {
+		name           string
+		publishService string
+		addresses      []string
+		expected       []string
+	}{
+		{name: "not set", addresses: []string{"10.0.0.1"}},
+		{name: "no namespace", publishService: "haproxy-kubernetes-ingress", addresses: []string{"10.0.0.1"}},
+		{name: "unknown namespace", publishService: "default/haproxy-kubernetes-ingress", addresses: []string{"10.0.0.1"}},
+		{name: "unknown service", publishService: "haproxy-controller/unknown", addresses: []string{"10.0.0.1"}},
+		{name: "no address", publishService: "haproxy-controller/haproxy-kubernetes-ingress"},
+		{name: "empty addresses", publishService: "haproxy-controller/haproxy-kubernetes-ingress", addresses: []string{}, expected: []string{}},
+		{name: "addresses", publishService: "haproxy-controller/haproxy-kubernetes-ingress", addresses: []string{"10.0.0.1", "lb.example.com"}, expected: []string{"10.0.0.1", "lb.example.com"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gm := GatewayManagerImpl{
+				k8sStore: newPublishServiceTestStore(test.addresses),
+				osArgs:   utils.OSArgs{PublishService: test.publishService},
+			}
+			assert.Equal(t, test.expected, gm.publishServiceAddresses())
+		})
+	}
+}
+
+func TestPublishServiceAddressesChanged(t *testing.T) {
+	k8sStore := newPublishServiceTestStore(nil)
+	gm := GatewayManagerImpl{
+		k8sStore: k8sStore,
+		osArgs:   utils.OSArgs{PublishService: "haproxy-controller/haproxy-kubernetes-ingress"},
+	}
+	statusManager := &StatusManagerImpl{}
+	ns := k8sStore.Namespaces["haproxy-controller"]
+	publish := func(status store.Status, addresses []string) {
+		k8sStore.EventPublishService(ns, &store.Service{
+			Namespace: "haproxy-controller",
+			Name:      "haproxy-kubernetes-ingress",
+			Status:    status,
+			Addresses: addresses,
+		})
+	}
+
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.False(t, statusManager.addressesChanged(), "no load balancer ingress yet")
+
+	publish(store.ADDED, []string{"10.0.0.1"})
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.True(t, statusManager.addressesChanged(), "load balancer ingress assigned")
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.False(t, statusManager.addressesChanged(), "same load balancer ingress")
+
+	publish(store.MODIFIED, []string{"lb.example.com", "10.0.0.1"})
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.True(t, statusManager.addressesChanged(), "hostname added to the load balancer ingress")
+
+	publish(store.MODIFIED, []string{"10.0.0.1", "lb.example.com"})
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.False(t, statusManager.addressesChanged(), "load balancer ingress reordered")
+
+	publish(store.MODIFIED, []string{"10.0.0.2", "lb.example.com"})
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.True(t, statusManager.addressesChanged(), "load balancer ingress IP changed")
+
+	publish(store.DELETED, nil)
+	statusManager.SetAddresses(gm.publishServiceAddresses())
+	assert.True(t, statusManager.addressesChanged(), "publish service deleted")
+	assert.Empty(t, gatewayStatusAddresses(statusManager.addresses))
+}
@@ -0,0 +1,358 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// httpRouteRule is a routing rule of a HTTP frontend, requests matching the hostname and the path are sent to the backend.
+// An empty hostname matches any request.
+type httpRouteRule struct {
+	hostname string
+	pathType string
+	path     string
+	backend  string
+}
+
+// manageHTTPRoutes creates a backend for each rule of the httproutes and routes the requests of the listeners they are attached to.
+func (gm GatewayManagerImpl) manageHTTPRoutes() {
+	routesByListeners := map[string]*utils.Pair[store.Listener, store.HTTPRoutes]{}
+	for _, ns := range gm.k8sStore.Namespaces {
+		if !ns.Relevant {
+			logger.Debugf("gwapi: skipping namespace '%s'", ns.Name)
+			continue
+		}
+		logger.Debugf("gwapi: namespace '%s' has %d httproutes", ns.Name, len(ns.HTTPRoutes))
+		for httproutename, httproute := range ns.HTTPRoutes {
+			if httproute == nil {
+				logger.Warningf("gwapi: nil httproute under name '%s'", httproutename)
+				continue
+			}
+			routeName := getHTTPRouteName(*httproute)
+			if httproute.Status == store.DELETED {
+				delete(ns.HTTPRoutes, httproute.Name)
+				delete(gm.listenersByRoute, routeName)
+				instance.Reload("httproute '%s/%s' deleted", httproute.Namespace, httproute.Name)
+				continue
+			}
+			gm.statusManager.PrepareHTTPRouteStatusRecord(*httproute)
+
+			// Get the list of listeners (frontends) this httproute wants to be attached to.
+			listeners, errListeners := gm.getOurListeners(K8S_HTTPROUTE_KIND, httproute.Namespace, httproute.Name, httproute.ParentRefs)
+			logger.Error(errListeners)
+			for _, listener := range listeners {
+				frontendName := getFrontendName(listener)
+				if rbl, ok := routesByListeners[frontendName]; ok {
+					rbl.P2 = append(rbl.P2, *httproute)
+				} else {
+					pair := utils.NewPair(listener, store.HTTPRoutes{*httproute})
+					routesByListeners[frontendName] = &pair
+				}
+			}
+			previousAssociatedListeners := gm.listenersByRoute[routeName]
+			gm.listenersByRoute[routeName] = listeners
+
+			instance.ReloadIf(((len(listeners) != 0 || len(listeners) == 0 && len(previousAssociatedListeners) != 0) &&
+				!utils.EqualSliceByIDFunc(listeners, previousAssociatedListeners, extractNameFromListener)),
+				"modification in listeners for httproute '%s/%s'", httproute.Namespace, httproute.Name)
+
+			if len(listeners) == 0 {
+				continue
+			}
+			for i, rule := range httproute.Rules {
+				if unsupported := getHTTPRouteRuleUnsupportedFeatures(rule); len(unsupported) != 0 {
+					gm.statusManager.SetRouteReasonUnsupportedValue(fmt.Sprintf("rule %d: %s not supported", i, strings.Join(unsupported, ", ")))
+					continue
+				}
+				gm.manageHTTPRouteRuleBackend(*httproute, i, listeners)
+			}
+		}
+	}
+
+	// Routes are ordered by creation time and then added to the listeners they are attached to.
+	for frontendName, rbl := range routesByListeners {
+		sort.SliceStable(rbl.P2, rbl.P2.Less)
+		logger.Error(gm.addHTTPRoutesToListener(frontendName, rbl.P2, rbl.P1))
+	}
+	for frontendName := range gm.rulesByFrontend {
+		if _, ok := routesByListeners[frontendName]; !ok {
+			delete(gm.rulesByFrontend, frontendName)
+			instance.Reload("routing rules of frontend '%s' removed", frontendName)
+		}
+	}
+}
+
+// manageHTTPRouteRuleBackend creates the backend of the rule of the httproute with its timeouts and its servers.
+func (gm GatewayManagerImpl) manageHTTPRouteRuleBackend(httproute store.HTTPRoute, ruleIndex int, listeners []store.Listener) {
+	rule := httproute.Rules[ruleIndex]
+	backendName := getHTTPRouteBackendName(httproute, ruleIndex)
+	backend := models.Backend{
+		BackendBase: models.BackendBase{
+			Name:          backendName,
+			Mode:          "http",
+			DefaultServer: &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled"}},
+		},
+	}
+	serverTimeout, errTimeouts := getHTTPRouteServerTimeout(rule.Timeouts)
+	if errTimeouts != nil {
+		gm.statusManager.SetRouteReasonUnsupportedValue(fmt.Sprintf("rule %d: %s", ruleIndex, errTimeouts))
+		logger.Errorf("gwapi: httproute '%s/%s' rule %d: %s", httproute.Namespace, httproute.Name, ruleIndex, errTimeouts)
+	}
+	backend.ServerTimeout = serverTimeout
+	diff, created := gm.haproxyClient.BackendCreateOrUpdate(backend)
+	instance.ReloadIf(created || len(diff) != 0, "modification in backend '%s' for httproute '%s/%s'", backendName, httproute.Namespace, httproute.Name)
+	gm.backends[backendName] = struct{}{}
+	// Adds the servers to the backend
+	reloadServers, errServers := gm.addServersToBackend(backendName, K8S_HTTPROUTE_KIND, httproute.Namespace, httproute.Name, rule.BackendRefs, listeners)
+	instance.ReloadIf(reloadServers, "modification in servers of backend '%s' from httproute '%s/%s'", backendName, httproute.Namespace, httproute.Name)
+	logger.Error(errServers)
+}
+
+// addHTTPRoutesToListener routes the requests of the frontend to the backends of the httproutes rules.
+func (gm GatewayManagerImpl) addHTTPRoutesToListener(frontendName string, routes store.HTTPRoutes, listener store.Listener) error {
+	rules := []httpRouteRule{}
+	for _, route := range routes {
+		hostnames, match := getHTTPRouteHostnames(listener.Hostname, route.Hostnames)
+		if !match {
+			logger.Warningf("gwapi: no hostname of httproute '%s/%s' matches listener '%s/%s/%s'", route.Namespace, route.Name, listener.GwNamespace, listener.GwName, listener.Name)
+			continue
+		}
+		rules = append(rules, getHTTPRouteRules(route, hostnames)...)
+		gm.statusManager.IncrementRouteForListener(listener)
+	}
+	sortHTTPRouteRules(rules)
+
+	var errs utils.Errors
+	switchingRules := make([]string, 0, len(rules))
+	id := int64(0)
+	for _, rule := range rules {
+		for _, condition := range rule.conditions() {
+			errs.Add(gm.haproxyClient.BackendSwitchingRuleCreate(id, frontendName, models.BackendSwitchingRule{
+				Name:     rule.backend,
+				Cond:     "if",
+				CondTest: condition,
+			}))
+			switchingRules = append(switchingRules, rule.backend+" if "+condition)
+			id++
+		}
+	}
+	instance.ReloadIf(!utils.EqualSliceComparable(switchingRules, gm.rulesByFrontend[frontendName]), "modification in routing rules of frontend '%s'", frontendName)
+	gm.rulesByFrontend[frontendName] = switchingRules
+	return errs.Result()
+}
+
+// getHTTPRouteRules returns the routing rules of the httproute for the provided hostnames.
+// Rules with unsupported features or without backendRefs are not routed.
+func getHTTPRouteRules(route store.HTTPRoute, hostnames []string) []httpRouteRule {
+	rules := []httpRouteRule{}
+	for i, rule := range route.Rules {
+		if len(getHTTPRouteRuleUnsupportedFeatures(rule)) != 0 || len(rule.BackendRefs) == 0 {
+			continue
+		}
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []store.HTTPRouteMatch{{PathType: string(gatewayv1.PathMatchPathPrefix), PathValue: "/"}}
+		}
+		for _, hostname := range hostnames {
+			for _, match := range matches {
+				rules = append(rules, httpRouteRule{
+					hostname: hostname,
+					pathType: match.PathType,
+					path:     match.PathValue,
+					backend:  getHTTPRouteBackendName(route, i),
+				})
+			}
+		}
+	}
+	return rules
+}
+
+// sortHTTPRouteRules orders the rules as required by Gateway API: the most specific hostnames first
+// then exact paths before prefixes and longest prefixes first, the order of routes being kept otherwise.
+func sortHTTPRouteRules(rules []httpRouteRule) {
+	hostnameRank := func(hostname string) int {
+		switch {
+		case hostname == "":
+			return 2
+		case strings.HasPrefix(hostname, "*"):
+			return 1
+		default:
+			return 0
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		ruleI, ruleJ := rules[i], rules[j]
+		if rankI, rankJ := hostnameRank(ruleI.hostname), hostnameRank(ruleJ.hostname); rankI != rankJ {
+			return rankI < rankJ
+		}
+		if len(ruleI.hostname) != len(ruleJ.hostname) {
+			return len(ruleI.hostname) > len(ruleJ.hostname)
+		}
+		if ruleI.pathType != ruleJ.pathType {
+			return ruleI.pathType == string(gatewayv1.PathMatchExact)
+		}
+		return len(ruleI.path) > len(ruleJ.path)
+	})
+}
+
+// conditions returns the ACL conditions of the use_backend statements of the rule.
+// A prefix matches the path elements so a rule needs two conditions, one for the prefix itself and one for the paths below it.
+func (rule httpRouteRule) conditions() []string {
+	var hostname string
+	switch {
+	case rule.hostname == "":
+	case strings.HasPrefix(rule.hostname, "*"):
+		hostname = fmt.Sprintf("{ req.hdr(host),field(1,:) -i -m end %s } ", strings.TrimPrefix(rule.hostname, "*"))
+	default:
+		hostname = fmt.Sprintf("{ req.hdr(host),field(1,:) -i -m str %s } ", rule.hostname)
+	}
+	if rule.pathType == string(gatewayv1.PathMatchExact) {
+		return []string{hostname + fmt.Sprintf("{ path -m str %s }", rule.path)}
+	}
+	prefix := strings.TrimSuffix(rule.path, "/")
+	if prefix == "" {
+		if hostname == "" {
+			return []string{"TRUE"}
+		}
+		return []string{strings.TrimSuffix(hostname, " ")}
+	}
+	return []string{
+		hostname + fmt.Sprintf("{ path -m str %s }", prefix),
+		hostname + fmt.Sprintf("{ path -m beg %s/ }", prefix),
+	}
+}
+
+// getHTTPRouteHostnames returns the hostnames the httproute is matched against on the listener.
+// Route hostnames are restricted to the ones matching the listener hostname, match is false if none of them does.
+// An empty hostname matches any request.
+func getHTTPRouteHostnames(listenerHostname *string, routeHostnames []string) (hostnames []string, match bool) {
+	if listenerHostname == nil || *listenerHostname == "" {
+		if len(routeHostnames) == 0 {
+			return []string{""}, true
+		}
+		return routeHostnames, true
+	}
+	if len(routeHostnames) == 0 {
+		return []string{*listenerHostname}, true
+	}
+	for _, hostname := range routeHostnames {
+		switch {
+		case hostname == *listenerHostname, isHostnameCoveredBy(hostname, *listenerHostname):
+			hostnames = append(hostnames, hostname)
+		case isHostnameCoveredBy(*listenerHostname, hostname):
+			hostnames = append(hostnames, *listenerHostname)
+		}
+	}
+	return hostnames, len(hostnames) != 0
+}
+
+// isHostnameCoveredBy tells if the hostname is matched by the wildcard hostname, "*.example.com" matching "foo.example.com".
+func isHostnameCoveredBy(hostname, wildcard string) bool {
+	if !strings.HasPrefix(wildcard, "*.") || strings.HasPrefix(hostname, "*.") {
+		return false
+	}
+	return strings.HasSuffix(hostname, wildcard[1:])
+}
+
+// getHTTPRouteServerTimeout returns the server timeout in milliseconds of the backend of a httproute rule.
+// backendRequest bounds a single request to a server, request the whole request which is also the server timeout
+// when backendRequest is not set. A zero duration disables the timeout, nil keeps the default timeout.
+func getHTTPRouteServerTimeout(timeouts *store.HTTPRouteTimeouts) (*int64, error) {
+	if timeouts == nil {
+		return nil, nil //nolint:nilnil
+	}
+	request, err := parseHTTPRouteTimeout(timeouts.Request)
+	if err != nil {
+		return nil, err
+	}
+	backendRequest, err := parseHTTPRouteTimeout(timeouts.BackendRequest)
+	if err != nil {
+		return nil, err
+	}
+	if backendRequest == nil {
+		return request, nil
+	}
+	if request != nil && *request != 0 && (*backendRequest == 0 || *backendRequest > *request) {
+		return nil, fmt.Errorf("backendRequest timeout '%s' is greater than request timeout '%s'", *timeouts.BackendRequest, *timeouts.Request)
+	}
+	return backendRequest, nil
+}
+
+// parseHTTPRouteTimeout converts a Gateway API duration into milliseconds.
+func parseHTTPRouteTimeout(timeout *string) (*int64, error) {
+	if timeout == nil {
+		return nil, nil //nolint:nilnil
+	}
+	duration, err := time.ParseDuration(*timeout)
+	if err != nil || duration < 0 {
+		return nil, fmt.Errorf("invalid timeout '%s'", *timeout)
+	}
+	milliseconds := duration.Milliseconds()
+	return &milliseconds, nil
+}
+
+// getHTTPRouteRuleUnsupportedFeatures returns the features of the rule the controller can't translate, such a rule is not routed.
+func getHTTPRouteRuleUnsupportedFeatures(rule store.HTTPRouteRule) []string {
+	unsupported := append([]string{}, rule.Unsupported...)
+	for _, match := range rule.Matches {
+		if match.PathType != string(gatewayv1.PathMatchExact) && match.PathType != string(gatewayv1.PathMatchPathPrefix) {
+			unsupported = append(unsupported, fmt.Sprintf("path match type '%s'", match.PathType))
+		}
+	}
+	return unsupported
+}
+
+// isListenerProtocolSupported tells if a frontend can be created for the listener.
+func (gm GatewayManagerImpl) isListenerProtocolSupported(listener store.Listener) bool {
+	switch listener.Protocol {
+	case store.TCPProtocolType:
+		return true
+	case string(gatewayv1.HTTPProtocolType):
+		return gm.httpRouteInstalled
+	case string(gatewayv1.HTTPSProtocolType):
+		return gm.httpRouteInstalled && isTLSTerminated(listener)
+	default:
+		return isTLSTerminated(listener)
+	}
+}
+
+// getListenerRouteKind returns the kind of routes the listener accepts according to its protocol.
+func getListenerRouteKind(listener store.Listener) string {
+	if listener.Protocol == string(gatewayv1.HTTPProtocolType) || listener.Protocol == string(gatewayv1.HTTPSProtocolType) {
+		return K8S_HTTPROUTE_KIND
+	}
+	return K8S_TCPROUTE_KIND
+}
+
+// getHTTPRouteName provides the name identifying the httproute among routes.
+func getHTTPRouteName(httproute store.HTTPRoute) string {
+	return "httproute_" + httproute.Namespace + "_" + httproute.Name
+}
+
+// getHTTPRouteBackendName provides the backend name of a rule of the httproute.
+func getHTTPRouteBackendName(httproute store.HTTPRoute, ruleIndex int) string {
+	return getHTTPRouteName(httproute) + "_" + strconv.Itoa(ruleIndex)
+}
@@ -0,0 +1,171 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHTTPRouteServerTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		timeouts       *store.HTTPRouteTimeouts
+		expected       *int64
+		expectedErrors bool
+	}{
+		{name: "not set"},
+		{name: "empty", timeouts: &store.HTTPRouteTimeouts{}},
+		{name: "request", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("10s")}, expected: utils.PtrInt64(10000)},
+		{name: "backend request", timeouts: &store.HTTPRouteTimeouts{BackendRequest: utils.PtrString("500ms")}, expected: utils.PtrInt64(500)},
+		{name: "both", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("1m"), BackendRequest: utils.PtrString("30s")}, expected: utils.PtrInt64(30000)},
+		{name: "disabled", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("0s")}, expected: utils.PtrInt64(0)},
+		{name: "disabled request", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("0s"), BackendRequest: utils.PtrString("5s")}, expected: utils.PtrInt64(5000)},
+		{name: "backend request greater", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("5s"), BackendRequest: utils.PtrString("10s")}, expectedErrors: true},
+		{name: "backend request disabled", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("5s"), BackendRequest: utils.PtrString("0s")}, expectedErrors: true},
+		{name: "invalid", timeouts: &store.HTTPRouteTimeouts{Request: utils.PtrString("10")}, expectedErrors: true},
+		{name: "negative", timeouts: &store.HTTPRouteTimeouts{BackendRequest: utils.PtrString("-1s")}, expectedErrors: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeout, err := getHTTPRouteServerTimeout(test.timeouts)
+			if test.expectedErrors {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, timeout)
+		})
+	}
+}
+
+func TestHTTPRouteRuleConditions(t *testing.T) {
+	tests := []struct {
+		rule     httpRouteRule
+		expected []string
+	}{
+		{
+			rule:     httpRouteRule{pathType: "PathPrefix", path: "/"},
+			expected: []string{"TRUE"},
+		},
+		{
+			rule:     httpRouteRule{hostname: "echo.example.com", pathType: "PathPrefix", path: "/"},
+			expected: []string{"{ req.hdr(host),field(1,:) -i -m str echo.example.com }"},
+		},
+		{
+			rule: httpRouteRule{hostname: "*.example.com", pathType: "PathPrefix", path: "/api/"},
+			expected: []string{
+				"{ req.hdr(host),field(1,:) -i -m end .example.com } { path -m str /api }",
+				"{ req.hdr(host),field(1,:) -i -m end .example.com } { path -m beg /api/ }",
+			},
+		},
+		{
+			rule:     httpRouteRule{pathType: "Exact", path: "/healthz"},
+			expected: []string{"{ path -m str /healthz }"},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.rule.conditions())
+	}
+}
+
+func TestGetHTTPRouteRules(t *testing.T) {
+	route := store.HTTPRoute{
+		Namespace: "default",
+		Name:      "echo",
+		Rules: []store.HTTPRouteRule{
+			{BackendRefs: []store.BackendRef{{Name: "echo"}}},
+			{
+				Matches:     []store.HTTPRouteMatch{{PathType: "PathPrefix", PathValue: "/api"}, {PathType: "Exact", PathValue: "/status"}},
+				BackendRefs: []store.BackendRef{{Name: "api"}},
+			},
+			{
+				Matches:     []store.HTTPRouteMatch{{PathType: "RegularExpression", PathValue: "/v[0-9]+"}},
+				BackendRefs: []store.BackendRef{{Name: "versioned"}},
+			},
+			{Matches: []store.HTTPRouteMatch{{PathType: "PathPrefix", PathValue: "/empty"}}},
+			{
+				Matches:     []store.HTTPRouteMatch{{PathType: "PathPrefix", PathValue: "/filtered"}},
+				BackendRefs: []store.BackendRef{{Name: "filtered"}},
+				Unsupported: []string{"filters"},
+			},
+		},
+	}
+	rules := getHTTPRouteRules(route, []string{"", "*.example.com"})
+	sortHTTPRouteRules(rules)
+	assert.Equal(t, []httpRouteRule{
+		{hostname: "*.example.com", pathType: "Exact", path: "/status", backend: "httproute_default_echo_1"},
+		{hostname: "*.example.com", pathType: "PathPrefix", path: "/api", backend: "httproute_default_echo_1"},
+		{hostname: "*.example.com", pathType: "PathPrefix", path: "/", backend: "httproute_default_echo_0"},
+		{hostname: "", pathType: "Exact", path: "/status", backend: "httproute_default_echo_1"},
+		{hostname: "", pathType: "PathPrefix", path: "/api", backend: "httproute_default_echo_1"},
+		{hostname: "", pathType: "PathPrefix", path: "/", backend: "httproute_default_echo_0"},
+	}, rules)
+}
+
+func TestGetHTTPRouteRuleUnsupportedFeatures(t *testing.T) {
+	rule := store.HTTPRouteRule{
+		Matches:     []store.HTTPRouteMatch{{PathType: "RegularExpression", PathValue: "/v[0-9]+"}},
+		Unsupported: make([]string, 1, 2),
+	}
+	rule.Unsupported[0] = "filters"
+	assert.Equal(t, []string{"filters", "path match type 'RegularExpression'"}, getHTTPRouteRuleUnsupportedFeatures(rule))
+	assert.Equal(t, []string{"filters"}, rule.Unsupported, "rule features are not modified")
+}
+
+func TestGetHTTPRouteHostnames(t *testing.T) {
+	tests := []struct {
+		name              string
+		listenerHostname  *string
+		routeHostnames    []string
+		expectedHostnames []string
+		expectedMatch     bool
+	}{
+		{name: "no hostname", expectedHostnames: []string{""}, expectedMatch: true},
+		{name: "route hostnames", routeHostnames: []string{"a.example.com"}, expectedHostnames: []string{"a.example.com"}, expectedMatch: true},
+		{name: "listener hostname", listenerHostname: utils.PtrString("a.example.com"), expectedHostnames: []string{"a.example.com"}, expectedMatch: true},
+		{
+			name:              "listener wildcard",
+			listenerHostname:  utils.PtrString("*.example.com"),
+			routeHostnames:    []string{"a.example.com", "b.example.org", "*.example.com"},
+			expectedHostnames: []string{"a.example.com", "*.example.com"},
+			expectedMatch:     true,
+		},
+		{
+			name:              "route wildcard",
+			listenerHostname:  utils.PtrString("a.example.com"),
+			routeHostnames:    []string{"*.example.com"},
+			expectedHostnames: []string{"a.example.com"},
+			expectedMatch:     true,
+		},
+		{name: "no match", listenerHostname: utils.PtrString("a.example.com"), routeHostnames: []string{"b.example.com"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hostnames, match := getHTTPRouteHostnames(test.listenerHostname, test.routeHostnames)
+			assert.Equal(t, test.expectedMatch, match)
+			assert.Equal(t, test.expectedHostnames, hostnames)
+		})
+	}
+}
+
+func TestIsRouteAllowedByListener(t *testing.T) {
+	statusManager := &StatusManagerImpl{}
+	statusManager.PrepareHTTPRouteStatusRecord(store.HTTPRoute{Namespace: "default", Name: "route"})
+	gm := GatewayManagerImpl{k8sStore: store.NewK8sStore(utils.OSArgs{}), statusManager: statusManager}
+	gatewayNs := "default"
+	parentRef := store.ParentRef{Namespace: &gatewayNs, Name: "gateway1"}
+	listener := store.Listener{Name: "http", Protocol: "HTTP", GwNamespace: "default", GwName: "gateway1"}
+
+	// without allowedRoutes, only the routes of the gateway namespace are allowed
+	assert.True(t, gm.isRouteAllowedByListener(K8S_HTTPROUTE_KIND, listener, "default", gatewayNs, parentRef))
+	assert.False(t, gm.isRouteAllowedByListener(K8S_HTTPROUTE_KIND, listener, "other", gatewayNs, parentRef))
+	// the listener protocol determines the kind of routes
+	assert.False(t, gm.isRouteAllowedByListener(K8S_TCPROUTE_KIND, listener, "default", gatewayNs, parentRef))
+
+	all := "All"
+	listener.AllowedRoutes = &store.AllowedRoutes{Namespaces: &store.RouteNamespaces{From: &all}}
+	assert.True(t, gm.isRouteAllowedByListener(K8S_HTTPROUTE_KIND, listener, "other", gatewayNs, parentRef))
+}
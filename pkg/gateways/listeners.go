@@ -0,0 +1,122 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+//nolint:golint,stylecheck
+const (
+	// LISTENER_TLS_OPTION_PREFIX is the prefix of the listener TLS options handled by the controller.
+	LISTENER_TLS_OPTION_PREFIX = "haproxy.org/"
+)
+
+// listenerTLSOptions are the TLS options of listeners, applied to all the certificates of the listener.
+var listenerTLSOptions = []string{"ssl-min-ver", "ssl-ciphers", "ssl-ciphersuites", "ssl-curves", "tls-alpn"}
+
+// isTLSTerminated returns true if TLS connections are terminated by the listener.
+// The TLS mode defaults to Terminate, passthrough listeners are not supported.
+func isTLSTerminated(listener store.Listener) bool {
+	if listener.Protocol != string(gatewayv1.TLSProtocolType) && listener.Protocol != string(gatewayv1.HTTPSProtocolType) {
+		return false
+	}
+	if listener.TLS == nil {
+		return false
+	}
+	return listener.TLS.Mode == nil || *listener.TLS.Mode == string(gatewayv1.TLSModeTerminate)
+}
+
+// writeListenerCertificates writes the secrets referenced by the listener certificateRefs and lists them in the listener crt-list
+// with the TLS options of the listener.
+// Invalid or not permitted references are reported in the listener status and skipped,
+// ok is false if no certificate could be written as the listener can't be bound without any certificate.
+func (gm GatewayManagerImpl) writeListenerCertificates(listener store.Listener, frontendName string) (crtList string, ok bool) {
+	policy := getListenerTLSPolicy(listener)
+	for _, certRef := range listener.TLS.CertificateRefs {
+		namespace := utils.PointerDefaultValueIfNil(certRef.Namespace)
+		if namespace == "" {
+			namespace = listener.GwNamespace
+		}
+		if certRef.Group != K8S_CORE_GROUP || (certRef.Kind != "" && certRef.Kind != K8S_SECRET_KIND) {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(fmt.Sprintf("certificateRef '%s/%s' of group '%s' and kind '%s' not supported",
+				namespace, certRef.Name, certRef.Group, certRef.Kind))
+			continue
+		}
+		if namespace != listener.GwNamespace &&
+			!gm.isReferenceGranted(listener.GwNamespace, K8S_GATEWAY_KIND, namespace, K8S_SECRET_KIND, certRef.Name) {
+			gm.statusManager.SetListenerReasonRefNotPermitted(fmt.Sprintf("certificateRef '%s/%s' not allowed by any referencegrant", namespace, certRef.Name))
+			continue
+		}
+		secret, err := gm.k8sStore.GetSecret(namespace, certRef.Name)
+		if err != nil {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(err.Error())
+			continue
+		}
+		certPath, err := gm.certificates.AddSecret(secret, certs.GW_CERT)
+		if err != nil {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(fmt.Sprintf("certificateRef '%s/%s': %s", namespace, certRef.Name, err))
+			continue
+		}
+		gm.certificates.AddReference(certs.GW_CERT, namespace, certRef.Name, fmt.Sprintf("Gateway %s/%s listener %s", listener.GwNamespace, listener.GwName, listener.Name))
+		gm.certificates.AddListenerEntry(frontendName, certPath, policy)
+	}
+	if len(listener.TLS.CertificateRefs) == 0 {
+		gm.statusManager.SetListenerReasonInvalidCertificateRef("no certificateRefs provided to terminate TLS")
+	}
+	crtList = gm.certificates.ListenerCrtList(frontendName)
+	ok = crtList != ""
+	if !ok {
+		logger.Errorf("gwapi: listener '%s' of gateway '%s/%s': no valid certificate to terminate TLS", listener.Name, listener.GwNamespace, listener.GwName)
+	}
+	return crtList, ok
+}
+
+// getListenerTLSPolicy returns the TLS parameters set by the "haproxy.org/" prefixed options of the listener,
+// they are the same as the TLS policy annotations of Ingresses. Invalid options are logged and ignored.
+func getListenerTLSPolicy(listener store.Listener) certs.TLSPolicy {
+	var policy certs.TLSPolicy
+	tlsPolicy := ingress.NewTLSPolicy(&policy)
+	for _, key := range slices.Sorted(maps.Keys(listener.TLS.Options)) {
+		name, found := strings.CutPrefix(key, LISTENER_TLS_OPTION_PREFIX)
+		if !found {
+			continue
+		}
+		if !slices.Contains(listenerTLSOptions, name) {
+			logger.Errorf("gwapi: listener '%s' of gateway '%s/%s': unknown TLS option '%s'", listener.Name, listener.GwNamespace, listener.GwName, key)
+			continue
+		}
+		if err := tlsPolicy.NewAnnotation(name).Process(store.K8s{}, map[string]string{name: listener.TLS.Options[key]}); err != nil {
+			logger.Errorf("gwapi: listener '%s' of gateway '%s/%s': TLS option '%s': %s", listener.Name, listener.GwNamespace, listener.GwName, key, err)
+		}
+	}
+	return policy
+}
+
+// withBindName returns a copy of the bind parameters with the bind name set.
+func withBindName(params models.BindParams, name string) models.BindParams {
+	params.Name = name
+	return params
+}
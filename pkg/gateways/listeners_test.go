@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTLSTerminated(t *testing.T) {
+	tests := []struct {
+		listener store.Listener
+		expected bool
+	}{
+		{listener: store.Listener{Protocol: "TCP"}},
+		{listener: store.Listener{Protocol: "TLS"}},
+		{listener: store.Listener{Protocol: "TLS", TLS: &store.ListenerTLS{}}, expected: true},
+		{listener: store.Listener{Protocol: "HTTPS", TLS: &store.ListenerTLS{Mode: utils.PtrString("Terminate")}}, expected: true},
+		{listener: store.Listener{Protocol: "TLS", TLS: &store.ListenerTLS{Mode: utils.PtrString("Passthrough")}}},
+		{listener: store.Listener{Protocol: "HTTP", TLS: &store.ListenerTLS{}}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, isTLSTerminated(test.listener), "%+v", test.listener)
+	}
+}
+
+func TestGetListenerTLSPolicy(t *testing.T) {
+	listener := store.Listener{Name: "https", GwNamespace: "default", GwName: "gateway", TLS: &store.ListenerTLS{Options: map[string]string{
+		"haproxy.org/ssl-min-ver":      "TLSv1.2",
+		"haproxy.org/ssl-ciphers":      "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384",
+		"haproxy.org/ssl-curves":       "X25519:P-256 ; invalid",
+		"haproxy.org/tls-alpn":         "h2,http/1.1",
+		"haproxy.org/unknown":          "value",
+		"example.com/ssl-ciphersuites": "TLS_AES_128_GCM_SHA256",
+	}}}
+	assert.Equal(t, certs.TLSPolicy{
+		MinVer:  "TLSv1.2",
+		Ciphers: "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384",
+		ALPN:    "h2,http/1.1",
+	}, getListenerTLSPolicy(listener))
+	assert.Equal(t, certs.TLSPolicy{}, getListenerTLSPolicy(store.Listener{TLS: &store.ListenerTLS{}}))
+}
+
+func newListenerTestManager() (GatewayManagerImpl, *fakeCertificates, *StatusManagerImpl) {
+	certificates := newFakeCertificates()
+	statusManager := &StatusManagerImpl{}
+	k8sStore := store.NewK8sStore(utils.OSArgs{})
+	for _, namespace := range []string{"default", "certs"} {
+		ns := k8sStore.GetNamespace(namespace)
+		ns.Relevant = true
+		for _, name := range []string{"cert1", "cert2"} {
+			ns.Secret[name] = &store.Secret{Namespace: namespace, Name: name, Data: map[string][]byte{"tls.crt": []byte(name), "tls.key": []byte(name)}}
+		}
+		ns.Secret["no-key"] = &store.Secret{Namespace: namespace, Name: "no-key", Data: map[string][]byte{"tls.crt": []byte("no-key")}}
+	}
+	k8sStore.GetNamespace("certs").ReferenceGrants = map[string]*store.ReferenceGrant{
+		"gateway-certs": {
+			From: []store.ReferenceGrantFrom{{Group: K8S_GATEWAY_GROUP, Kind: K8S_GATEWAY_KIND, Namespace: "default"}},
+			To:   []store.ReferenceGrantTo{{Group: K8S_CORE_GROUP, Kind: K8S_SECRET_KIND, Name: utils.PtrString("cert2")}},
+		},
+	}
+	gm := GatewayManagerImpl{k8sStore: k8sStore, certificates: certificates, statusManager: statusManager}
+	statusManager.PrepareGatewayStatus(store.Gateway{Namespace: "default", Name: "gateway"})
+	return gm, certificates, statusManager
+}
+
+func TestWriteListenerCertificates(t *testing.T) {
+	gm, certificates, statusManager := newListenerTestManager()
+	listener := store.Listener{Name: "https", Protocol: "HTTPS", GwNamespace: "default", GwName: "gateway", TLS: &store.ListenerTLS{
+		Options: map[string]string{"haproxy.org/ssl-min-ver": "TLSv1.3"},
+		CertificateRefs: []store.SecretObjectRef{
+			{Kind: K8S_SECRET_KIND, Name: "cert2"},
+			{Name: "cert1"},
+			{Namespace: utils.PtrString("certs"), Kind: K8S_SECRET_KIND, Name: "cert1"},
+			{Namespace: utils.PtrString("certs"), Kind: K8S_SECRET_KIND, Name: "cert2"},
+			{Kind: K8S_SECRET_KIND, Name: "no-key"},
+			{Kind: K8S_SECRET_KIND, Name: "missing"},
+			{Group: "example.com", Kind: "Certificate", Name: "cert1"},
+		},
+	}}
+	statusManager.PrepareListenerStatus(listener)
+	crtList, ok := gm.writeListenerCertificates(listener, "gateway_default_gateway_https")
+	require.True(t, ok)
+	assert.Equal(t, "/etc/haproxy/certs/gateway/crt-lists/gateway_default_gateway_https.crt-list", crtList)
+	// certificates are listed in the order of the certificateRefs with the listener TLS options
+	assert.Equal(t, []string{
+		"/etc/haproxy/certs/gateway/default_cert2.pem {MinVer:TLSv1.3 Ciphers: Ciphersuites: Curves: ALPN:}",
+		"/etc/haproxy/certs/gateway/default_cert1.pem {MinVer:TLSv1.3 Ciphers: Ciphersuites: Curves: ALPN:}",
+		"/etc/haproxy/certs/gateway/certs_cert2.pem {MinVer:TLSv1.3 Ciphers: Ciphersuites: Curves: ALPN:}",
+	}, certificates.crtLists["gateway_default_gateway_https"])
+
+	reasons := statusManager.listener.reasons
+	assert.Equal(t, "certificateRef 'certs/cert1' not allowed by any referencegrant\n", reasons[ListenerReasonRefNotPermitted])
+	assert.Contains(t, reasons[ListenerReasonInvalidCertificateRef], "certificateRef 'default/no-key': private key missing")
+	assert.Contains(t, reasons[ListenerReasonInvalidCertificateRef], "missing")
+	assert.Contains(t, reasons[ListenerReasonInvalidCertificateRef], "certificateRef 'default/cert1' of group 'example.com' and kind 'Certificate' not supported")
+}
+
+func TestWriteListenerCertificatesNoValidCertificate(t *testing.T) {
+	gm, certificates, statusManager := newListenerTestManager()
+	listener := store.Listener{Name: "tls", Protocol: "TLS", GwNamespace: "default", GwName: "gateway", TLS: &store.ListenerTLS{}}
+	statusManager.PrepareListenerStatus(listener)
+	_, ok := gm.writeListenerCertificates(listener, "gateway_default_gateway_tls")
+	assert.False(t, ok)
+	assert.Equal(t, "no certificateRefs provided to terminate TLS\n", statusManager.listener.reasons[ListenerReasonInvalidCertificateRef])
+
+	listener.TLS.CertificateRefs = []store.SecretObjectRef{{Kind: K8S_SECRET_KIND, Name: "no-key"}}
+	statusManager.PrepareListenerStatus(listener)
+	_, ok = gm.writeListenerCertificates(listener, "gateway_default_gateway_tls")
+	assert.False(t, ok)
+	assert.Empty(t, certificates.crtLists)
+}
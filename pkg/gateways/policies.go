@@ -0,0 +1,169 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+//nolint:golint,stylecheck
+const (
+	K8S_CONFIGMAP_KIND       = "ConfigMap"
+	K8S_SECRET_KIND          = "Secret"
+	K8S_GATEWAY_KIND         = "Gateway"
+	WELL_KNOWN_CA_SYSTEM     = "System"
+	HAPROXY_SYSTEM_CA_FILE   = "@system-ca"
+	BACKENDTLSPOLICY_CA_NAME = "backendtlspolicy-%s"
+)
+
+// getBackendTLSPolicy returns the backendtlspolicy of the namespace targeting the service.
+// A policy targeting the service port by its name takes precedence over a policy targeting the whole service.
+// In case of conflict, policies are considered in alphabetical order.
+func getBackendTLSPolicy(ns *store.Namespace, serviceName, portName string) *store.BackendTLSPolicy {
+	names := make([]string, 0, len(ns.BackendTLSPolicies))
+	for name := range ns.BackendTLSPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var policy *store.BackendTLSPolicy
+	for _, name := range names {
+		candidate := ns.BackendTLSPolicies[name]
+		for _, targetRef := range candidate.TargetRefs {
+			if targetRef.Group != K8S_CORE_GROUP || targetRef.Kind != K8S_SERVICE_KIND || targetRef.Name != serviceName {
+				continue
+			}
+			if targetRef.SectionName == nil {
+				if policy == nil {
+					policy = candidate
+				}
+				continue
+			}
+			if *targetRef.SectionName == portName {
+				return candidate
+			}
+		}
+	}
+	return policy
+}
+
+// getPolicyAncestors returns the gateways the listeners belong to, they are the ancestors of policies applied to route backends.
+func getPolicyAncestors(listeners []store.Listener) []store.ParentRef {
+	ancestors := []store.ParentRef{}
+	seen := map[string]struct{}{}
+	for _, listener := range listeners {
+		key := listener.GwNamespace + "/" + listener.GwName
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		namespace := listener.GwNamespace
+		ancestors = append(ancestors, store.ParentRef{
+			Group:     K8S_GATEWAY_GROUP,
+			Kind:      K8S_GATEWAY_KIND,
+			Namespace: &namespace,
+			Name:      listener.GwName,
+		})
+	}
+	return ancestors
+}
+
+// getBackendTLSServerParams translates the backendtlspolicy into the TLS parameters of the backend servers.
+// Servers connect with TLS, verify the certificate against the policy CA and send the policy hostname as SNI.
+func (gm GatewayManagerImpl) getBackendTLSServerParams(policy store.BackendTLSPolicy) (models.ServerParams, error) {
+	caFile, err := gm.getBackendTLSPolicyCAFile(policy)
+	if err != nil {
+		return models.ServerParams{}, err
+	}
+	return models.ServerParams{
+		Ssl:       "enabled",
+		Verify:    "required",
+		SslCafile: caFile,
+		Sni:       fmt.Sprintf("str(%s)", policy.Hostname),
+		CheckSni:  policy.Hostname,
+	}, nil
+}
+
+// getBackendTLSPolicyCAFile returns the path of the CA file to use to verify backend certificates.
+// CA certificates referenced by the policy are gathered in a single file written by the certificates manager.
+func (gm GatewayManagerImpl) getBackendTLSPolicyCAFile(policy store.BackendTLSPolicy) (string, error) {
+	if policy.WellKnownCACertificates != nil {
+		if *policy.WellKnownCACertificates != WELL_KNOWN_CA_SYSTEM {
+			return "", fmt.Errorf("wellKnownCACertificates '%s' not supported", *policy.WellKnownCACertificates)
+		}
+		return HAPROXY_SYSTEM_CA_FILE, nil
+	}
+	if len(policy.CACertificateRefs) == 0 {
+		return "", errors.New("no CA certificate provided")
+	}
+	ns, found := gm.k8sStore.Namespaces[policy.Namespace]
+	if !found {
+		return "", fmt.Errorf("namespace '%s' not found", policy.Namespace)
+	}
+	status := policy.Status
+	var bundle []byte
+	for _, caRef := range policy.CACertificateRefs {
+		if caRef.Group != K8S_CORE_GROUP {
+			return "", fmt.Errorf("CA certificate reference group '%s' not supported", caRef.Group)
+		}
+		var content []byte
+		switch caRef.Kind {
+		case K8S_CONFIGMAP_KIND:
+			configmap, ok := ns.CABundles[caRef.Name]
+			if !ok {
+				return "", fmt.Errorf("configmap '%s/%s' with a '%s' entry not found", policy.Namespace, caRef.Name, store.CABundleKey)
+			}
+			content = []byte(configmap.Annotations[store.CABundleKey])
+			if configmap.Status != store.EMPTY {
+				status = store.MODIFIED
+			}
+		case K8S_SECRET_KIND:
+			secret, err := gm.k8sStore.GetSecret(policy.Namespace, caRef.Name)
+			if err != nil {
+				return "", err
+			}
+			var ok bool
+			if content, ok = secret.Data[store.CABundleKey]; !ok {
+				if content, ok = secret.Data["tls.crt"]; !ok {
+					return "", fmt.Errorf("secret '%s/%s' has no '%s' nor 'tls.crt' entry", policy.Namespace, caRef.Name, store.CABundleKey)
+				}
+			}
+			if secret.Status != store.EMPTY {
+				status = store.MODIFIED
+			}
+		default:
+			return "", fmt.Errorf("CA certificate reference kind '%s' not supported", caRef.Kind)
+		}
+		bundle = append(bundle, content...)
+		if len(bundle) > 0 && bundle[len(bundle)-1] != '\n' {
+			bundle = append(bundle, '\n')
+		}
+	}
+	if status != store.EMPTY {
+		status = store.MODIFIED
+	}
+	return gm.certificates.AddSecret(&store.Secret{
+		Namespace: policy.Namespace,
+		Name:      fmt.Sprintf(BACKENDTLSPOLICY_CA_NAME, policy.Name),
+		Data:      map[string][]byte{"tls.crt": bundle},
+		Status:    status,
+	}, certs.CA_CERT)
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBackendTLSPolicy(t *testing.T) {
+	port := "https"
+	otherPort := "admin"
+	ns := &store.Namespace{BackendTLSPolicies: map[string]*store.BackendTLSPolicy{
+		"b-service": {Name: "b-service", TargetRefs: []store.PolicyTargetRef{{Kind: K8S_SERVICE_KIND, Name: "echo"}}},
+		"a-service": {Name: "a-service", TargetRefs: []store.PolicyTargetRef{{Kind: K8S_SERVICE_KIND, Name: "echo"}}},
+		"z-port":    {Name: "z-port", TargetRefs: []store.PolicyTargetRef{{Kind: K8S_SERVICE_KIND, Name: "echo", SectionName: &port}}},
+		"other-port": {Name: "other-port", TargetRefs: []store.PolicyTargetRef{
+			{Kind: K8S_SERVICE_KIND, Name: "echo", SectionName: &otherPort},
+			{Kind: K8S_SERVICE_KIND, Name: "other"},
+		}},
+		"gateway": {Name: "gateway", TargetRefs: []store.PolicyTargetRef{{Group: K8S_GATEWAY_GROUP, Kind: K8S_GATEWAY_KIND, Name: "echo"}}},
+	}}
+
+	tests := []struct {
+		service  string
+		port     string
+		expected string
+	}{
+		{service: "echo", port: "https", expected: "z-port"},
+		{service: "echo", port: "http", expected: "a-service"},
+		{service: "echo", port: "admin", expected: "other-port"},
+		{service: "other", port: "http", expected: "other-port"},
+		{service: "unknown", port: "http"},
+	}
+	for _, test := range tests {
+		t.Run(test.service+"/"+test.port, func(t *testing.T) {
+			policy := getBackendTLSPolicy(ns, test.service, test.port)
+			if test.expected == "" {
+				assert.Nil(t, policy)
+				return
+			}
+			require.NotNil(t, policy)
+			assert.Equal(t, test.expected, policy.Name)
+		})
+	}
+}
+
+func TestGetPolicyAncestors(t *testing.T) {
+	ancestors := getPolicyAncestors([]store.Listener{
+		{Name: "http", GwNamespace: "default", GwName: "gateway1"},
+		{Name: "https", GwNamespace: "default", GwName: "gateway1"},
+		{Name: "http", GwNamespace: "infra", GwName: "gateway2"},
+	})
+	require.Len(t, ancestors, 2)
+	assert.Equal(t, "gateway1", ancestors[0].Name)
+	assert.Equal(t, "default", *ancestors[0].Namespace)
+	assert.Equal(t, "gateway2", ancestors[1].Name)
+	assert.Equal(t, K8S_GATEWAY_KIND, ancestors[1].Kind)
+}
+
+// fakeCertificates keeps the secrets and the listener crt-lists instead of writing them on disk
+// and updating them through the runtime API.
+type fakeCertificates struct {
+	certs.Certificates
+	secrets  map[string]*store.Secret
+	crtLists map[string][]string
+}
+
+func newFakeCertificates() *fakeCertificates {
+	return &fakeCertificates{secrets: map[string]*store.Secret{}, crtLists: map[string][]string{}}
+}
+
+func (c *fakeCertificates) AddSecret(secret *store.Secret, secretType certs.SecretType) (string, error) {
+	var dir string
+	switch secretType {
+	case certs.CA_CERT:
+		dir = "/etc/haproxy/certs/ca"
+	case certs.GW_CERT:
+		if _, ok := secret.Data["tls.key"]; !ok {
+			return "", errors.New("private key missing")
+		}
+		dir = "/etc/haproxy/certs/gateway"
+	default:
+		return "", errors.New("unexpected secret type")
+	}
+	certPath := path.Join(dir, secret.Namespace+"_"+secret.Name+".pem")
+	c.secrets[certPath] = secret
+	return certPath, nil
+}
+
+func (c *fakeCertificates) AddReference(secretType certs.SecretType, namespace, name, reference string) {
+}
+
+func (c *fakeCertificates) AddListenerEntry(listener, certPath string, policy certs.TLSPolicy) {
+	entry := certPath
+	if policy != (certs.TLSPolicy{}) {
+		entry += fmt.Sprintf(" %+v", policy)
+	}
+	c.crtLists[listener] = append(c.crtLists[listener], entry)
+}
+
+func (c *fakeCertificates) ListenerCrtList(listener string) string {
+	if len(c.crtLists[listener]) == 0 {
+		return ""
+	}
+	return path.Join("/etc/haproxy/certs/gateway/crt-lists", listener+".crt-list")
+}
+
+func newPolicyTestManager() (GatewayManagerImpl, *fakeCertificates) {
+	certificates := newFakeCertificates()
+	k8sStore := store.NewK8sStore(utils.OSArgs{})
+	ns := k8sStore.GetNamespace("default")
+	ns.CABundles["backend-ca"] = &store.ConfigMap{Namespace: "default", Name: "backend-ca", Annotations: map[string]string{store.CABundleKey: "configmap-ca"}}
+	ns.Secret["secret-ca"] = &store.Secret{Namespace: "default", Name: "secret-ca", Data: map[string][]byte{"tls.crt": []byte("secret-ca\n")}}
+	return GatewayManagerImpl{k8sStore: k8sStore, certificates: certificates}, certificates
+}
+
+func TestGetBackendTLSPolicyCAFile(t *testing.T) {
+	gm, certificates := newPolicyTestManager()
+	system := WELL_KNOWN_CA_SYSTEM
+	other := "Other"
+
+	caFile, err := gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "system", WellKnownCACertificates: &system})
+	require.NoError(t, err)
+	assert.Equal(t, HAPROXY_SYSTEM_CA_FILE, caFile)
+
+	_, err = gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "other", WellKnownCACertificates: &other})
+	require.Error(t, err)
+
+	_, err = gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "empty"})
+	require.Error(t, err)
+
+	// CA certificates of configmaps and secrets are gathered in a single file.
+	caFile, err = gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "bundle", CACertificateRefs: []store.LocalObjectRef{
+		{Kind: K8S_CONFIGMAP_KIND, Name: "backend-ca"},
+		{Kind: K8S_SECRET_KIND, Name: "secret-ca"},
+	}})
+	require.NoError(t, err)
+	require.Contains(t, certificates.secrets, caFile)
+	assert.Equal(t, "configmap-ca\nsecret-ca\n", string(certificates.secrets[caFile].Data["tls.crt"]))
+
+	// configmaps not kept by the store, such as unreferenced ones, can't be used.
+	_, err = gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "missing", CACertificateRefs: []store.LocalObjectRef{
+		{Kind: K8S_CONFIGMAP_KIND, Name: "kube-root-ca.crt"},
+	}})
+	require.Error(t, err)
+
+	_, err = gm.getBackendTLSPolicyCAFile(store.BackendTLSPolicy{Namespace: "default", Name: "group", CACertificateRefs: []store.LocalObjectRef{
+		{Group: "example.com", Kind: K8S_CONFIGMAP_KIND, Name: "backend-ca"},
+	}})
+	require.Error(t, err)
+}
+
+func TestGetBackendTLSServerParams(t *testing.T) {
+	gm, _ := newPolicyTestManager()
+	system := WELL_KNOWN_CA_SYSTEM
+	params, err := gm.getBackendTLSServerParams(store.BackendTLSPolicy{Namespace: "default", Name: "system", Hostname: "echo.example.com", WellKnownCACertificates: &system})
+	require.NoError(t, err)
+	assert.Equal(t, "enabled", params.Ssl)
+	assert.Equal(t, "required", params.Verify)
+	assert.Equal(t, HAPROXY_SYSTEM_CA_FILE, params.SslCafile)
+	assert.Equal(t, "str(echo.example.com)", params.Sni)
+	assert.Equal(t, "echo.example.com", params.CheckSni)
+}
@@ -15,6 +15,8 @@
 package gateway
 
 import (
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -65,15 +67,24 @@ const (
 	RouteReasonRefNotPermitted = "RefNotPermitted"
 	RouteReasonInvalidKind     = "InvalidKind"
 	RouteReasonBackendNotFound = "BackendNotFound"
+
+	PolicyConditionAccepted = "Accepted"
+	PolicyReasonAccepted    = "Accepted"
+	PolicyReasonInvalid     = "Invalid"
 )
 
 // NewStatusManager creates the default implementation for status management with gateway controller.
-func NewStatusManager(k8sRestClient client.Client, gatewayControllerName string) StatusManager {
+// Statuses are only written when leaderElector reports the replica as leader.
+func NewStatusManager(k8sRestClient client.Client, gatewayControllerName string, leaderElector leader.Elector) StatusManager {
 	return &StatusManagerImpl{
 		k8sRestClient:                        k8sRestClient,
+		leaderElector:                        leaderElector,
+		wasLeader:                            leaderElector.IsLeader(),
 		gatewayControllerName:                gatewayControllerName,
 		numRoutesByListenerByGateway:         map[string]map[string]int32{},
 		previousNumRoutesByListenerByGateway: map[string]map[string]int32{},
+		backendTLSPolicies:                   map[string]*policyStatusRecord{},
+		previousBackendTLSPolicies:           map[string]policyStatusRecord{},
 	}
 }
 
@@ -82,32 +93,47 @@ type RouteStatusManager interface {
 	SetRouteReasonBackendNotFound(string)
 	SetRouteReasonRefNotPermitted(string)
 	SetRouteReasonNotAllowedByListeners(string, store.ParentRef)
+	SetRouteReasonUnsupportedValue(string)
 }
 
 type StatusManager interface {
 	ProcessStatuses()
 	PrepareGatewayStatus(store.Gateway)
 	PrepareTCPRouteStatusRecord(store.TCPRoute)
+	PrepareHTTPRouteStatusRecord(store.HTTPRoute)
 	PrepareListenerStatus(store.Listener)
 	SetListenerReasonUnsupportedProtocol(string)
 	SetListenerReasonInvalidRouteKinds(string, []store.RouteGroupKind)
+	SetListenerReasonInvalidCertificateRef(string)
+	SetListenerReasonRefNotPermitted(string)
 	RouteStatusManager
 	SetGatewayClassConditionStatusAccepted(store.GatewayClass)
 	AddManagedParentRef(parentRef store.ParentRef)
 	IncrementRouteForListener(store.Listener)
+	SetGatewayAPIVersions(k8s.GatewayAPIVersions)
+	SetAddresses([]string)
+	SetBackendTLSPolicyStatus(policy store.BackendTLSPolicy, ancestors []store.ParentRef, reason, msg string)
 }
 
 type StatusManagerImpl struct {
 	k8sRestClient                        client.Client
 	gateway                              *gatewayStatusRecord
 	listener                             *listenerStatusRecord
-	tcproute                             *routeStatusRecord
+	route                                *routeStatusRecord
 	numRoutesByListenerByGateway         map[string]map[string]int32
 	previousNumRoutesByListenerByGateway map[string]map[string]int32
 	gatewayControllerName                string
 	gatewayclasses                       []store.GatewayClass
 	gateways                             []gatewayStatusRecord
 	tcproutes                            []routeStatusRecord
+	httproutes                           []routeStatusRecord
+	gatewayAPIVersions                   k8s.GatewayAPIVersions
+	leaderElector                        leader.Elector
+	wasLeader                            bool
+	addresses                            []string
+	previousAddresses                    []string
+	backendTLSPolicies                   map[string]*policyStatusRecord
+	previousBackendTLSPolicies           map[string]policyStatusRecord
 }
 
 // status records are created for two purposes:
@@ -116,6 +142,7 @@ type StatusManagerImpl struct {
 type routeStatusRecord struct {
 	parentsStatusesRecords map[string]parentrefStatusRecord
 	generalConditions      map[string]string
+	kind                   string
 	name                   string
 	namespace              string
 	status                 store.Status
@@ -136,6 +163,17 @@ type gatewayStatusRecord struct {
 	listenerWithError        bool
 }
 
+// policyStatusRecord records the status of a policy for every gateway (ancestor) it is applied to.
+type policyStatusRecord struct {
+	ancestors  map[string]store.ParentRef
+	reason     string
+	message    string
+	name       string
+	namespace  string
+	status     store.Status
+	generation int64
+}
+
 type listenerStatusRecord struct {
 	name     string
 	reasons  map[string]string
@@ -174,6 +212,7 @@ func (rteStatusRecord *routeStatusRecord) copy() routeStatusRecord {
 	}
 
 	return routeStatusRecord{
+		kind:                   rteStatusRecord.kind,
 		name:                   rteStatusRecord.name,
 		namespace:              rteStatusRecord.namespace,
 		generalConditions:      utils.CopyMap(rteStatusRecord.generalConditions),
@@ -183,12 +222,60 @@ func (rteStatusRecord *routeStatusRecord) copy() routeStatusRecord {
 	}
 }
 
-// pushTCPRoute pushes the current tcproute whose status is set by gatewaycontroller to the list of previous ones
-func (statusMgr *StatusManagerImpl) pushTCPRoute() {
-	if statusMgr.tcproute != nil {
-		statusMgr.tcproutes = append(statusMgr.tcproutes, *statusMgr.tcproute)
-		statusMgr.tcproute = nil
+// copy returns a copy of the policy status record.
+func (policyRecord *policyStatusRecord) copy() policyStatusRecord {
+	ancestors := make(map[string]store.ParentRef, len(policyRecord.ancestors))
+	for key, value := range policyRecord.ancestors {
+		ancestors[key] = value
+	}
+	return policyStatusRecord{
+		ancestors:  ancestors,
+		reason:     policyRecord.reason,
+		message:    policyRecord.message,
+		name:       policyRecord.name,
+		namespace:  policyRecord.namespace,
+		status:     policyRecord.status,
+		generation: policyRecord.generation,
+	}
+}
+
+// equal tells if two policy status records lead to the same status in k8s.
+func (policyRecord policyStatusRecord) equal(other policyStatusRecord) bool {
+	if policyRecord.reason != other.reason || policyRecord.message != other.message ||
+		policyRecord.generation != other.generation || len(policyRecord.ancestors) != len(other.ancestors) {
+		return false
+	}
+	for key := range policyRecord.ancestors {
+		if _, ok := other.ancestors[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// copyBackendTLSPoliciesStatusRecords returns the backendtlspolicies status records which lead to a status change.
+func (statusMgr *StatusManagerImpl) copyBackendTLSPoliciesStatusRecords(force bool) []policyStatusRecord {
+	copies := []policyStatusRecord{}
+	for key, record := range statusMgr.backendTLSPolicies {
+		previous, found := statusMgr.previousBackendTLSPolicies[key]
+		if force || record.status != store.EMPTY || !found || !previous.equal(*record) {
+			copies = append(copies, record.copy())
+		}
+	}
+	return copies
+}
+
+// pushRoute pushes the current route whose status is set by gatewaycontroller to the list of previous ones of its kind
+func (statusMgr *StatusManagerImpl) pushRoute() {
+	if statusMgr.route == nil {
+		return
 	}
+	if statusMgr.route.kind == K8S_HTTPROUTE_KIND {
+		statusMgr.httproutes = append(statusMgr.httproutes, *statusMgr.route)
+	} else {
+		statusMgr.tcproutes = append(statusMgr.tcproutes, *statusMgr.route)
+	}
+	statusMgr.route = nil
 }
 
 // pushGateway pushes the current gateway whose status is set by gatewaycontroller to the list of previous ones
@@ -207,10 +294,10 @@ func (statusMgr *StatusManagerImpl) pushListener() {
 	}
 }
 
-// copyTCPRoutesStatusRecords returns a copy of all the routes statuses.
-func (statusMgr *StatusManagerImpl) copyTCPRoutesStatusRecords() []routeStatusRecord {
-	copies := make([]routeStatusRecord, len(statusMgr.tcproutes))
-	for i, data := range statusMgr.tcproutes {
+// copyRoutesStatusRecords returns a copy of all the routes statuses.
+func copyRoutesStatusRecords(routes []routeStatusRecord) []routeStatusRecord {
+	copies := make([]routeStatusRecord, len(routes))
+	for i, data := range routes {
 		copies[i] = data.copy()
 	}
 	return copies
@@ -265,9 +352,10 @@ func (statusMgr *StatusManagerImpl) PrepareListenerStatus(listener store.Listene
 // PrepareTCPRouteStatusRecord sets the tcproute status record for a tcproute.
 // Every upcoming status information about a tcproute provided by the gateway controller will be set into this record.
 func (statusMgr *StatusManagerImpl) PrepareTCPRouteStatusRecord(tcproute store.TCPRoute) {
-	statusMgr.pushTCPRoute()
+	statusMgr.pushRoute()
 
-	statusMgr.tcproute = &routeStatusRecord{
+	statusMgr.route = &routeStatusRecord{
+		kind:                   K8S_TCPROUTE_KIND,
 		name:                   tcproute.Name,
 		namespace:              tcproute.Namespace,
 		generation:             tcproute.Generation,
@@ -277,21 +365,56 @@ func (statusMgr *StatusManagerImpl) PrepareTCPRouteStatusRecord(tcproute store.T
 	}
 }
 
+// PrepareHTTPRouteStatusRecord sets the httproute status record for a httproute.
+// Every upcoming status information about a httproute provided by the gateway controller will be set into this record.
+func (statusMgr *StatusManagerImpl) PrepareHTTPRouteStatusRecord(httproute store.HTTPRoute) {
+	statusMgr.pushRoute()
+
+	statusMgr.route = &routeStatusRecord{
+		kind:                   K8S_HTTPROUTE_KIND,
+		name:                   httproute.Name,
+		namespace:              httproute.Namespace,
+		generation:             httproute.Generation,
+		parentsStatusesRecords: map[string]parentrefStatusRecord{},
+		generalConditions:      map[string]string{},
+		status:                 httproute.Status,
+	}
+}
+
 // ProcessStatuses goes over all status records to update their counterparts in k8s with the corresponding resource.
 func (statusMgr *StatusManagerImpl) ProcessStatuses() {
 	statusMgr.pushListener()
 	statusMgr.pushGateway()
-	statusMgr.pushTCPRoute()
+	statusMgr.pushRoute()
 	copyGatewaysStatusRecords := statusMgr.copyGatewaysStatusRecords()
-	copyTCPRouteStatusRecords := statusMgr.copyTCPRoutesStatusRecords()
+	copyTCPRouteStatusRecords := copyRoutesStatusRecords(statusMgr.tcproutes)
+	copyHTTPRouteStatusRecords := copyRoutesStatusRecords(statusMgr.httproutes)
 	copyGatewayclasses := statusMgr.copyGatewayclasses()
 	statusMgr.gatewayclasses = nil
 	statusMgr.gateways = nil
 	statusMgr.tcproutes = nil
-	// we update asynchonously all statuses.
-	go statusMgr.UpdateStatusGatewayclasses(copyGatewayclasses)
-	go statusMgr.UpdateStatusGateways(copyGatewaysStatusRecords, utils.CopyMapOfMap(statusMgr.numRoutesByListenerByGateway), utils.CopyMapOfMap(statusMgr.previousNumRoutesByListenerByGateway))
-	go statusMgr.UpdateStatusTCPRoutes(copyTCPRouteStatusRecords)
+	statusMgr.httproutes = nil
+	// only the leader writes statuses, a newly elected leader rewrites all of them.
+	isLeader := statusMgr.leaderElector.IsLeader()
+	force := isLeader && !statusMgr.wasLeader
+	statusMgr.wasLeader = isLeader
+	copyBackendTLSPoliciesStatusRecords := statusMgr.copyBackendTLSPoliciesStatusRecords(force)
+	if isLeader {
+		statusMgr.previousBackendTLSPolicies = make(map[string]policyStatusRecord, len(statusMgr.backendTLSPolicies))
+		for key, record := range statusMgr.backendTLSPolicies {
+			statusMgr.previousBackendTLSPolicies[key] = record.copy()
+		}
+	}
+	statusMgr.backendTLSPolicies = map[string]*policyStatusRecord{}
+	if isLeader {
+		addressesChanged := statusMgr.addressesChanged()
+		// we update asynchonously all statuses.
+		go statusMgr.UpdateStatusGatewayclasses(copyGatewayclasses, force)
+		go statusMgr.UpdateStatusGateways(copyGatewaysStatusRecords, utils.CopyMapOfMap(statusMgr.numRoutesByListenerByGateway), utils.CopyMapOfMap(statusMgr.previousNumRoutesByListenerByGateway), statusMgr.addresses, force || addressesChanged)
+		go statusMgr.UpdateStatusTCPRoutes(copyTCPRouteStatusRecords, force)
+		go statusMgr.UpdateStatusHTTPRoutes(copyHTTPRouteStatusRecords, force)
+		go statusMgr.UpdateStatusBackendTLSPolicies(copyBackendTLSPoliciesStatusRecords)
+	}
 
 	statusMgr.previousNumRoutesByListenerByGateway = statusMgr.numRoutesByListenerByGateway
 	statusMgr.numRoutesByListenerByGateway = map[string]map[string]int32{}
@@ -310,29 +433,46 @@ func (statusMgr *StatusManagerImpl) SetListenerReasonInvalidRouteKinds(msg strin
 	statusMgr.gateway.listenerWithError = true
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonBackendNotFound for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetListenerReasonInvalidCertificateRef sets the msg and the reason ListenerReasonInvalidCertificateRef for the current listener pushed by PrepareListenerStatus.
+func (statusMgr *StatusManagerImpl) SetListenerReasonInvalidCertificateRef(msg string) {
+	statusMgr.listener.reasons[ListenerReasonInvalidCertificateRef] += msg + "\n"
+	statusMgr.gateway.listenerWithError = true
+}
+
+// SetListenerReasonRefNotPermitted sets the msg and the reason ListenerReasonRefNotPermitted for the current listener pushed by PrepareListenerStatus.
+func (statusMgr *StatusManagerImpl) SetListenerReasonRefNotPermitted(msg string) {
+	statusMgr.listener.reasons[ListenerReasonRefNotPermitted] += msg + "\n"
+	statusMgr.gateway.listenerWithError = true
+}
+
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonBackendNotFound for the current route pushed by PrepareTCPRouteStatusRecord or PrepareHTTPRouteStatusRecord.
 func (statusMgr *StatusManagerImpl) SetRouteReasonBackendNotFound(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonBackendNotFound] = msg
+	statusMgr.route.generalConditions[RouteReasonBackendNotFound] = msg
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonRefNotPermitted for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonRefNotPermitted for the current route pushed by PrepareTCPRouteStatusRecord or PrepareHTTPRouteStatusRecord.
 func (statusMgr *StatusManagerImpl) SetRouteReasonRefNotPermitted(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonRefNotPermitted] = msg
+	statusMgr.route.generalConditions[RouteReasonRefNotPermitted] = msg
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonNotAllowedByListeners for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonNotAllowedByListeners for the current route pushed by PrepareTCPRouteStatusRecord or PrepareHTTPRouteStatusRecord.
 func (statusMgr *StatusManagerImpl) SetRouteReasonNotAllowedByListeners(msg string, parentRef store.ParentRef) {
-	parentStatusRecord := statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name]
+	parentStatusRecord := statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name]
 	if parentStatusRecord.reasons == nil {
 		parentStatusRecord.reasons = map[string]string{}
 	}
 	parentStatusRecord.reasons[RouteReasonNotAllowedByListeners] += msg + "\n"
-	statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentStatusRecord
+	statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentStatusRecord
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonInvalidKind for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonInvalidKind for the current route pushed by PrepareTCPRouteStatusRecord or PrepareHTTPRouteStatusRecord.
 func (statusMgr *StatusManagerImpl) SetRouteReasonInvalidKind(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonInvalidKind] = msg
+	statusMgr.route.generalConditions[RouteReasonInvalidKind] = msg
+}
+
+// SetRouteReasonUnsupportedValue sets the msg and the reason RouteReasonUnsupportedValue for the current route pushed by PrepareTCPRouteStatusRecord or PrepareHTTPRouteStatusRecord.
+func (statusMgr *StatusManagerImpl) SetRouteReasonUnsupportedValue(msg string) {
+	statusMgr.route.generalConditions[RouteReasonUnsupportedValue] += msg + "\n"
 }
 
 // SetGatewayClassConditionStatusAccepted adds the provided gatewayclass to the list of accepted gatewayclasses.
@@ -340,9 +480,9 @@ func (statusMgr *StatusManagerImpl) SetGatewayClassConditionStatusAccepted(gwCla
 	statusMgr.gatewayclasses = append(statusMgr.gatewayclasses, gwClass)
 }
 
-// AddManagedParentRef adds the parentref inside a new parentrefStatusRecord for the current tcp route.
+// AddManagedParentRef adds the parentref inside a new parentrefStatusRecord for the current route.
 func (statusMgr *StatusManagerImpl) AddManagedParentRef(parentRef store.ParentRef) {
-	statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentrefStatusRecord{
+	statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentrefStatusRecord{
 		parentRef: parentRef,
 	}
 }
@@ -356,3 +496,47 @@ func (statusMgr *StatusManagerImpl) IncrementRouteForListener(listener store.Lis
 	}
 	numRoutesByListenerByGateway[listener.Name]++
 }
+
+// SetGatewayAPIVersions sets the Gateway API versions used to update the statuses.
+func (statusMgr *StatusManagerImpl) SetGatewayAPIVersions(gatewayAPIVersions k8s.GatewayAPIVersions) {
+	statusMgr.gatewayAPIVersions = gatewayAPIVersions
+}
+
+// SetAddresses sets the addresses reported in gateways statuses, these are the publish service addresses.
+func (statusMgr *StatusManagerImpl) SetAddresses(addresses []string) {
+	statusMgr.addresses = addresses
+}
+
+// addressesChanged reports if the addresses have changed since the previous call.
+// Gateways addresses mirror the publish service, every gateway is updated when they change.
+func (statusMgr *StatusManagerImpl) addressesChanged() bool {
+	changed := !utils.EqualSliceStringsWithoutOrder(statusMgr.addresses, statusMgr.previousAddresses)
+	statusMgr.previousAddresses = statusMgr.addresses
+	return changed
+}
+
+// SetBackendTLSPolicyStatus records the reason and message of the backendtlspolicy applied to backends of routes attached to ancestors.
+// An invalid reason is kept over an accepted one for the same policy.
+func (statusMgr *StatusManagerImpl) SetBackendTLSPolicyStatus(policy store.BackendTLSPolicy, ancestors []store.ParentRef, reason, msg string) {
+	key := policy.Namespace + "/" + policy.Name
+	record, found := statusMgr.backendTLSPolicies[key]
+	if !found {
+		record = &policyStatusRecord{
+			ancestors:  map[string]store.ParentRef{},
+			name:       policy.Name,
+			namespace:  policy.Namespace,
+			generation: policy.Generation,
+			status:     policy.Status,
+			reason:     reason,
+			message:    msg,
+		}
+		statusMgr.backendTLSPolicies[key] = record
+	}
+	if reason != PolicyReasonAccepted {
+		record.reason = reason
+		record.message = msg
+	}
+	for _, ancestor := range ancestors {
+		record.ancestors[*ancestor.Namespace+"/"+ancestor.Name] = ancestor
+	}
+}
@@ -16,32 +16,37 @@ package gateway
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
-	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // UpdateStatusGatewayclasses is responsible of updating the statuses of the accepted gateway classes.
-func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []store.GatewayClass) {
+// If force is set, unchanged gateway classes are also updated.
+func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []store.GatewayClass, force bool) {
 	transitionTime := metav1.NewTime(time.Now())
 	for _, gwClass := range gatewayclasses {
-		if gwClass.Status == store.EMPTY || gwClass.Status == store.DELETED {
+		if gwClass.Status == store.DELETED || (!force && gwClass.Status == store.EMPTY) {
 			continue
 		}
-		gwc := &v1beta1.GatewayClass{}
+		obj, gwc := statusMgr.newGatewayClass()
 		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
 			Name: gwClass.Name,
-		}, gwc)
+		}, obj)
 		if err != nil {
 			logger.Error(err)
 			continue
 		}
 
-		gwc.Status = v1beta1.GatewayClassStatus{
+		gwc.Status = gatewayv1.GatewayClassStatus{
 			Conditions: []metav1.Condition{{
 				Type:               GatewayClassConditionStatusAccepted,
 				ObservedGeneration: gwc.Generation,
@@ -51,23 +56,26 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []
 			}},
 		}
 
-		logger.Error(statusMgr.k8sRestClient.Status().Update(context.TODO(), gwc))
+		logger.Error(statusMgr.k8sRestClient.Status().Update(context.TODO(), obj))
 	}
 }
 
 // UpdateStatusGateways is responsible of updating the statuses of the  gateways.
 // To be able to determine if a status update is necessary because of the number of route attached has changed by attachment or detachment.
 // This is mandatory because an unmodified gateway can have its status to be updated because of changes from tcp routes in term of attachment.
-func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []gatewayStatusRecord, numRoutesByListenerByGateway, previousNumRoutesByListenerByGateway map[string]map[string]int32) {
+// Gateways addresses are set from the provided addresses. If force is set, unchanged gateways are also updated.
+func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []gatewayStatusRecord, numRoutesByListenerByGateway, previousNumRoutesByListenerByGateway map[string]map[string]int32, addresses []string, force bool) {
+	gwAddresses := gatewayStatusAddresses(addresses)
 	transitionTime := metav1.NewTime(time.Now())
 	for _, gatewayStatusRecord := range gatewayStatusRecords {
-		numRoutesHasChanged := hasNumberOfRoutesForAnyListenerChanged(gatewayStatusRecord, numRoutesByListenerByGateway, previousNumRoutesByListenerByGateway)
+		numRoutesHasChanged := force || hasNumberOfRoutesForAnyListenerChanged(gatewayStatusRecord, numRoutesByListenerByGateway, previousNumRoutesByListenerByGateway)
 		if !numRoutesHasChanged && (gatewayStatusRecord.status == store.EMPTY || gatewayStatusRecord.status == store.DELETED) {
 			continue
 		}
 
-		gwStatus := v1beta1.GatewayStatus{
-			Listeners: make([]v1beta1.ListenerStatus, len(gatewayStatusRecord.listenersStatusesRecords)),
+		gwStatus := gatewayv1.GatewayStatus{
+			Addresses: gwAddresses,
+			Listeners: make([]gatewayv1.ListenerStatus, len(gatewayStatusRecord.listenersStatusesRecords)),
 			Conditions: []metav1.Condition{{
 				Type:               GatewayConditionReady,
 				ObservedGeneration: gatewayStatusRecord.generation,
@@ -82,8 +90,8 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 			if numRoutesByListener := numRoutesByListenerByGateway[gatewayStatusRecord.namespace+"/"+gatewayStatusRecord.name]; numRoutesByListener != nil {
 				numRoutes = numRoutesByListener[listenerStatusRecord.name]
 			}
-			gwStatus.Listeners[i] = v1beta1.ListenerStatus{
-				Name:           v1beta1.SectionName(listenerStatusRecord.name),
+			gwStatus.Listeners[i] = gatewayv1.ListenerStatus{
+				Name:           gatewayv1.SectionName(listenerStatusRecord.name),
 				SupportedKinds: RouteGroupKinds(listenerStatusRecord.validRGK).asK8SGroupKind(),
 				AttachedRoutes: numRoutes,
 			}
@@ -108,6 +116,16 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 				condition.Reason = ListenerReasonInvalidRouteKinds
 				condition.Status = metav1.ConditionFalse
 				conditionReady.Status = metav1.ConditionFalse
+			} else if msg, ok := listenerStatusRecord.reasons[ListenerReasonRefNotPermitted]; ok {
+				condition.Message = msg
+				condition.Reason = ListenerReasonRefNotPermitted
+				condition.Status = metav1.ConditionFalse
+				conditionReady.Status = metav1.ConditionFalse
+			} else if msg, ok := listenerStatusRecord.reasons[ListenerReasonInvalidCertificateRef]; ok {
+				condition.Message = msg
+				condition.Reason = ListenerReasonInvalidCertificateRef
+				condition.Status = metav1.ConditionFalse
+				conditionReady.Status = metav1.ConditionFalse
 			} else {
 				condition.Message = msg
 				condition.Reason = ListenerReasonResolvedRefs
@@ -134,11 +152,11 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 			gwStatus.Listeners[i].Conditions = listenerConditions
 		}
 
-		gw := &v1beta1.Gateway{}
+		obj, gw := statusMgr.newGateway()
 		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
 			Namespace: gatewayStatusRecord.namespace,
 			Name:      gatewayStatusRecord.name,
-		}, gw)
+		}, obj)
 		if err != nil {
 			logger.Error(err)
 			continue
@@ -146,24 +164,39 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 
 		gw.Status = gwStatus
 
-		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), gw)
+		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), obj)
 		logger.Error(err)
 	}
 }
 
+// gatewayStatusAddresses converts the publish service addresses into gateway status addresses.
+func gatewayStatusAddresses(addresses []string) []gatewayv1.GatewayStatusAddress {
+	var gwAddresses []gatewayv1.GatewayStatusAddress
+	for _, address := range addresses {
+		if address == "" {
+			continue
+		}
+		addressType := gatewayv1.HostnameAddressType
+		if net.ParseIP(address) != nil {
+			addressType = gatewayv1.IPAddressType
+		}
+		gwAddresses = append(gwAddresses, gatewayv1.GatewayStatusAddress{
+			Type:  &addressType,
+			Value: address,
+		})
+	}
+	return gwAddresses
+}
+
 // UpdateStatusTCPRoutes is responsible of updating the statuses of the  tcp routes.
-func (statusMgr *StatusManagerImpl) UpdateStatusTCPRoutes(routesStatusRecords []routeStatusRecord) {
+// If force is set, unchanged tcp routes are also updated.
+func (statusMgr *StatusManagerImpl) UpdateStatusTCPRoutes(routesStatusRecords []routeStatusRecord, force bool) {
 	transitionTime := metav1.NewTime(time.Now())
 	for _, tcprouteStatusRecord := range routesStatusRecords {
-		if tcprouteStatusRecord.status == store.EMPTY || tcprouteStatusRecord.status == store.DELETED {
+		if tcprouteStatusRecord.status == store.DELETED || (!force && tcprouteStatusRecord.status == store.EMPTY) {
 			continue
 		}
 
-		tcprouteStatus := v1alpha2.TCPRouteStatus{
-			RouteStatus: v1alpha2.RouteStatus{
-				Parents: []v1alpha2.RouteParentStatus{},
-			},
-		}
 		tcproute := &v1alpha2.TCPRoute{}
 		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
 			Namespace: tcprouteStatusRecord.namespace,
@@ -174,66 +207,162 @@ func (statusMgr *StatusManagerImpl) UpdateStatusTCPRoutes(routesStatusRecords []
 			continue
 		}
 
-		for _, parentStatusRecord := range tcprouteStatusRecord.parentsStatusesRecords {
-			conditions := []metav1.Condition{}
-			routeParentStatus := v1alpha2.RouteParentStatus{
-				ControllerName: v1alpha2.GatewayController(statusMgr.gatewayControllerName),
-				ParentRef: v1alpha2.ParentReference{
-					Group:       (*v1alpha2.Group)(&parentStatusRecord.parentRef.Group),
-					Kind:        (*v1alpha2.Kind)(&parentStatusRecord.parentRef.Kind),
-					Namespace:   (*v1alpha2.Namespace)(parentStatusRecord.parentRef.Namespace),
-					Name:        v1alpha2.ObjectName(parentStatusRecord.parentRef.Name),
-					SectionName: (*v1alpha2.SectionName)(parentStatusRecord.parentRef.SectionName),
-					Port:        (*v1alpha2.PortNumber)(parentStatusRecord.parentRef.Port),
-				},
-			}
+		tcproute.Status = v1alpha2.TCPRouteStatus{
+			RouteStatus: v1alpha2.RouteStatus{
+				Parents: statusMgr.routeParentStatuses(tcprouteStatusRecord, transitionTime),
+			},
+		}
+		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), tcproute)
+		logger.Error(err)
+	}
+}
 
-			// RouteConditionAccepted
-			condition := metav1.Condition{
-				Type:               RouteConditionAccepted,
-				ObservedGeneration: tcprouteStatusRecord.generation,
-				LastTransitionTime: transitionTime,
-			}
-			if msg, ok := parentStatusRecord.reasons[RouteReasonNotAllowedByListeners]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonNotAllowedByListeners
-			} else {
-				condition.Status = metav1.ConditionTrue
-				condition.Reason = RouteReasonAccepted
-			}
-			conditions = append(conditions, condition)
+// UpdateStatusHTTPRoutes is responsible of updating the statuses of the http routes.
+// If force is set, unchanged http routes are also updated.
+func (statusMgr *StatusManagerImpl) UpdateStatusHTTPRoutes(routesStatusRecords []routeStatusRecord, force bool) {
+	transitionTime := metav1.NewTime(time.Now())
+	for _, httprouteStatusRecord := range routesStatusRecords {
+		if httprouteStatusRecord.status == store.DELETED || (!force && httprouteStatusRecord.status == store.EMPTY) {
+			continue
+		}
 
-			// RouteConditionResolvedRefs
-			condition = metav1.Condition{
-				Type:               RouteConditionResolvedRefs,
-				ObservedGeneration: tcprouteStatusRecord.generation,
-				LastTransitionTime: transitionTime,
-				Status:             metav1.ConditionTrue,
-				Reason:             RouteReasonResolvedRefs,
-			}
+		obj, httproute := statusMgr.newHTTPRoute()
+		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: httprouteStatusRecord.namespace,
+			Name:      httprouteStatusRecord.name,
+		}, obj)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
 
-			if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonRefNotPermitted]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonRefNotPermitted
-			} else if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonInvalidKind]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonInvalidKind
-			} else if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonBackendNotFound]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonBackendNotFound
+		httproute.Status = gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: statusMgr.routeParentStatuses(httprouteStatusRecord, transitionTime),
+			},
+		}
+		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), obj)
+		logger.Error(err)
+	}
+}
+
+// routeParentStatuses returns the statuses of the route for each of its parents managed by the controller.
+func (statusMgr *StatusManagerImpl) routeParentStatuses(routeStatusRecord routeStatusRecord, transitionTime metav1.Time) []gatewayv1.RouteParentStatus {
+	parents := []gatewayv1.RouteParentStatus{}
+	for _, parentStatusRecord := range routeStatusRecord.parentsStatusesRecords {
+		conditions := []metav1.Condition{}
+		routeParentStatus := gatewayv1.RouteParentStatus{
+			ControllerName: gatewayv1.GatewayController(statusMgr.gatewayControllerName),
+			ParentRef: gatewayv1.ParentReference{
+				Group:       (*gatewayv1.Group)(&parentStatusRecord.parentRef.Group),
+				Kind:        (*gatewayv1.Kind)(&parentStatusRecord.parentRef.Kind),
+				Namespace:   (*gatewayv1.Namespace)(parentStatusRecord.parentRef.Namespace),
+				Name:        gatewayv1.ObjectName(parentStatusRecord.parentRef.Name),
+				SectionName: (*gatewayv1.SectionName)(parentStatusRecord.parentRef.SectionName),
+				Port:        (*gatewayv1.PortNumber)(parentStatusRecord.parentRef.Port),
+			},
+		}
+
+		// RouteConditionAccepted
+		condition := metav1.Condition{
+			Type:               RouteConditionAccepted,
+			ObservedGeneration: routeStatusRecord.generation,
+			LastTransitionTime: transitionTime,
+		}
+		if msg, ok := parentStatusRecord.reasons[RouteReasonNotAllowedByListeners]; ok {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = msg
+			condition.Reason = RouteReasonNotAllowedByListeners
+		} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonUnsupportedValue]; ok {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = msg
+			condition.Reason = RouteReasonUnsupportedValue
+		} else {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = RouteReasonAccepted
+		}
+		conditions = append(conditions, condition)
+
+		// RouteConditionResolvedRefs
+		condition = metav1.Condition{
+			Type:               RouteConditionResolvedRefs,
+			ObservedGeneration: routeStatusRecord.generation,
+			LastTransitionTime: transitionTime,
+			Status:             metav1.ConditionTrue,
+			Reason:             RouteReasonResolvedRefs,
+		}
+
+		if msg, ok := routeStatusRecord.generalConditions[RouteReasonRefNotPermitted]; ok {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = msg
+			condition.Reason = RouteReasonRefNotPermitted
+		} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonInvalidKind]; ok {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = msg
+			condition.Reason = RouteReasonInvalidKind
+		} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonBackendNotFound]; ok {
+			condition.Status = metav1.ConditionFalse
+			condition.Message = msg
+			condition.Reason = RouteReasonBackendNotFound
+		}
+		conditions = append(conditions, condition)
+
+		routeParentStatus.Conditions = conditions
+		parents = append(parents, routeParentStatus)
+	}
+	return parents
+}
+
+// UpdateStatusBackendTLSPolicies is responsible of updating the statuses of the backendtlspolicies.
+// Only the ancestors managed by the controller are updated, the ones from other controllers are kept.
+func (statusMgr *StatusManagerImpl) UpdateStatusBackendTLSPolicies(policiesStatusRecords []policyStatusRecord) {
+	transitionTime := metav1.NewTime(time.Now())
+	for _, policyStatusRecord := range policiesStatusRecords {
+		policy := &v1alpha3.BackendTLSPolicy{}
+		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: policyStatusRecord.namespace,
+			Name:      policyStatusRecord.name,
+		}, policy)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		ancestors := []v1alpha2.PolicyAncestorStatus{}
+		for _, ancestor := range policy.Status.Ancestors {
+			if string(ancestor.ControllerName) != statusMgr.gatewayControllerName {
+				ancestors = append(ancestors, ancestor)
 			}
-			conditions = append(conditions, condition)
+		}
 
-			routeParentStatus.Conditions = conditions
-			tcprouteStatus.Parents = append(tcprouteStatus.Parents, routeParentStatus)
+		condition := metav1.Condition{
+			Type:               PolicyConditionAccepted,
+			ObservedGeneration: policyStatusRecord.generation,
+			LastTransitionTime: transitionTime,
+			Status:             metav1.ConditionTrue,
+			Reason:             PolicyReasonAccepted,
+		}
+		if policyStatusRecord.reason != PolicyReasonAccepted {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = policyStatusRecord.reason
+			condition.Message = policyStatusRecord.message
 		}
 
-		tcproute.Status = tcprouteStatus
-		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), tcproute)
+		for _, ancestor := range policyStatusRecord.ancestors {
+			ancestors = append(ancestors, v1alpha2.PolicyAncestorStatus{
+				AncestorRef: v1alpha2.ParentReference{
+					Group:     (*v1alpha2.Group)(&ancestor.Group),
+					Kind:      (*v1alpha2.Kind)(&ancestor.Kind),
+					Namespace: (*v1alpha2.Namespace)(ancestor.Namespace),
+					Name:      v1alpha2.ObjectName(ancestor.Name),
+				},
+				ControllerName: v1alpha2.GatewayController(statusMgr.gatewayControllerName),
+				Conditions:     []metav1.Condition{condition},
+			})
+		}
+
+		policy.Status.Ancestors = ancestors
+		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), policy)
 		logger.Error(err)
 	}
 }
@@ -266,13 +395,43 @@ func hasNumberOfRoutesForAnyListenerChanged(gatewayStatusRecord gatewayStatusRec
 
 type RouteGroupKinds []store.RouteGroupKind
 
-func (rgk RouteGroupKinds) asK8SGroupKind() []v1beta1.RouteGroupKind {
-	routeGroupKind := make([]v1beta1.RouteGroupKind, len(rgk))
+func (rgk RouteGroupKinds) asK8SGroupKind() []gatewayv1.RouteGroupKind {
+	routeGroupKind := make([]gatewayv1.RouteGroupKind, len(rgk))
 	for i, rgk := range rgk {
-		routeGroupKind[i] = v1beta1.RouteGroupKind{
-			Group: (*v1beta1.Group)(rgk.Group),
-			Kind:  v1beta1.Kind(rgk.Kind),
+		routeGroupKind[i] = gatewayv1.RouteGroupKind{
+			Group: (*gatewayv1.Group)(rgk.Group),
+			Kind:  gatewayv1.Kind(rgk.Kind),
 		}
 	}
 	return routeGroupKind
 }
+
+// newGatewayClass returns an empty gatewayclass in the served API version along with its v1 view used to set the status.
+func (statusMgr *StatusManagerImpl) newGatewayClass() (client.Object, *gatewayv1.GatewayClass) {
+	if statusMgr.gatewayAPIVersions.Gateway == gatewayv1beta1.GroupVersion.Version {
+		gwc := &gatewayv1beta1.GatewayClass{}
+		return gwc, (*gatewayv1.GatewayClass)(gwc)
+	}
+	gwc := &gatewayv1.GatewayClass{}
+	return gwc, gwc
+}
+
+// newGateway returns an empty gateway in the served API version along with its v1 view used to set the status.
+func (statusMgr *StatusManagerImpl) newGateway() (client.Object, *gatewayv1.Gateway) {
+	if statusMgr.gatewayAPIVersions.Gateway == gatewayv1beta1.GroupVersion.Version {
+		gw := &gatewayv1beta1.Gateway{}
+		return gw, (*gatewayv1.Gateway)(gw)
+	}
+	gw := &gatewayv1.Gateway{}
+	return gw, gw
+}
+
+// newHTTPRoute returns an empty httproute in the served API version along with its v1 view used to set the status.
+func (statusMgr *StatusManagerImpl) newHTTPRoute() (client.Object, *gatewayv1.HTTPRoute) {
+	if statusMgr.gatewayAPIVersions.HTTPRoute == gatewayv1beta1.GroupVersion.Version {
+		httproute := &gatewayv1beta1.HTTPRoute{}
+		return httproute, (*gatewayv1.HTTPRoute)(httproute)
+	}
+	httproute := &gatewayv1.HTTPRoute{}
+	return httproute, httproute
+}
@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestGatewayStatusAddresses(t *testing.T) {
+	ipType := gatewayv1.IPAddressType
+	hostnameType := gatewayv1.HostnameAddressType
+	tests := []struct {
+		name      string
+		addresses []string
+		expected  []gatewayv1.GatewayStatusAddress
+	}{
+		{name: "nil"},
+		{name: "empty", addresses: []string{}},
+		{name: "empty address", addresses: []string{""}},
+		{name: "ipv4", addresses: []string{"10.0.0.1"}, expected: []gatewayv1.GatewayStatusAddress{{Type: &ipType, Value: "10.0.0.1"}}},
+		{name: "ipv6", addresses: []string{"2001:db8::1"}, expected: []gatewayv1.GatewayStatusAddress{{Type: &ipType, Value: "2001:db8::1"}}},
+		{name: "hostname", addresses: []string{"lb.example.com"}, expected: []gatewayv1.GatewayStatusAddress{{Type: &hostnameType, Value: "lb.example.com"}}},
+		{
+			name:      "mixed",
+			addresses: []string{"lb.example.com", "", "10.0.0.1"},
+			expected: []gatewayv1.GatewayStatusAddress{
+				{Type: &hostnameType, Value: "lb.example.com"},
+				{Type: &ipType, Value: "10.0.0.1"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, gatewayStatusAddresses(test.addresses))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+//nolint:golint, stylecheck
+const (
+	CERTIFICATES_URL_PATH = "/debug/certificates"
+	// warnings about a given expiring certificate are logged at most once in this interval
+	certificateWarningInterval = 24 * time.Hour
+	// certificates are checked for expiry at each update and at this interval in between
+	certificateCheckInterval = time.Hour
+)
+
+var (
+	certificateInventory = []certs.CertInfo{}
+	certificatesMu       sync.RWMutex
+)
+
+// Certificates keeps the inventory of certificates in use, exposes their expiry
+// as metrics and warns about certificates expiring soon, at each update and every
+// certificateCheckInterval in between.
+type Certificates struct {
+	warned  map[string]time.Time
+	window  time.Duration
+	mu      sync.Mutex
+	running bool
+}
+
+func (handler *Certificates) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
+	window := 30 * 24 * time.Hour
+	if value := common.GetValue("certificate-expiry-warning", k.ConfigMaps.Main.Annotations); value != "" {
+		if window, err = time.ParseDuration(value); err != nil || window < 0 {
+			window = 30 * 24 * time.Hour
+			logger.Errorf("annotation 'certificate-expiry-warning': invalid duration '%s'", value)
+		}
+	}
+
+	inventory := h.Inventory()
+	certificates := make([]metrics.Certificate, 0, len(inventory))
+	for _, crt := range inventory {
+		certificates = append(certificates, metrics.Certificate{Type: crt.Type, Secret: crt.Secret, Subject: crt.Subject, NotAfter: crt.NotAfter})
+	}
+	metrics.New().SetCertificates(certificates)
+	certificatesMu.Lock()
+	certificateInventory = inventory
+	certificatesMu.Unlock()
+
+	handler.mu.Lock()
+	handler.window = window
+	if !handler.running {
+		handler.running = true
+		go handler.checkExpiry()
+	}
+	handler.mu.Unlock()
+	handler.warn(time.Now())
+	return nil
+}
+
+// checkExpiry warns about expiring certificates between updates.
+func (handler *Certificates) checkExpiry() {
+	ticker := time.NewTicker(certificateCheckInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		handler.warn(now)
+	}
+}
+
+// warn logs a warning for the certificates in use expiring within the window,
+// at most once every certificateWarningInterval for a given certificate.
+func (handler *Certificates) warn(now time.Time) {
+	certificatesMu.RLock()
+	inventory := certificateInventory
+	certificatesMu.RUnlock()
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if handler.warned == nil {
+		handler.warned = make(map[string]time.Time)
+	}
+	for _, crt := range inventory {
+		expiry := crt.NotAfter.Sub(now)
+		if expiry > handler.window {
+			delete(handler.warned, crt.File)
+			continue
+		}
+		if last, ok := handler.warned[crt.File]; ok && now.Sub(last) < certificateWarningInterval {
+			continue
+		}
+		handler.warned[crt.File] = now
+		if expiry <= 0 {
+			logger.Warningf("certificate '%s' from secret '%s' expired on %s, used by %v", crt.Subject, crt.Secret, crt.NotAfter.Format(time.RFC3339), crt.References)
+		} else {
+			logger.Warningf("certificate '%s' from secret '%s' expires in %s on %s, used by %v", crt.Subject, crt.Secret, expiry.Round(time.Minute), crt.NotAfter.Format(time.RFC3339), crt.References)
+		}
+	}
+}
+
+// CertificatesInventoryHandler serves the inventory of certificates in use as JSON.
+func CertificatesInventoryHandler(ctx *fasthttp.RequestCtx) {
+	certificatesMu.RLock()
+	data, err := json.MarshalIndent(certificateInventory, "", "  ")
+	certificatesMu.RUnlock()
+	if err != nil {
+		ctx.Error(fmt.Sprintf("unable to encode certificates inventory: %s", err), fasthttp.StatusInternalServerError)
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(data)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+type fakeCertificates struct {
+	certs.Certificates
+	inventory []certs.CertInfo
+}
+
+func (c *fakeCertificates) Inventory() []certs.CertInfo {
+	return c.inventory
+}
+
+// certificateExpiryMetrics returns the haproxy_certificate_expiry_seconds gauges by secret.
+func certificateExpiryMetrics(t *testing.T) map[string]float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	gauges := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "haproxy_certificate_expiry_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "secret" {
+					gauges[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return gauges
+}
+
+func TestCertificatesUpdate(t *testing.T) {
+	now := time.Now()
+	inventory := []certs.CertInfo{
+		{Type: "frontend", Secret: "default/valid", File: "/etc/haproxy/certs/frontend/default_valid.pem", Subject: "CN=valid.example.com", NotAfter: now.Add(90 * 24 * time.Hour)},
+		{Type: "frontend", Secret: "default/expiring", File: "/etc/haproxy/certs/frontend/default_expiring.pem", Subject: "CN=expiring.example.com", NotAfter: now.Add(48 * time.Hour)},
+		{Type: "backend", Secret: "default/expired", File: "/etc/haproxy/certs/backend/default_expired.pem", Subject: "CN=expired.example.com", NotAfter: now.Add(-time.Hour)},
+	}
+	fake := &fakeCertificates{inventory: inventory}
+	h := haproxy.HAProxy{Certificates: fake}
+	k := store.NewK8sStore(utils.OSArgs{})
+	handler := &Certificates{}
+
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	gauges := certificateExpiryMetrics(t)
+	require.Len(t, gauges, 3)
+	assert.InDelta(t, (90 * 24 * time.Hour).Seconds(), gauges["default/valid"], 60)
+	assert.InDelta(t, (48 * time.Hour).Seconds(), gauges["default/expiring"], 60)
+	assert.InDelta(t, -time.Hour.Seconds(), gauges["default/expired"], 60)
+	// certificates expiring within the default 30 days window are warned about
+	assert.Len(t, handler.warned, 2)
+	assert.Contains(t, handler.warned, "/etc/haproxy/certs/frontend/default_expiring.pem")
+	assert.Contains(t, handler.warned, "/etc/haproxy/certs/backend/default_expired.pem")
+
+	// an already warned certificate is not warned again before the warning interval
+	warned := handler.warned["/etc/haproxy/certs/frontend/default_expiring.pem"]
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	assert.Equal(t, warned, handler.warned["/etc/haproxy/certs/frontend/default_expiring.pem"])
+	handler.warned["/etc/haproxy/certs/frontend/default_expiring.pem"] = warned.Add(-certificateWarningInterval)
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	assert.True(t, handler.warned["/etc/haproxy/certs/frontend/default_expiring.pem"].After(warned))
+
+	// certificates out of the window are not warned about anymore
+	k.ConfigMaps.Main.Annotations = map[string]string{"certificate-expiry-warning": "24h"}
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	assert.Len(t, handler.warned, 1)
+	assert.Contains(t, handler.warned, "/etc/haproxy/certs/backend/default_expired.pem")
+
+	// an invalid window falls back to the default one
+	k.ConfigMaps.Main.Annotations = map[string]string{"certificate-expiry-warning": "30 days"}
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	assert.Len(t, handler.warned, 2)
+
+	// certificates are checked for expiry between updates
+	handler.warn(now.Add(61 * 24 * time.Hour))
+	assert.Len(t, handler.warned, 3)
+	assert.Contains(t, handler.warned, "/etc/haproxy/certs/frontend/default_valid.pem")
+
+	// metrics of certificates not in use anymore are removed
+	fake.inventory = inventory[:1]
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	gauges = certificateExpiryMetrics(t)
+	assert.Len(t, gauges, 1)
+	assert.Contains(t, gauges, "default/valid")
+}
+
+func TestCertificatesInventoryHandler(t *testing.T) {
+	notAfter := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	fake := &fakeCertificates{inventory: []certs.CertInfo{{
+		Type:       "frontend",
+		Secret:     "default/site",
+		File:       "/etc/haproxy/certs/frontend/default_site.pem",
+		Subject:    "CN=example.com",
+		SANs:       []string{"example.com"},
+		Issuer:     "CN=test CA",
+		NotAfter:   notAfter,
+		References: []string{"ingress/default/web"},
+	}}}
+	require.NoError(t, (&Certificates{}).Update(store.NewK8sStore(utils.OSArgs{}), haproxy.HAProxy{Certificates: fake}, annotations.New()))
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI(CERTIFICATES_URL_PATH)
+	CertificatesInventoryHandler(ctx)
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+	assert.Equal(t, "application/json", string(ctx.Response.Header.ContentType()))
+
+	var inventory []certs.CertInfo
+	require.NoError(t, json.Unmarshal(ctx.Response.Body(), &inventory))
+	assert.Equal(t, fake.inventory, inventory)
+	assert.Contains(t, string(ctx.Response.Body()), `"notAfter": "2026-12-31T00:00:00Z"`)
+}
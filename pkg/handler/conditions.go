@@ -0,0 +1,38 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
+)
+
+//nolint:golint, stylecheck
+const CONDITIONS_URL_PATH = "/debug/conditions"
+
+// ConditionsHandler serves the Configured condition of the resources as JSON.
+func ConditionsHandler(ctx *fasthttp.RequestCtx) {
+	data, err := json.MarshalIndent(events.Conditions(), "", "  ")
+	if err != nil {
+		ctx.Error(fmt.Sprintf("unable to encode conditions: %s", err), fasthttp.StatusInternalServerError)
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(data)
+}
@@ -38,6 +38,7 @@ type HTTPS struct {
 	AddrIPv6                   string
 	CertDir                    string
 	alpn                       string
+	tlsPolicy                  certs.TLSPolicy
 	Port                       int64
 	Enabled                    bool
 	IPv4                       bool
@@ -113,6 +114,7 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 			err = fmt.Errorf("client TLS Auth: %w", err)
 			return err
 		}
+		h.AddReference(certs.CA_CERT, secret.Namespace, secret.Name, "ConfigMap client-ca")
 	}
 
 	binds, bindsErr := h.FrontendBindsGet(h.FrontHTTPS)
@@ -129,8 +131,10 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 		verify = "optional"
 	}
 
+	crlFile := h.CRLFile(caFile)
+
 	// No changes
-	if binds[0].SslCafile == caFile && (caFile == "" || binds[0].Verify == verify) {
+	if binds[0].SslCafile == caFile && (caFile == "" || binds[0].Verify == verify && binds[0].CrlFile == crlFile) {
 		return err
 	}
 	// Removing config
@@ -138,6 +142,7 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 		logger.Info("removing client TLS authentication")
 		for i := range binds {
 			binds[i].SslCafile = ""
+			binds[i].CrlFile = ""
 			binds[i].Verify = ""
 			if err = h.FrontendBindEdit(h.FrontHTTPS, *binds[i]); err != nil {
 				return err
@@ -150,6 +155,7 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 	logger.Info("configuring client TLS authentication")
 	for i := range binds {
 		binds[i].SslCafile = caFile
+		binds[i].CrlFile = crlFile
 		binds[i].Verify = verify
 		if err = h.FrontendBindEdit(h.FrontHTTPS, *binds[i]); err != nil {
 			return err
@@ -159,6 +165,76 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 	return err
 }
 
+// handleSNICrtList adds to the HTTPS binds the crt-list of the hosts having their own TLS options.
+// Its entries are matched by SNI and take precedence over the options of the binds.
+func (handler *HTTPS) handleSNICrtList(h haproxy.HAProxy) error {
+	binds, err := h.FrontendBindsGet(h.FrontHTTPS)
+	if err != nil {
+		return fmt.Errorf("SNI crt-list: %w", err)
+	}
+	crtList := h.SNICrtList(len(binds) > 0 && binds[0].CrtList != "")
+	for i := range binds {
+		if binds[i].CrtList == crtList {
+			continue
+		}
+		binds[i].CrtList = crtList
+		if err = h.FrontendBindEdit(h.FrontHTTPS, *binds[i]); err != nil {
+			return err
+		}
+		instance.Reload("SNI crt-list changed")
+	}
+	return nil
+}
+
+// handleTLSPolicy sets the TLS parameters of the ConfigMap on the HTTPS binds,
+// they apply to the hosts without their own TLS policy.
+func (handler *HTTPS) handleTLSPolicy(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) error {
+	var policy certs.TLSPolicy
+	for _, ann := range a.TLSPolicy(&policy) {
+		if err := ann.Process(k, k.ConfigMaps.Main.Annotations); err != nil {
+			logger.Errorf("annotation %s: %s", ann.GetName(), err)
+		}
+	}
+	binds, err := h.FrontendBindsGet(h.FrontHTTPS)
+	if err != nil {
+		return fmt.Errorf("TLS policy: %w", err)
+	}
+	// the parameters of the binds are only changed when the policy sets them or no longer does,
+	// otherwise they keep their defaults, such as the tls-alpn value.
+	previous := handler.tlsPolicy
+	handler.tlsPolicy = policy
+	set := func(param *string, value, previous, fallback string) bool {
+		if value == "" {
+			if previous == "" {
+				return false
+			}
+			value = fallback
+		}
+		if *param == value {
+			return false
+		}
+		*param = value
+		return true
+	}
+	var updated bool
+	for _, bind := range binds {
+		changed := set(&bind.SslMinVer, policy.MinVer, previous.MinVer, "")
+		changed = set(&bind.Ciphers, policy.Ciphers, previous.Ciphers, "") || changed
+		changed = set(&bind.Ciphersuites, policy.Ciphersuites, previous.Ciphersuites, "") || changed
+		changed = set(&bind.Curves, policy.Curves, previous.Curves, "") || changed
+		changed = set(&bind.Alpn, policy.ALPN, previous.ALPN, handler.alpn) || changed
+		if !changed {
+			continue
+		}
+		if err = h.FrontendBindEdit(h.FrontHTTPS, *bind); err != nil {
+			return err
+		}
+		updated = true
+	}
+	instance.ReloadIf(updated, "TLS policy updated")
+	return nil
+}
+
 func (handler *HTTPS) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
 	if !handler.Enabled {
 		logger.Debug("Cannot proceed with SSL Passthrough update, HTTPS is disabled")
@@ -203,6 +279,14 @@ func (handler *HTTPS) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annot
 		if err != nil {
 			return err
 		}
+		err = handler.handleSNICrtList(h)
+		if err != nil {
+			return err
+		}
+		err = handler.handleTLSPolicy(k, h, a)
+		if err != nil {
+			return err
+		}
 	} else if sslOffloadEnabled {
 		logger.Panic(h.FrontendDisableSSLOffload(h.FrontHTTPS))
 		instance.Reload("SSL offload disabled")
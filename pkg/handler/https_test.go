@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+type fakeBindsClient struct {
+	api.HAProxyClient
+	binds models.Binds
+	edits int
+}
+
+func (c *fakeBindsClient) FrontendBindsGet(frontend string) (models.Binds, error) {
+	return c.binds, nil
+}
+
+func (c *fakeBindsClient) FrontendBindEdit(frontend string, bind models.Bind) error {
+	c.edits++
+	for i := range c.binds {
+		if c.binds[i].Name == bind.Name {
+			*c.binds[i] = bind
+		}
+	}
+	return nil
+}
+
+func TestHandleTLSPolicy(t *testing.T) {
+	client := &fakeBindsClient{binds: models.Binds{
+		{BindParams: models.BindParams{Name: "v4", Alpn: "h2,http/1.1", Ciphers: "ECDHE-RSA-AES128-GCM-SHA256"}},
+	}}
+	h := haproxy.HAProxy{HAProxyClient: client}
+	k := store.NewK8sStore(utils.OSArgs{})
+	handler := &HTTPS{alpn: "h2,http/1.1"}
+
+	// without policy the binds are left untouched
+	require.NoError(t, handler.handleTLSPolicy(k, h, annotations.New()))
+	assert.Equal(t, 0, client.edits)
+	assert.Equal(t, "ECDHE-RSA-AES128-GCM-SHA256", client.binds[0].Ciphers)
+
+	k.ConfigMaps.Main.Annotations = map[string]string{"ssl-min-ver": "TLSv1.2", "tls-alpn": "http/1.1"}
+	require.NoError(t, handler.handleTLSPolicy(k, h, annotations.New()))
+	assert.Equal(t, 1, client.edits)
+	assert.Equal(t, "TLSv1.2", client.binds[0].SslMinVer)
+	assert.Equal(t, "http/1.1", client.binds[0].Alpn)
+	assert.Equal(t, "ECDHE-RSA-AES128-GCM-SHA256", client.binds[0].Ciphers)
+
+	// an unchanged policy doesn't edit the binds
+	require.NoError(t, handler.handleTLSPolicy(k, h, annotations.New()))
+	assert.Equal(t, 1, client.edits)
+
+	// removed parameters are reset, the ALPN to the default one
+	k.ConfigMaps.Main.Annotations = map[string]string{}
+	require.NoError(t, handler.handleTLSPolicy(k, h, annotations.New()))
+	assert.Equal(t, 2, client.edits)
+	assert.Empty(t, client.binds[0].SslMinVer)
+	assert.Equal(t, "h2,http/1.1", client.binds[0].Alpn)
+	assert.Equal(t, "ECDHE-RSA-AES128-GCM-SHA256", client.binds[0].Ciphers)
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+const (
+	ocspTimeout         = 10 * time.Second
+	ocspRetryDelay      = 15 * time.Minute
+	ocspMinRefresh      = 5 * time.Minute
+	ocspMaxRefresh      = 24 * time.Hour
+	ocspMaxResponseSize = 1 << 20
+	ocspCheckInterval   = time.Minute
+)
+
+// OCSP staples OCSP responses to frontend certificates having an OCSP responder.
+// Responses are fetched in the background and set at the next update, they are
+// refreshed halfway through their validity period.
+// A sync is requested when a fetch completes and when a response is due for refresh.
+type OCSP struct {
+	Client    *http.Client
+	mu        sync.Mutex
+	pending   map[string]struct{}
+	results   []ocspResult
+	refreshes map[string]time.Time
+	running   bool
+}
+
+type ocspResult struct {
+	file     string
+	response []byte
+	refresh  time.Time
+}
+
+func (handler *OCSP) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
+	enabled, err := annotations.Bool("ocsp-stapling", k.ConfigMaps.Main.Annotations)
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if err != nil || !enabled {
+		handler.refreshes = nil
+		return err
+	}
+
+	if handler.pending == nil {
+		handler.pending = make(map[string]struct{})
+	}
+	if handler.refreshes == nil {
+		handler.refreshes = make(map[string]time.Time)
+	}
+	if !handler.running {
+		handler.running = true
+		go handler.checkRefreshes()
+	}
+	for _, result := range handler.results {
+		h.UpdateOCSP(result.file, result.response, result.refresh)
+		handler.refreshes[result.file] = result.refresh
+	}
+	handler.results = nil
+	for _, req := range h.OCSPRequests(time.Now()) {
+		if _, ok := handler.pending[req.File]; ok {
+			continue
+		}
+		handler.pending[req.File] = struct{}{}
+		go handler.fetch(req)
+	}
+	return nil
+}
+
+func (handler *OCSP) fetch(req certs.OCSPRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), ocspTimeout)
+	defer cancel()
+	result := ocspResult{file: req.File}
+	now := time.Now()
+	raw, resp, err := fetchOCSPResponse(ctx, handler.Client, req.Leaf, req.Issuer)
+	switch {
+	case err != nil:
+		logger.Warningf("OCSP response of '%s': %s", req.File, err)
+		result.refresh = now.Add(ocspRetryDelay)
+	case resp.Status != ocsp.Good:
+		logger.Warningf("OCSP response of '%s': certificate status is not good, response not stapled", req.File)
+		result.refresh = now.Add(ocspRetryDelay)
+	default:
+		logger.Debugf("OCSP response of '%s' fetched, valid until %s", req.File, resp.NextUpdate)
+		result.response = raw
+		result.refresh = ocspRefreshTime(resp, now)
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	handler.results = append(handler.results, result)
+	delete(handler.pending, req.File)
+	instance.RequestSync("OCSP response of '%s' fetched", req.File)
+}
+
+// checkRefreshes requests a sync when OCSP responses are due for refresh.
+func (handler *OCSP) checkRefreshes() {
+	ticker := time.NewTicker(ocspCheckInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		handler.requestDueRefreshes(now)
+	}
+}
+
+// requestDueRefreshes requests a sync if an OCSP response is due for refresh at now.
+func (handler *OCSP) requestDueRefreshes(now time.Time) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	for file, refresh := range handler.refreshes {
+		if now.Before(refresh) {
+			continue
+		}
+		delete(handler.refreshes, file)
+		instance.RequestSync("OCSP response of '%s' due for refresh", file)
+	}
+}
+
+// fetchOCSPResponse requests the OCSP response of a certificate to the first responder of its AIA extension.
+func fetchOCSPResponse(ctx context.Context, client *http.Client, leaf, issuer *x509.Certificate) ([]byte, *ocsp.Response, error) {
+	if len(leaf.OCSPServer) == 0 {
+		return nil, nil, errors.New("no OCSP responder")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("responder '%s' returned status %d", leaf.OCSPServer[0], httpResp.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(httpResp.Body, ocspMaxResponseSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, resp, nil
+}
+
+// ocspRefreshTime returns when to refresh an OCSP response, halfway through its validity period.
+func ocspRefreshTime(resp *ocsp.Response, now time.Time) time.Time {
+	refresh := now.Add(ocspMaxRefresh)
+	if !resp.NextUpdate.IsZero() {
+		if halfway := resp.ThisUpdate.Add(resp.NextUpdate.Sub(resp.ThisUpdate) / 2); halfway.Before(refresh) {
+			refresh = halfway
+		}
+	}
+	if minRefresh := now.Add(ocspMinRefresh); refresh.Before(minRefresh) {
+		refresh = minRefresh
+	}
+	return refresh
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// fakeOCSPCertificates requests the OCSP response of a certificate until it is set.
+type fakeOCSPCertificates struct {
+	certs.Certificates
+	request   certs.OCSPRequest
+	responses map[string][]byte
+	refresh   time.Time
+}
+
+func (c *fakeOCSPCertificates) OCSPRequests(now time.Time) []certs.OCSPRequest {
+	if now.Before(c.refresh) {
+		return nil
+	}
+	return []certs.OCSPRequest{c.request}
+}
+
+func (c *fakeOCSPCertificates) UpdateOCSP(file string, response []byte, refresh time.Time) {
+	c.responses[file] = response
+	c.refresh = refresh
+}
+
+func newTestCertificate(t *testing.T, template, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	crt, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return crt, key
+}
+
+func TestFetchOCSPResponse(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	issuer, issuerKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, nil)
+
+	status := ocsp.Good
+	// local OCSP responder
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		resp, err := ocsp.CreateResponse(issuer, issuer, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   now,
+			NextUpdate:   now.Add(4 * time.Hour),
+			RevokedAt:    now,
+		}, issuerKey)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+
+	leaf, _ := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		OCSPServer:   []string{responder.URL},
+	}, issuer, issuerKey)
+
+	raw, resp, err := fetchOCSPResponse(context.Background(), responder.Client(), leaf, issuer)
+	require.NoError(t, err)
+	assert.NotEmpty(t, raw)
+	assert.Equal(t, ocsp.Good, resp.Status)
+	assert.True(t, now.Add(2*time.Hour).Equal(ocspRefreshTime(resp, now)))
+
+	status = ocsp.Revoked
+	_, resp, err = fetchOCSPResponse(context.Background(), responder.Client(), leaf, issuer)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, resp.Status)
+
+	leaf.OCSPServer = nil
+	_, _, err = fetchOCSPResponse(context.Background(), responder.Client(), leaf, issuer)
+	assert.Error(t, err)
+}
+
+func TestOCSPRefreshTime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		resp ocsp.Response
+		want time.Time
+	}{
+		{"halfway", ocsp.Response{ThisUpdate: now, NextUpdate: now.Add(8 * time.Hour)}, now.Add(4 * time.Hour)},
+		{"no next update", ocsp.Response{ThisUpdate: now}, now.Add(ocspMaxRefresh)},
+		{"long validity", ocsp.Response{ThisUpdate: now, NextUpdate: now.Add(7 * 24 * time.Hour)}, now.Add(ocspMaxRefresh)},
+		{"almost expired", ocsp.Response{ThisUpdate: now.Add(-time.Hour), NextUpdate: now.Add(time.Minute)}, now.Add(ocspMinRefresh)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ocspRefreshTime(&tt.resp, now))
+		})
+	}
+}
+
+func TestOCSPUpdateRequestsSync(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	issuer, issuerKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, nil)
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		resp, err := ocsp.CreateResponse(issuer, issuer, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   now,
+			NextUpdate:   now.Add(4 * time.Hour),
+		}, issuerKey)
+		require.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+	leaf, _ := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		OCSPServer:   []string{responder.URL},
+	}, issuer, issuerKey)
+
+	file := "/etc/haproxy/certs/frontend/default_site.pem"
+	fake := &fakeOCSPCertificates{request: certs.OCSPRequest{File: file, Leaf: leaf, Issuer: issuer}, responses: map[string][]byte{}}
+	h := haproxy.HAProxy{Certificates: fake}
+	k := store.NewK8sStore(utils.OSArgs{})
+	k.ConfigMaps.Main.Annotations = map[string]string{"ocsp-stapling": "true"}
+	handler := &OCSP{Client: responder.Client()}
+	instance.SyncRequested()
+
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	// the fetched response requests a sync while no resource has changed
+	require.Eventually(t, instance.SyncRequested, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, fake.responses)
+	require.NoError(t, handler.Update(k, h, annotations.New()))
+	assert.NotEmpty(t, fake.responses[file])
+	assert.Equal(t, now.Add(2*time.Hour), fake.refresh)
+	assert.False(t, instance.SyncRequested())
+
+	// a sync is requested once the response is due for refresh
+	handler.requestDueRefreshes(fake.refresh.Add(-time.Minute))
+	assert.False(t, instance.SyncRequested())
+	handler.requestDueRefreshes(fake.refresh)
+	assert.True(t, instance.SyncRequested())
+	handler.requestDueRefreshes(fake.refresh.Add(time.Minute))
+	assert.False(t, instance.SyncRequested())
+}
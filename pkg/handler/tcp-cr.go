@@ -21,6 +21,7 @@ import (
 	"github.com/haproxytech/client-native/v6/models"
 	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
@@ -108,14 +109,17 @@ func (handler TCPCustomResource) Update(k store.K8s, h haproxy.HAProxy, a annota
 				namespace: ns.Name,
 			}
 			for _, tcp := range tcpCR.Items {
+				owner := tcp.Owner()
+				events.Processed(owner)
 				if tcp.CollisionStatus == store.ERROR {
 					logger.Errorf("tcp-cr: skipping tcp '%s/%s/%s' due to collision %s", ctx.namespace, tcp.ParentName, tcp.Name, tcp.Reason)
+					events.Error(owner, events.REASON_INVALID_RESOURCE, fmt.Errorf("tcp '%s' skipped due to collision %s", tcp.Name, tcp.Reason))
 					continue
 				}
-				owner := tcp.Owner()
 				errSvc := handler.checkService(ctx, tcp.TCPModel)
 				if errSvc != nil {
 					errs.Add(errSvc)
+					events.Error(owner, events.REASON_INVALID_RESOURCE, errSvc)
 					continue
 				}
 
@@ -123,6 +127,7 @@ func (handler TCPCustomResource) Update(k store.K8s, h haproxy.HAProxy, a annota
 				errH := handler.reconcileFrontend(ctx, owner, tcp.TCPModel, a)
 				if errH != nil {
 					errs.Add(errH)
+					events.Error(owner, events.REASON_INVALID_RESOURCE, errH)
 					continue
 				}
 
@@ -130,6 +135,7 @@ func (handler TCPCustomResource) Update(k store.K8s, h haproxy.HAProxy, a annota
 				errBack := handler.reconcileAdditionalBackends(ctx, tcp.TCPModel.Services, a)
 				if errBack != nil {
 					errs.Add(errBack)
+					events.Error(owner, events.REASON_INVALID_RESOURCE, errBack)
 					continue
 				}
 			}
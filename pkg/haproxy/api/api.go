@@ -17,6 +17,7 @@ import (
 	runtimeoptions "github.com/haproxytech/client-native/v6/runtime/options"
 
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
@@ -54,6 +55,9 @@ type HAProxyClient interface { //nolint:interfacebloat
 	BackendServerDelete(backendName string, serverName string) error
 	BackendServerGet(serverName, backendNa string) (*models.Server, error)
 	BackendServersGet(backendName string) (models.Servers, error)
+	CacheCreateOrUpdate(cache models.Cache) (updated bool)
+	// CacheStats returns the cache lookups and hits of the backends using a cache
+	CacheStats() (map[string]metrics.CacheStats, error)
 	BackendSwitchingRule
 	Capture
 	DefaultsGetConfiguration() (*models.Defaults, error)
@@ -92,6 +96,7 @@ type HAProxyClient interface { //nolint:interfacebloat
 	UserListCreateByGroup(group string, userPasswordMap map[string][]byte) error
 	Cert
 	CertAuth
+	CertRevocation
 	PushPreviousBackends() error
 	PopPreviousBackends() error
 }
@@ -104,6 +109,7 @@ type Cert interface {
 	CrtListEntryAdd(crtList string, entry runtime.CrtListEntry) error
 	CrtListEntryDelete(crtList, filename string, linenumber *int64) error
 	CertEntryDelete(filename string) error
+	OCSPResponseSet(response []byte) error
 }
 
 type CertAuth interface {
@@ -114,6 +120,13 @@ type CertAuth interface {
 	CertEntryDelete(filename string) error
 }
 
+type CertRevocation interface {
+	CRLEntryCreate(filename string) error
+	CRLEntrySet(filename string, payload []byte) error
+	CRLEntryCommit(filename string) error
+	CRLEntryAbort(filename string) error
+}
+
 type Backend struct { // use same names as in client native v6
 	models.Backend
 	ConfigSnippets []string
@@ -186,6 +199,7 @@ type clientNative struct {
 	nativeAPI                           clientnative.HAProxyClient
 	activeTransaction                   string
 	backends                            map[string]Backend
+	caches                              map[string]Cache
 	previousBackends                    []byte
 	configurationHashAtTransactionStart string
 }
@@ -200,7 +214,16 @@ func New(transactionDir, configFile, programPath, runtimeSocket string) (client
 	if err != nil {
 		return nil, err
 	}
+	return newClient(transactionDir, configFile, programPath, runtimeClient)
+}
+
+// NewFileOnly returns a client editing the configuration file without runtime API,
+// runtime operations fail so that changes are written in files as for a reload.
+func NewFileOnly(transactionDir, configFile, programPath string) (client HAProxyClient, err error) { //nolint:ireturn
+	return newClient(transactionDir, configFile, programPath, nil)
+}
 
+func newClient(transactionDir, configFile, programPath string, runtimeClient runtime.Runtime) (client HAProxyClient, err error) { //nolint:ireturn
 	confClient, err := configuration.New(context.Background(),
 		cfgoptions.ConfigurationFile(configFile),
 		cfgoptions.HAProxyBin(programPath),
@@ -214,7 +237,9 @@ func New(transactionDir, configFile, programPath, runtimeSocket string) (client
 
 	opt := []options.Option{
 		options.Configuration(confClient),
-		options.Runtime(runtimeClient),
+	}
+	if runtimeClient != nil {
+		opt = append(opt, options.Runtime(runtimeClient))
 	}
 	cnHAProxyClient, err := clientnative.New(context.Background(), opt...)
 	if err != nil {
@@ -224,6 +249,7 @@ func New(transactionDir, configFile, programPath, runtimeSocket string) (client
 	cn := clientNative{
 		nativeAPI: cnHAProxyClient,
 		backends:  make(map[string]Backend),
+		caches:    make(map[string]Cache),
 	}
 	return &cn, nil
 }
@@ -299,6 +325,8 @@ func (c *clientNative) APIFinalCommitTransaction() error {
 	for _, deletedBackend := range deletedBackends {
 		instance.Reload("backend '%s' deleted", deletedBackend)
 	}
+	// Caches are processed before the backends using them.
+	errs.AddErrors(c.processCaches(configuration))
 	// ... then we parse the backends to take decisions.
 	for backendName, backend := range c.backends {
 		errs.Add(c.processBackend(&backend.Backend, configuration))
@@ -306,6 +334,8 @@ func (c *clientNative) APIFinalCommitTransaction() error {
 		errs.Add(c.processConfigSnippets(backendName, backend.ConfigSnippets, configuration))
 		errs.AddErrors(c.processACLs(backendName, backend.ACLList, configuration))
 		errs.AddErrors(c.processHTTPRequestRules(backendName, backend.HTTPRequestRuleList, configuration))
+		errs.AddErrors(c.processHTTPResponseRules(backendName, backend.HTTPResponseRuleList, configuration))
+		errs.AddErrors(c.processFilters(backendName, backend.FilterList, configuration))
 		backend.Used = false
 		c.backends[backendName] = backend
 	}
@@ -414,6 +444,27 @@ func (c *clientNative) processHTTPRequestRules(backendName string, httpRequestsR
 	return errs
 }
 
+func (c *clientNative) processHTTPResponseRules(backendName string, httpResponseRules models.HTTPResponseRules, configuration configuration.Configuration) utils.Errors {
+	// we remove all http response rules because of permanent backend still in parsers.
+	_, existingHTTPResponseRules, _ := configuration.GetHTTPResponseRules("backend", backendName, c.activeTransaction)
+	for range existingHTTPResponseRules {
+		_ = configuration.DeleteHTTPResponseRule(0, "backend", backendName, c.activeTransaction, 0)
+	}
+	var errs utils.Errors
+	// we (re)create all http response rules
+	for i, httpResponseRule := range httpResponseRules {
+		errs.Add(configuration.CreateHTTPResponseRule(int64(i), "backend", backendName, httpResponseRule, c.activeTransaction, 0))
+	}
+	return errs
+}
+
+func (c *clientNative) processFilters(backendName string, filters models.Filters, configuration configuration.Configuration) utils.Errors {
+	var errs utils.Errors
+	// filters order matters, they are replaced all at once.
+	errs.Add(configuration.ReplaceFilters("backend", backendName, filters, c.activeTransaction, 0))
+	return errs
+}
+
 func (c *clientNative) PushPreviousBackends() error {
 	logger.Debug("Pushing backends as previous successfully applied backends")
 	jsonBackends, err := json.Marshal(c.backends)
@@ -59,6 +59,19 @@ func (c *clientNative) BackendCreateOrUpdate(backend models.Backend) (diff map[s
 	}
 
 	diff = oldBackend.BackendBase.Diff(backend.BackendBase)
+	// rules and filters lists are processed at the end of the transaction, a change requires a reload.
+	for name, listDiff := range map[string]map[string][]interface{}{
+		"HTTPRequestRuleList":  oldBackend.HTTPRequestRuleList.Diff(backend.HTTPRequestRuleList),
+		"HTTPResponseRuleList": oldBackend.HTTPResponseRuleList.Diff(backend.HTTPResponseRuleList),
+		"FilterList":           oldBackend.FilterList.Diff(backend.FilterList),
+	} {
+		if len(listDiff) > 0 {
+			if diff == nil {
+				diff = map[string][]interface{}{}
+			}
+			diff[name] = []interface{}{listDiff}
+		}
+	}
 
 	oldBackend.Backend = backend
 	oldBackend.Used = true
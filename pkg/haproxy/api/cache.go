@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/configuration"
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// cacheNameRegexp matches the cache lines of the "show cache" runtime command, the entries of the caches being indented.
+var cacheNameRegexp = regexp.MustCompile(`(?m)^0x[0-9a-fA-F]+: (\S+) \(`)
+
+type Cache struct {
+	models.Cache
+	Used bool
+}
+
+// CacheCreateOrUpdate registers a cache section for the current transaction,
+// it returns true if the cache is new or its parameters changed.
+func (c *clientNative) CacheCreateOrUpdate(cache models.Cache) (updated bool) {
+	if cache.Name == nil {
+		return false
+	}
+	oldCache, ok := c.caches[*cache.Name]
+	updated = !ok || !oldCache.Cache.Equal(cache)
+	c.caches[*cache.Name] = Cache{Cache: cache, Used: true}
+	return updated
+}
+
+// processCaches writes the cache sections used in the transaction and removes the other ones.
+func (c *clientNative) processCaches(configuration configuration.Configuration) utils.Errors {
+	var errs utils.Errors
+	_, existingCaches, err := configuration.GetCaches(c.activeTransaction)
+	if err != nil {
+		errs.Add(err)
+		return errs
+	}
+	for _, existingCache := range existingCaches {
+		if existingCache.Name == nil {
+			continue
+		}
+		if cache, ok := c.caches[*existingCache.Name]; ok && cache.Used {
+			continue
+		}
+		errs.Add(configuration.DeleteCache(*existingCache.Name, c.activeTransaction, 0))
+		instance.Reload("cache '%s' deleted", *existingCache.Name)
+	}
+	for name, cache := range c.caches {
+		if !cache.Used {
+			delete(c.caches, name)
+			continue
+		}
+		if errCreate := configuration.CreateCache(&cache.Cache, c.activeTransaction, 0); errCreate != nil {
+			errs.Add(configuration.EditCache(name, &cache.Cache, c.activeTransaction, 0))
+		}
+		cache.Used = false
+		c.caches[name] = cache
+	}
+	return errs
+}
+
+// CacheStats returns the cache lookups and hits of the backends using a cache.
+// Caches are named after their backend, they are listed from the running HAProxy
+// so that the statistics are read without the configuration state of the controller.
+func (c *clientNative) CacheStats() (map[string]metrics.CacheStats, error) {
+	caches, err := c.ExecuteRaw("show cache")
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, match := range cacheNameRegexp.FindAllStringSubmatch(caches, -1) {
+		names[match[1]] = struct{}{}
+	}
+	if len(names) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	// backends statistics only
+	stats, err := c.ExecuteRaw("show stat -1 2 -1")
+	if err != nil {
+		return nil, err
+	}
+	return parseCacheStats(stats, names)
+}
+
+// parseCacheStats reads the cache_lookups and cache_hits fields of the backends in the CSV output of "show stat".
+func parseCacheStats(stats string, backends map[string]struct{}) (map[string]metrics.CacheStats, error) {
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(stats, "# "))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty statistics")
+	}
+	columns := map[string]int{}
+	for i, field := range records[0] {
+		columns[field] = i
+	}
+	pxname, okName := columns["pxname"]
+	lookups, okLookups := columns["cache_lookups"]
+	hits, okHits := columns["cache_hits"]
+	if !okName || !okLookups || !okHits {
+		return nil, errors.New("cache statistics not found")
+	}
+	result := map[string]metrics.CacheStats{}
+	for _, record := range records[1:] {
+		if _, ok := backends[record[pxname]]; !ok {
+			continue
+		}
+		// empty values are reported as 0
+		lookupsValue, _ := strconv.ParseFloat(record[lookups], 64)
+		hitsValue, _ := strconv.ParseFloat(record[hits], 64)
+		result[record[pxname]] = metrics.CacheStats{Lookups: lookupsValue, Hits: hitsValue}
+	}
+	return result, nil
+}
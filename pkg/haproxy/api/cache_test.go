@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/metrics"
+)
+
+func TestCacheNames(t *testing.T) {
+	showCache := "0x7f2b1c2e8018: default_svc_http (shctx:0x7f2b1c2e8000, available blocks:4096)\n" +
+		"  0x7f2b1c2e8268 hash:3331379405 vary:0x0 size:1024 (1 blocks), refcount:0, expire:59\n" +
+		"0x7f2b1c4e8018: default_other_http (shctx:0x7f2b1c4e8000, available blocks:1024)\n"
+	var names []string
+	for _, match := range cacheNameRegexp.FindAllStringSubmatch(showCache, -1) {
+		names = append(names, match[1])
+	}
+	assert.Equal(t, []string{"default_svc_http", "default_other_http"}, names)
+}
+
+func TestParseCacheStats(t *testing.T) {
+	showStat := "# pxname,svname,qcur,cache_lookups,cache_hits,mode,\n" +
+		"default_svc_http,BACKEND,0,120,90,http,\n" +
+		"default_nocache_http,BACKEND,0,0,0,http,\n" +
+		"default_other_http,BACKEND,0,,,http,\n"
+	stats, err := parseCacheStats(showStat, map[string]struct{}{"default_svc_http": {}, "default_other_http": {}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]metrics.CacheStats{
+		"default_svc_http":   {Lookups: 120, Hits: 90},
+		"default_other_http": {},
+	}, stats)
+
+	_, err = parseCacheStats("# pxname,svname,qcur,\ndefault_svc_http,BACKEND,0,\n", map[string]struct{}{"default_svc_http": {}})
+	assert.Error(t, err)
+}
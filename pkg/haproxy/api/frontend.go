@@ -107,12 +107,18 @@ func (c *clientNative) FrontendDisableSSLOffload(frontendName string) (err error
 	for _, bind := range binds {
 		bind.Ssl = false
 		bind.SslCafile = ""
+		bind.CrlFile = ""
 		bind.Verify = ""
 		bind.SslCertificate = ""
 		bind.CaSignFile = ""
 		bind.Alpn = ""
+		bind.SslMinVer = ""
+		bind.Ciphers = ""
+		bind.Ciphersuites = ""
+		bind.Curves = ""
 		bind.StrictSni = false
 		bind.GenerateCertificates = false
+		bind.CrtList = ""
 		err = c.FrontendBindEdit(frontendName, *bind)
 	}
 	if err != nil {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strconv"
@@ -288,6 +289,14 @@ func (c *clientNative) CertEntryDelete(filename string) error {
 	return runtime.DeleteCertEntry(filename)
 }
 
+func (c *clientNative) OCSPResponseSet(response []byte) error {
+	runtime, err := c.nativeAPI.Runtime()
+	if err != nil {
+		return err
+	}
+	return runtime.SetOcspResponse(base64.StdEncoding.EncodeToString(response))
+}
+
 func (c *clientNative) CertAuthEntryCreate(filename string) error {
 	runtime, err := c.nativeAPI.Runtime()
 	if err != nil {
@@ -319,3 +328,35 @@ func (c *clientNative) CertAuthEntryAbort(filename string) error {
 	}
 	return runtime.AbortCAFile(filename)
 }
+
+func (c *clientNative) CRLEntryCreate(filename string) error {
+	runtime, err := c.nativeAPI.Runtime()
+	if err != nil {
+		return err
+	}
+	return runtime.NewCrlFile(filename)
+}
+
+func (c *clientNative) CRLEntrySet(filename string, payload []byte) error {
+	runtime, err := c.nativeAPI.Runtime()
+	if err != nil {
+		return err
+	}
+	return runtime.SetCrlFile(filename, string(payload))
+}
+
+func (c *clientNative) CRLEntryCommit(filename string) error {
+	runtime, err := c.nativeAPI.Runtime()
+	if err != nil {
+		return err
+	}
+	return runtime.CommitCrlFile(filename)
+}
+
+func (c *clientNative) CRLEntryAbort(filename string) error {
+	runtime, err := c.nativeAPI.Runtime()
+	if err != nil {
+		return err
+	}
+	return runtime.AbortCrlFile(filename)
+}
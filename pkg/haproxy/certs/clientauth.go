@@ -0,0 +1,19 @@
+package certs
+
+// ClientAuth holds the client certificate options of a frontend certificate.
+type ClientAuth struct {
+	CAFile  string
+	CRLFile string
+	Verify  string
+}
+
+func (a ClientAuth) options() []string {
+	if a.CAFile == "" {
+		return nil
+	}
+	options := []string{"ca-file " + a.CAFile, "verify " + a.Verify}
+	if a.CRLFile != "" {
+		options = append(options, "crl-file "+a.CRLFile)
+	}
+	return options
+}
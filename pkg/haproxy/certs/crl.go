@@ -0,0 +1,99 @@
+package certs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/google/renameio"
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// crlExt is the suffix of the CRL written next to a CA file when its secret has a "crl.pem" key.
+const crlExt = ".crl"
+
+// addCRL writes the CRL of a CRL_FILE secret in the CRL directory.
+func (c *certs) addCRL(secret *store.Secret) (crlPath string, err error) {
+	content, ok := secret.Data["crl.pem"]
+	if !ok || len(content) == 0 {
+		return "", fmt.Errorf("crl.pem missing in %s/%s", secret.Namespace, secret.Name)
+	}
+	name := fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
+	crt, ok := c.crl[name]
+	if !ok {
+		crt = &cert{
+			name: name,
+			path: path.Join(env.CRLDir, name+".pem"),
+		}
+		c.crl[name] = crt
+	}
+	crt.inUse = true
+	c.writeCRL(crt.path, content)
+	return crt.path, nil
+}
+
+func (c *certs) CRLFile(caFile string) string {
+	for _, crt := range c.ca {
+		if crt.inUse && crt.path == caFile {
+			return crt.crl
+		}
+	}
+	return ""
+}
+
+// writeCRL writes a CRL on disk and loads it through the runtime API,
+// so that revocations take effect without a reload and new CRLs can be used by runtime crt-list entries.
+func (c *certs) writeCRL(filename string, content []byte) {
+	current, known := c.crlContents[filename]
+	if known && bytes.Equal(current, content) {
+		return
+	}
+	c.crlContents[filename] = content
+	fs.Writer.Write(func() {
+		current, err := os.ReadFile(filename)
+		exists := err == nil
+		switch {
+		case os.IsNotExist(err):
+			if err = renameio.WriteFile(filename, content, 0o666); err != nil {
+				logger.Error(err)
+				return
+			}
+			utils.GetLogger().Debugf("CRL written on disk [%s]", filename)
+		case err != nil:
+			logger.Error(err)
+			return
+		case !known && bytes.Equal(current, content):
+			// Without a previous update the file on disk is the one loaded by HAProxy
+			return
+		}
+		if _, err = c.updateRuntime(filename, content, "crl-file", false); err != nil {
+			instance.Reload("Runtime update of CRL file '%s' failed : %s", filename, err.Error())
+		} else {
+			utils.GetLogger().Debugf("Runtime update of CRL ok [%s]", filename)
+		}
+		if !exists {
+			return
+		}
+		fs.AddDelayedFunc(filename, func() {
+			if err := renameio.WriteFile(filename, content, 0o666); err != nil {
+				logger.Error(err)
+				return
+			}
+			utils.GetLogger().Debugf("Delayed writing CRL on disk ok [%s] ", filename)
+		})
+	})
+}
+
+// removeCRL removes a CRL which is not used anymore.
+func (c *certs) removeCRL(filename string) {
+	delete(c.crlContents, filename)
+	fs.AddDelayedFunc(filename, func() {
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			logger.Error(err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CertInfo describes a certificate in use.
+type CertInfo struct {
+	Type       string    `json:"type"`
+	Secret     string    `json:"secret"`
+	File       string    `json:"file"`
+	Subject    string    `json:"subject"`
+	SANs       []string  `json:"sans"`
+	Issuer     string    `json:"issuer"`
+	NotAfter   time.Time `json:"notAfter"`
+	References []string  `json:"references"`
+}
+
+func (c *certs) AddReference(secretType SecretType, namespace, name, reference string) {
+	certName := fmt.Sprintf("%s_%s", namespace, name)
+	var certs map[string]*cert
+	switch secretType {
+	case FT_DEFAULT_CERT:
+		certName = "0_" + certName
+		certs = c.frontend
+	case FT_CERT:
+		certs = c.frontend
+	case BD_CERT:
+		certs = c.backend
+	case CA_CERT:
+		certs = c.ca
+	case TCP_CERT:
+		certs = c.TCPCR
+	case FT_SNI_CERT:
+		certs = c.sni
+	case GW_CERT:
+		certs = c.gateway
+	default:
+		return
+	}
+	crt, ok := certs[certName]
+	if !ok || slices.Contains(crt.refs, reference) {
+		return
+	}
+	crt.refs = append(crt.refs, reference)
+}
+
+func (c *certs) Inventory() []CertInfo {
+	inventory := []CertInfo{}
+	add := func(certType string, certs map[string]*cert) {
+		for _, crt := range certs {
+			if !crt.inUse || crt.leaf == nil {
+				continue
+			}
+			sans := slices.Clone(crt.leaf.DNSNames)
+			for _, ip := range crt.leaf.IPAddresses {
+				sans = append(sans, ip.String())
+			}
+			inventory = append(inventory, CertInfo{
+				Type:       certType,
+				Secret:     crt.name,
+				File:       crt.path,
+				Subject:    crt.leaf.Subject.String(),
+				SANs:       sans,
+				Issuer:     crt.leaf.Issuer.String(),
+				NotAfter:   crt.leaf.NotAfter,
+				References: slices.Sorted(slices.Values(crt.refs)),
+			})
+		}
+	}
+	add("frontend", c.frontend)
+	add("backend", c.backend)
+	add("ca", c.ca)
+	add("tcp", c.TCPCR)
+	add("sni", c.sni)
+	add("gateway", c.gateway)
+	slices.SortFunc(inventory, func(a, b CertInfo) int {
+		return strings.Compare(a.Type+a.File, b.Type+b.File)
+	})
+	return inventory
+}
+
+// parseChain returns the first certificate of PEM encoded data, or nil if there is none,
+// and its issuer if it is part of the chain.
+func parseChain(data []byte) (leaf, issuer *x509.Certificate) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return leaf, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return leaf, nil
+		}
+		if leaf == nil {
+			leaf = crt
+			continue
+		}
+		if bytes.Equal(leaf.RawIssuer, crt.RawSubject) && leaf.CheckSignatureFrom(crt) == nil {
+			return leaf, crt
+		}
+	}
+}
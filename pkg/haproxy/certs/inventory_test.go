@@ -0,0 +1,137 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, template, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	crt, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return crt, key
+}
+
+func pemEncode(crts ...*x509.Certificate) []byte {
+	var data []byte
+	for _, crt := range crts {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt.Raw})...)
+	}
+	return data
+}
+
+func newTestChain(t *testing.T, notAfter time.Time) (leaf, issuer *x509.Certificate) {
+	t.Helper()
+	issuer, issuerKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, nil)
+	leaf, _ = newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}, issuer, issuerKey)
+	return leaf, issuer
+}
+
+func TestParseChain(t *testing.T) {
+	leaf, issuer := newTestChain(t, time.Now().Add(24*time.Hour))
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})
+
+	parsedLeaf, parsedIssuer := parseChain(append(key, pemEncode(leaf, issuer)...))
+	require.NotNil(t, parsedLeaf)
+	assert.Equal(t, leaf.Raw, parsedLeaf.Raw)
+	require.NotNil(t, parsedIssuer)
+	assert.Equal(t, issuer.Raw, parsedIssuer.Raw)
+
+	// the issuer is only known when it is part of the chain
+	parsedLeaf, parsedIssuer = parseChain(pemEncode(leaf))
+	assert.NotNil(t, parsedLeaf)
+	assert.Nil(t, parsedIssuer)
+	parsedLeaf, parsedIssuer = parseChain(pemEncode(leaf, leaf))
+	assert.NotNil(t, parsedLeaf)
+	assert.Nil(t, parsedIssuer)
+
+	parsedLeaf, parsedIssuer = parseChain(key)
+	assert.Nil(t, parsedLeaf)
+	assert.Nil(t, parsedIssuer)
+}
+
+func TestInventory(t *testing.T) {
+	c := newTestCerts(t)
+	assert.Empty(t, c.Inventory())
+
+	leaf, issuer := newTestChain(t, time.Now().Add(24*time.Hour))
+	c.frontend["default_site"] = &cert{name: "default/site", path: "/etc/haproxy/certs/frontend/default_site.pem", inUse: true, leaf: leaf, issuer: issuer}
+	c.frontend["0_default_fallback"] = &cert{name: "default/fallback", path: "/etc/haproxy/certs/frontend/0_default_fallback.pem", inUse: true, leaf: leaf}
+	c.ca["default_ca"] = &cert{name: "default/ca", path: "/etc/haproxy/certs/ca/default_ca.pem", inUse: true, leaf: issuer}
+	// unused certificates and secrets without certificate are not listed
+	c.backend["default_unused"] = &cert{name: "default/unused", path: "/etc/haproxy/certs/backend/default_unused.pem", leaf: leaf}
+	c.TCPCR["default_invalid"] = &cert{name: "default/invalid", path: "/etc/haproxy/certs/tcp/default_invalid.pem", inUse: true}
+
+	c.AddReference(FT_CERT, "default", "site", "ingress/default/web")
+	c.AddReference(FT_CERT, "default", "site", "configmap/haproxy-controller/haproxy-kubernetes-ingress")
+	c.AddReference(FT_CERT, "default", "site", "ingress/default/web")
+	c.AddReference(FT_DEFAULT_CERT, "default", "fallback", "controller")
+	// references to unknown certificates are ignored
+	c.AddReference(BD_CERT, "default", "site", "ingress/default/web")
+	c.AddReference(CRL_FILE, "default", "site", "ingress/default/web")
+
+	assert.Equal(t, []CertInfo{
+		{
+			Type:     "ca",
+			Secret:   "default/ca",
+			File:     "/etc/haproxy/certs/ca/default_ca.pem",
+			Subject:  "CN=test CA",
+			Issuer:   "CN=test CA",
+			NotAfter: issuer.NotAfter,
+		},
+		{
+			Type:       "frontend",
+			Secret:     "default/fallback",
+			File:       "/etc/haproxy/certs/frontend/0_default_fallback.pem",
+			Subject:    "CN=example.com",
+			SANs:       []string{"example.com", "www.example.com", "10.0.0.1"},
+			Issuer:     "CN=test CA",
+			NotAfter:   leaf.NotAfter,
+			References: []string{"controller"},
+		},
+		{
+			Type:       "frontend",
+			Secret:     "default/site",
+			File:       "/etc/haproxy/certs/frontend/default_site.pem",
+			Subject:    "CN=example.com",
+			SANs:       []string{"example.com", "www.example.com", "10.0.0.1"},
+			Issuer:     "CN=test CA",
+			NotAfter:   leaf.NotAfter,
+			References: []string{"configmap/haproxy-controller/haproxy-kubernetes-ingress", "ingress/default/web"},
+		},
+	}, c.Inventory())
+}
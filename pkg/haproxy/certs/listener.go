@@ -0,0 +1,87 @@
+package certs
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/renameio"
+	"github.com/haproxytech/client-native/v6/runtime"
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+)
+
+// listenerCrtList is the crt-list of a Gateway listener, it lists the certificates of the listener
+// in the order of its certificateRefs, the first one being used when no SNI matches.
+type listenerCrtList struct {
+	lines   []string
+	content string
+	inUse   bool
+}
+
+func (c *certs) AddListenerEntry(listener, certPath string, policy TLSPolicy) {
+	crtList, ok := c.gatewayLists[listener]
+	if !ok {
+		crtList = &listenerCrtList{}
+		c.gatewayLists[listener] = crtList
+	}
+	crtList.inUse = true
+	line := crtListLine(runtime.CrtListEntry{
+		File:          certPath,
+		SSLBindConfig: strings.Join(policy.options(), " "),
+	})
+	if !slices.Contains(crtList.lines, line) {
+		crtList.lines = append(crtList.lines, line)
+	}
+}
+
+// ListenerCrtList writes the crt-list of the listener.
+// The bind of the listener is created with its crt-list so an updated crt-list requires a reload.
+func (c *certs) ListenerCrtList(listener string) (crtList string) {
+	list, ok := c.gatewayLists[listener]
+	if !ok || len(list.lines) == 0 {
+		return ""
+	}
+	crtList = listenerCrtListPath(listener)
+	content := strings.Join(list.lines, "\n") + "\n"
+	if content == list.content {
+		return crtList
+	}
+	if err := os.MkdirAll(path.Dir(crtList), 0o755); err != nil {
+		logger.Error(err)
+		return crtList
+	}
+	if err := renameio.WriteFile(crtList, []byte(content), 0o666); err != nil {
+		logger.Error(err)
+		return crtList
+	}
+	if list.content != "" {
+		instance.Reload("crt-list of listener '%s' updated", listener)
+	}
+	list.content = content
+	return crtList
+}
+
+// refreshListenerCrtLists removes the crt-lists of the listeners without any certificate anymore.
+func (c *certs) refreshListenerCrtLists() {
+	for listener, list := range c.gatewayLists {
+		if list.inUse && len(list.lines) != 0 {
+			continue
+		}
+		delete(c.gatewayLists, listener)
+		if list.content == "" {
+			continue
+		}
+		crtList := listenerCrtListPath(listener)
+		fs.AddDelayedFunc(crtList, func() {
+			logger.Error(os.Remove(crtList))
+		})
+	}
+}
+
+// listenerCrtListPath returns the path of the crt-list of a listener, crt-lists are kept in a sub-directory
+// of the Gateway certificates directory so that they are not mistaken for certificates.
+func listenerCrtListPath(listener string) string {
+	return path.Join(env.GatewayDir, "crt-lists", listener+".crt-list")
+}
@@ -0,0 +1,64 @@
+package certs
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCerts(t *testing.T) *certs {
+	t.Helper()
+	dir := t.TempDir()
+	certificates, err := New(Env{
+		MainDir:     dir,
+		FrontendDir: path.Join(dir, "frontend"),
+		BackendDir:  path.Join(dir, "backend"),
+		CaDir:       path.Join(dir, "ca"),
+		TCPCRDir:    path.Join(dir, "tcp"),
+		GatewayDir:  path.Join(dir, "gateway"),
+		JWTDir:      path.Join(dir, "jwt"),
+		SNIDir:      path.Join(dir, "sni"),
+		CRLDir:      path.Join(dir, "crl"),
+	})
+	require.NoError(t, err)
+	return certificates.(*certs)
+}
+
+func TestListenerCrtList(t *testing.T) {
+	c := newTestCerts(t)
+	assert.Empty(t, c.ListenerCrtList("https"))
+
+	c.AddListenerEntry("https", "/etc/haproxy/certs/gateway/default_cert2.pem", TLSPolicy{MinVer: "TLSv1.2", ALPN: "h2,http/1.1"})
+	c.AddListenerEntry("https", "/etc/haproxy/certs/gateway/default_cert1.pem", TLSPolicy{MinVer: "TLSv1.2", ALPN: "h2,http/1.1"})
+	c.AddListenerEntry("https", "/etc/haproxy/certs/gateway/default_cert2.pem", TLSPolicy{MinVer: "TLSv1.2", ALPN: "h2,http/1.1"})
+	c.AddListenerEntry("tls", "/etc/haproxy/certs/gateway/default_cert1.pem", TLSPolicy{})
+
+	crtList := c.ListenerCrtList("https")
+	assert.Equal(t, path.Join(env.GatewayDir, "crt-lists", "https.crt-list"), crtList)
+	content, err := os.ReadFile(crtList)
+	require.NoError(t, err)
+	// the order of the certificateRefs is kept, the first certificate being the default one
+	assert.Equal(t, "/etc/haproxy/certs/gateway/default_cert2.pem [ssl-min-ver TLSv1.2 alpn h2,http/1.1]\n"+
+		"/etc/haproxy/certs/gateway/default_cert1.pem [ssl-min-ver TLSv1.2 alpn h2,http/1.1]\n", string(content))
+
+	crtList = c.ListenerCrtList("tls")
+	content, err = os.ReadFile(crtList)
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/haproxy/certs/gateway/default_cert1.pem\n", string(content))
+
+	// crt-lists of listeners without certificates are removed
+	c.CleanCerts()
+	c.AddListenerEntry("https", "/etc/haproxy/certs/gateway/default_cert1.pem", TLSPolicy{})
+	c.refreshListenerCrtLists()
+	fs.RunDelayedFuncs()
+	_, err = os.Stat(crtList)
+	assert.True(t, os.IsNotExist(err))
+	assert.NotContains(t, c.gatewayLists, "tls")
+	content, err = os.ReadFile(c.ListenerCrtList("https"))
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/haproxy/certs/gateway/default_cert1.pem\n", string(content))
+}
@@ -1,6 +1,8 @@
 package certs
 
 import (
+	"bytes"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/renameio"
 	"github.com/haproxytech/client-native/v6/runtime"
@@ -23,13 +26,44 @@ type certs struct {
 	backend  map[string]*cert
 	ca       map[string]*cert
 	TCPCR    map[string]*cert
-	client   api.HAProxyClient
-	mu       *sync.Mutex
+	// gateway are the certificates of Gateway listeners, listed in the crt-lists of their listeners
+	gateway      map[string]*cert
+	gatewayLists map[string]*listenerCrtList
+	jwt          map[string]*cert
+	// sni are frontend certificates listed in the SNI crt-list with the options of their hosts
+	sni        map[string]*cert
+	sniEntries map[string]*sniEntry
+	sniList    string
+	crl        map[string]*cert
+	// crlContents are the last contents of the CRL files by path, unchanged CRLs are not updated again
+	crlContents map[string][]byte
+	client      api.HAProxyClient
+	mu          *sync.Mutex
 }
 
 type Certificates interface {
 	// Add takes a secret and its type and creats or updates the corresponding certificate
 	AddSecret(secret *store.Secret, secretType SecretType) (certPath string, err error)
+	// AddListenerEntry lists a GW_CERT certificate in the crt-list of a Gateway listener with its TLS options
+	AddListenerEntry(listener, certPath string, policy TLSPolicy)
+	// ListenerCrtList writes the crt-list of a Gateway listener and returns its path, or an empty path if it has no entry
+	ListenerCrtList(listener string) (crtList string)
+	// AddJWTKey creates or updates the PEM encoded public key used to verify JSON Web Tokens
+	AddJWTKey(name string, key []byte) (keyPath string, err error)
+	// AddSNIEntry lists a FT_SNI_CERT certificate in the SNI crt-list with the options of its hosts
+	AddSNIEntry(certPath string, options SNIOptions)
+	// SNICrtList writes the SNI crt-list and returns its path, or an empty path if it has no entry
+	SNICrtList(loaded bool) (crtList string)
+	// AddReference records a resource, such as an Ingress, using the certificate of a secret
+	AddReference(secretType SecretType, namespace, name, reference string)
+	// Inventory returns the details of the certificates in use
+	Inventory() []CertInfo
+	// OCSPRequests returns the frontend certificates whose OCSP response is missing or due for refresh
+	OCSPRequests(now time.Time) []OCSPRequest
+	// CRLFile returns the CRL written next to a CA file, or an empty path if the CA secret has no "crl.pem" key
+	CRLFile(caFile string) (crlFile string)
+	// UpdateOCSP sets the OCSP response of a frontend certificate and when to refresh it, a nil response keeps the current one
+	UpdateOCSP(file string, response []byte, refresh time.Time)
 	// FrontCertsInuse returns true if a frontend certificate is configured.
 	FrontCertsInUse() bool
 	// Updated returns true if there is any updadted/created certificate
@@ -47,6 +81,18 @@ type cert struct {
 	inUse   bool
 	updated bool
 	ca      bool
+	// listed certificates are added to their crt-list with their options, not to the crt-list of their directory
+	listed bool
+	// leaf is the parsed certificate, issuer its issuer if found in the chain, refs the resources using it
+	leaf   *x509.Certificate
+	issuer *x509.Certificate
+	refs   []string
+	// ocsp is the stapled OCSP response, refreshed after ocspRefresh
+	ocsp        []byte
+	ocspRefresh time.Time
+	// content is the last written content of a CA, an unchanged CA is not updated again, crl its CRL if any
+	content []byte
+	crl     string
 }
 
 type SecretType int
@@ -57,6 +103,10 @@ type Env struct {
 	BackendDir  string
 	CaDir       string
 	TCPCRDir    string
+	GatewayDir  string
+	JWTDir      string
+	SNIDir      string
+	CRLDir      string
 }
 
 var env Env
@@ -72,6 +122,9 @@ const (
 	BD_CERT
 	CA_CERT
 	TCP_CERT
+	FT_SNI_CERT
+	CRL_FILE
+	GW_CERT
 )
 
 type SecretCtx struct {
@@ -94,12 +147,31 @@ func New(envParam Env) (Certificates, error) { //nolint:ireturn
 	if env.TCPCRDir == "" {
 		return nil, errors.New("empty name for TCP Cert Directory")
 	}
+	if env.GatewayDir == "" {
+		return nil, errors.New("empty name for Gateway Cert Directory")
+	}
+	if env.JWTDir == "" {
+		return nil, errors.New("empty name for JWT Key Directory")
+	}
+	if env.SNIDir == "" {
+		return nil, errors.New("empty name for SNI Cert Directory")
+	}
+	if env.CRLDir == "" {
+		return nil, errors.New("empty name for CRL Directory")
+	}
 	return &certs{
-		frontend: make(map[string]*cert),
-		backend:  make(map[string]*cert),
-		ca:       make(map[string]*cert),
-		TCPCR:    make(map[string]*cert),
-		mu:       &sync.Mutex{},
+		frontend:     make(map[string]*cert),
+		backend:      make(map[string]*cert),
+		ca:           make(map[string]*cert),
+		TCPCR:        make(map[string]*cert),
+		gateway:      make(map[string]*cert),
+		gatewayLists: make(map[string]*listenerCrtList),
+		jwt:          make(map[string]*cert),
+		sni:          make(map[string]*cert),
+		sniEntries:   make(map[string]*sniEntry),
+		crl:          make(map[string]*cert),
+		crlContents:  make(map[string][]byte),
+		mu:           &sync.Mutex{},
 	}, nil
 }
 
@@ -108,10 +180,13 @@ func (c *certs) AddSecret(secret *store.Secret, secretType SecretType) (certPath
 		err = errors.New("nil secret")
 		return certPath, err
 	}
+	if secretType == CRL_FILE {
+		return c.addCRL(secret)
+	}
 
 	var certs map[string]*cert
 	var crt *cert
-	var crtOk, isCa bool
+	var crtOk, isCa, listed bool
 	var certName string
 	switch secretType {
 	case FT_DEFAULT_CERT:
@@ -136,21 +211,41 @@ func (c *certs) AddSecret(secret *store.Secret, secretType SecretType) (certPath
 		certName = fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
 		certPath = path.Join(env.TCPCRDir, certName)
 		certs = c.TCPCR
+	case FT_SNI_CERT:
+		certName = fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
+		certPath = path.Join(env.SNIDir, certName)
+		certs = c.sni
+		listed = true
+	case GW_CERT:
+		certName = fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
+		certPath = path.Join(env.GatewayDir, certName)
+		certs = c.gateway
+		listed = true
 	default:
 		return "", errors.New("unspecified context")
 	}
 	crt, crtOk = certs[certName]
+	var refs []string
+	var content []byte
 	if crtOk {
 		crt.inUse = true
 		if secret.Status == store.EMPTY {
 			return crt.path, nil
 		}
+		refs = crt.refs
+		content = crt.content
+		if crt.ocsp != nil {
+			c.removeOCSP(crt.path)
+		}
 	}
 	crt = &cert{
-		path:  certPath,
-		name:  fmt.Sprintf("%s/%s", secret.Namespace, secret.Name),
-		inUse: true,
-		ca:    isCa,
+		path:    certPath,
+		name:    fmt.Sprintf("%s/%s", secret.Namespace, secret.Name),
+		inUse:   true,
+		ca:      isCa,
+		listed:  listed,
+		refs:    refs,
+		content: content,
 	}
 	err = c.writeSecret(secret, crt, isCa)
 	if err != nil {
@@ -160,21 +255,60 @@ func (c *certs) AddSecret(secret *store.Secret, secretType SecretType) (certPath
 	return crt.path, nil
 }
 
-func (c *certs) updateRuntime(filename string, payload []byte, isCa bool) (bool, error) {
+// JWT keys are loaded by HAProxy at startup only, so they are written on disk
+// and an updated key triggers a reload instead of a runtime update.
+func (c *certs) AddJWTKey(name string, key []byte) (keyPath string, err error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("empty JWT key '%s'", name)
+	}
+	return c.writeFile(c.jwt, name, path.Join(env.JWTDir, name+".pem"), key)
+}
+
+// writeFile writes a file which is loaded by HAProxy at startup only,
+// the file is marked as updated when its content changes so that a reload is triggered.
+func (c *certs) writeFile(files map[string]*cert, name, filePath string, content []byte) (string, error) {
+	crt, ok := files[name]
+	if !ok {
+		crt = &cert{
+			name: name,
+			path: filePath,
+		}
+		files[name] = crt
+	}
+	crt.inUse = true
+	current, errRead := os.ReadFile(crt.path)
+	if errRead == nil && bytes.Equal(current, content) {
+		return crt.path, nil
+	}
+	if err := renameio.WriteFile(crt.path, content, 0o666); err != nil {
+		return "", err
+	}
+	crt.updated = true
+	return crt.path, nil
+}
+
+// updateRuntime updates a file through the runtime API, certType is the kind of file
+// in the runtime commands: "cert", "ca-file" or "crl-file".
+func (c *certs) updateRuntime(filename string, payload []byte, certType string, listed bool) (bool, error) {
 	// if instance.NeedReload() {
 	// 	return false, nil
 	// }
 	// Only 1 transaction in parallel is possible for now in haproxy
 	// Keep this mutex for now to ensure that we perform 1 transaction at a time
-	certType := "cert"
 	entryCreate := c.client.CertEntryCreate
 	entrySet := c.client.CertEntrySet
 	entryCommit := c.client.CertEntryCommit
-	if isCa {
+	entryAbort := c.client.CertEntryAbort
+	switch certType {
+	case "ca-file":
 		entryCreate = c.client.CertAuthEntryCreate
 		entrySet = c.client.CertAuthEntrySet
 		entryCommit = c.client.CertAuthEntryCommit
-		certType = "ca"
+	case "crl-file":
+		entryCreate = c.client.CRLEntryCreate
+		entrySet = c.client.CRLEntrySet
+		entryCommit = c.client.CRLEntryCommit
+		entryAbort = c.client.CRLEntryAbort
 	}
 
 	c.mu.Lock()
@@ -204,7 +338,7 @@ func (c *certs) updateRuntime(filename string, payload []byte, isCa bool) (bool,
 	err = entryCommit(filename)
 	if err != nil {
 		// Abort transaction
-		errAbort := c.client.CertEntryAbort(filename)
+		errAbort := entryAbort(filename)
 		// If error, just log it
 		// a Reload will follow, transaction will be gone no matter what
 		if errAbort != nil {
@@ -216,7 +350,7 @@ func (c *certs) updateRuntime(filename string, payload []byte, isCa bool) (bool,
 	updated = true
 	utils.GetLogger().Debugf("`commit ssl %s` ok [%s]", certType, filename)
 
-	if !alreadyExists && !isCa {
+	if !alreadyExists && certType == "cert" && !listed {
 		dirPath := filepath.Dir(filename)
 		err = c.client.CrtListEntryAdd(dirPath,
 			runtime.CrtListEntry{
@@ -260,18 +394,47 @@ func (c *certs) CleanCerts() {
 	for i := range c.frontend {
 		c.frontend[i].inUse = false
 		c.frontend[i].updated = false
+		c.frontend[i].refs = nil
 	}
 	for i := range c.backend {
 		c.backend[i].inUse = false
 		c.backend[i].updated = false
+		c.backend[i].refs = nil
 	}
 	for i := range c.ca {
 		c.ca[i].inUse = false
 		c.ca[i].updated = false
+		c.ca[i].refs = nil
 	}
 	for i := range c.TCPCR {
 		c.TCPCR[i].inUse = false
 		c.TCPCR[i].updated = false
+		c.TCPCR[i].refs = nil
+	}
+	for i := range c.gateway {
+		c.gateway[i].inUse = false
+		c.gateway[i].updated = false
+		c.gateway[i].refs = nil
+	}
+	for i := range c.gatewayLists {
+		c.gatewayLists[i].lines = nil
+		c.gatewayLists[i].inUse = false
+	}
+	for i := range c.jwt {
+		c.jwt[i].inUse = false
+		c.jwt[i].updated = false
+	}
+	for i := range c.sni {
+		c.sni[i].inUse = false
+		c.sni[i].updated = false
+		c.sni[i].refs = nil
+	}
+	for i := range c.sniEntries {
+		c.sniEntries[i].inUse = false
+	}
+	for i := range c.crl {
+		c.crl[i].inUse = false
+		c.crl[i].updated = false
 	}
 }
 
@@ -281,6 +444,11 @@ func (c *certs) FrontCertsInUse() bool {
 			return true
 		}
 	}
+	for _, cert := range c.sni {
+		if cert.inUse {
+			return true
+		}
+	}
 	return false
 }
 
@@ -290,10 +458,15 @@ func (c *certs) RefreshCerts(api api.HAProxyClient) {
 	c.refreshCerts(c.backend, env.BackendDir)
 	c.refreshCerts(c.ca, env.CaDir)
 	c.refreshCerts(c.TCPCR, env.TCPCRDir)
+	c.refreshCerts(c.sni, env.SNIDir)
+	c.refreshFiles(c.jwt, env.JWTDir, "JWT key")
+	c.refreshFiles(c.crl, env.CRLDir, "CRL")
+	c.refreshCerts(c.gateway, env.GatewayDir)
+	c.refreshListenerCrtLists()
 }
 
 func (c *certs) CertsUpdated() (reload bool) {
-	for _, certs := range []map[string]*cert{c.frontend, c.backend, c.ca, c.TCPCR} {
+	for _, certs := range []map[string]*cert{c.frontend, c.backend, c.ca, c.TCPCR, c.jwt, c.sni, c.crl, c.gateway} {
 		for _, crt := range certs {
 			if crt.updated {
 				logger.Debugf("Secret '%s' was updated", crt.name)
@@ -318,6 +491,22 @@ func (c *certs) refreshCerts(certs map[string]*cert, certDir string) {
 		// certificate file name should be already in the format: certName.pem
 		certName := strings.Split(filename, ".pem")[0]
 		crt, crtOk := certs[certName]
+		if strings.HasSuffix(filename, ocspExt) {
+			// OCSP responses are not known by the runtime API as files, they are removed with their certificate
+			if !crtOk || !crt.inUse || crt.ocsp == nil {
+				fs.AddDelayedFunc(path.Join(certDir, filename), func() {
+					logger.Error(os.Remove(path.Join(certDir, filename)))
+				})
+			}
+			continue
+		}
+		if strings.HasSuffix(filename, crlExt) {
+			// CRLs are referenced by the configuration, which is reloaded when their CA does not use them anymore
+			if !crtOk || !crt.inUse || crt.crl == "" {
+				c.removeCRL(path.Join(certDir, filename))
+			}
+			continue
+		}
 		if !crtOk || !crt.inUse {
 			err := c.deleteRuntime(certDir, filename)
 			if err != nil {
@@ -333,6 +522,31 @@ func (c *certs) refreshCerts(certs map[string]*cert, certDir string) {
 	}
 }
 
+// refreshFiles removes unused files, such as JWT keys, which are not known by the runtime API so a reload is required.
+func (c *certs) refreshFiles(files map[string]*cert, dir, kind string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	for _, f := range entries {
+		if f.IsDir() {
+			continue
+		}
+		filename := f.Name()
+		name := strings.TrimSuffix(filename, ".pem")
+		if file, ok := files[name]; ok && file.inUse {
+			continue
+		}
+		fs.AddDelayedFunc(path.Join(dir, filename), func() {
+			logger.Error(os.Remove(path.Join(dir, filename)))
+		})
+		delete(files, name)
+		delete(c.crlContents, path.Join(dir, filename))
+		instance.Reload("%s '%s' removed", kind, name)
+	}
+}
+
 func (c *certs) writeSecret(secret *store.Secret, cert *cert, isCa bool) (err error) {
 	var crtValue, keyValue []byte
 	var crtOk, keyOk, pemOk bool
@@ -343,7 +557,16 @@ func (c *certs) writeSecret(secret *store.Secret, cert *cert, isCa bool) (err er
 			return fmt.Errorf("certificate missing in %s/%s", secret.Namespace, secret.Name)
 		}
 		cert.path += ".pem"
+		cert.leaf, cert.issuer = parseChain(crtValue)
+		if crl := secret.Data["crl.pem"]; len(crl) > 0 {
+			cert.crl = cert.path + crlExt
+			c.writeCRL(cert.crl, crl)
+		}
 		content := certContent([]byte(""), crtValue)
+		if bytes.Equal(cert.content, content) {
+			return nil
+		}
+		cert.content = content
 		return c.writeCert(cert, cert.path, content, isCa)
 	}
 	for _, k := range []string{"tls", "rsa", "ecdsa", "dsa"} {
@@ -356,6 +579,9 @@ func (c *certs) writeSecret(secret *store.Secret, cert *cert, isCa bool) (err er
 				// HAProxy "cert bundle"
 				certPath = fmt.Sprintf("%s.%s", certPath, k)
 			}
+			if cert.leaf == nil {
+				cert.leaf, cert.issuer = parseChain(crtValue)
+			}
 			content := certContent(keyValue, crtValue)
 			err = c.writeCert(cert, certPath, content, isCa)
 			if err != nil {
@@ -390,7 +616,11 @@ func (c *certs) writeCert(cert *cert, filename string, content []byte, isCa bool
 			}
 		}
 
-		updated, err := c.updateRuntime(filename, content, isCa)
+		certType := "cert"
+		if isCa {
+			certType = "ca-file"
+		}
+		updated, err := c.updateRuntime(filename, content, certType, cert.listed)
 		if err != nil {
 			instance.Reload("Runtime update of cert file '%s' failed : %s", filename, err.Error())
 		} else if updated {
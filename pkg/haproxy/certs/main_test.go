@@ -0,0 +1,38 @@
+package certs
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshFiles(t *testing.T) {
+	c := newTestCerts(t)
+	// unused files with the same name in different directories are all removed
+	for _, dir := range []string{env.JWTDir, env.CRLDir} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(path.Join(dir, "default_key.pem"), []byte("key"), 0o600))
+	}
+	require.NoError(t, os.WriteFile(path.Join(env.JWTDir, "default_used.pem"), []byte("key"), 0o600))
+	c.jwt["default_key"] = &cert{name: "default/key", path: path.Join(env.JWTDir, "default_key.pem")}
+	c.jwt["default_used"] = &cert{name: "default/used", path: path.Join(env.JWTDir, "default_used.pem"), inUse: true}
+	c.crl["default_key"] = &cert{name: "default/key", path: path.Join(env.CRLDir, "default_key.pem")}
+
+	c.refreshFiles(c.jwt, env.JWTDir, "JWT key")
+	c.refreshFiles(c.crl, env.CRLDir, "CRL")
+	fs.RunDelayedFuncs()
+
+	for _, file := range []string{path.Join(env.JWTDir, "default_key.pem"), path.Join(env.CRLDir, "default_key.pem")} {
+		_, err := os.Stat(file)
+		assert.True(t, os.IsNotExist(err), file)
+	}
+	_, err := os.Stat(path.Join(env.JWTDir, "default_used.pem"))
+	require.NoError(t, err)
+	assert.NotContains(t, c.jwt, "default_key")
+	assert.Contains(t, c.jwt, "default_used")
+	assert.NotContains(t, c.crl, "default_key")
+}
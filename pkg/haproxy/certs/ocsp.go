@@ -0,0 +1,91 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/renameio"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ocspExt is the extension of OCSP response files, HAProxy loads them with the certificate file they are named after.
+const ocspExt = ".ocsp"
+
+// OCSPRequest is a frontend certificate whose OCSP response needs to be fetched.
+type OCSPRequest struct {
+	File   string
+	Leaf   *x509.Certificate
+	Issuer *x509.Certificate
+}
+
+func (c *certs) OCSPRequests(now time.Time) []OCSPRequest {
+	var requests []OCSPRequest
+	for _, crt := range c.frontend {
+		// OCSP responses can't be attached to certificate bundles
+		if !crt.inUse || crt.leaf == nil || crt.issuer == nil || len(crt.leaf.OCSPServer) == 0 ||
+			!strings.HasSuffix(crt.path, ".pem") || now.Before(crt.ocspRefresh) {
+			continue
+		}
+		requests = append(requests, OCSPRequest{File: crt.path, Leaf: crt.leaf, Issuer: crt.issuer})
+	}
+	return requests
+}
+
+func (c *certs) UpdateOCSP(file string, response []byte, refresh time.Time) {
+	var crt *cert
+	for _, frontend := range c.frontend {
+		if frontend.path == file {
+			crt = frontend
+			break
+		}
+	}
+	if crt == nil || crt.issuer == nil {
+		return
+	}
+	crt.ocspRefresh = refresh
+	if response == nil || bytes.Equal(response, crt.ocsp) {
+		return
+	}
+	// the certificate may have been replaced while its response was fetched
+	if _, err := ocsp.ParseResponseForCert(response, crt.leaf, crt.issuer); err != nil {
+		crt.ocspRefresh = time.Time{}
+		return
+	}
+	crt.ocsp = response
+	ocspFile := file + ocspExt
+	fs.Writer.Write(func() {
+		c.mu.Lock()
+		err := c.client.OCSPResponseSet(response)
+		c.mu.Unlock()
+		if err != nil {
+			instance.Reload("Runtime update of OCSP response '%s' failed : %s", ocspFile, err.Error())
+		} else {
+			utils.GetLogger().Debugf("Runtime update of OCSP response ok [%s]", ocspFile)
+		}
+		fs.AddDelayedFunc(ocspFile, func() {
+			err := renameio.WriteFile(ocspFile, response, 0o666)
+			if err != nil {
+				logger.Error(err)
+				return
+			}
+			utils.GetLogger().Debugf("Delayed writing OCSP response on disk ok [%s] ", ocspFile)
+		})
+	})
+}
+
+// removeOCSP removes the OCSP response of a replaced certificate, which would not match the new one.
+func (c *certs) removeOCSP(certPath string) {
+	ocspFile := certPath + ocspExt
+	fs.AddDelayedFunc(ocspFile, func() {
+		if err := os.Remove(ocspFile); err != nil && !os.IsNotExist(err) {
+			logger.Error(err)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package certs
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/renameio"
+	"github.com/haproxytech/client-native/v6/runtime"
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+)
+
+// SNIOptions holds the options of a frontend certificate listed in the SNI crt-list,
+// they apply to the TLS connections whose SNI matches one of SNIs.
+type SNIOptions struct {
+	ClientAuth ClientAuth
+	TLSPolicy  TLSPolicy
+	SNIs       []string
+}
+
+// TLSPolicy holds the TLS parameters of a frontend certificate, empty ones are taken from the binds.
+type TLSPolicy struct {
+	MinVer       string
+	Ciphers      string
+	Ciphersuites string
+	Curves       string
+	ALPN         string
+}
+
+func (p TLSPolicy) options() (options []string) {
+	for _, option := range [][2]string{
+		{"ssl-min-ver", p.MinVer},
+		{"ciphers", p.Ciphers},
+		{"ciphersuites", p.Ciphersuites},
+		{"curves", p.Curves},
+		{"alpn", p.ALPN},
+	} {
+		if option[1] != "" {
+			options = append(options, option[0]+" "+option[1])
+		}
+	}
+	return options
+}
+
+type sniEntry struct {
+	entry runtime.CrtListEntry
+	inUse bool
+}
+
+func (c *certs) AddSNIEntry(certPath string, options SNIOptions) {
+	entry := runtime.CrtListEntry{
+		File:          certPath,
+		SSLBindConfig: strings.Join(append(options.ClientAuth.options(), options.TLSPolicy.options()...), " "),
+		SNIFilter:     slices.Sorted(slices.Values(options.SNIs)),
+	}
+	line := crtListLine(entry)
+	if e, ok := c.sniEntries[line]; ok {
+		e.inUse = true
+		return
+	}
+	c.sniEntries[line] = &sniEntry{entry: entry, inUse: true}
+}
+
+// crtListLine returns the crt-list line of an entry: the certificate file, its options between brackets and its SNI filters.
+func crtListLine(entry runtime.CrtListEntry) string {
+	line := entry.File
+	if entry.SSLBindConfig != "" {
+		line += fmt.Sprintf(" [%s]", entry.SSLBindConfig)
+	}
+	if len(entry.SNIFilter) != 0 {
+		line += " " + strings.Join(entry.SNIFilter, " ")
+	}
+	return line
+}
+
+// SNICrtList writes the crt-list of the hosts having their own TLS options.
+// When the crt-list is already loaded by HAProxy, new entries are added through the runtime API,
+// a removed entry requires a reload.
+func (c *certs) SNICrtList(loaded bool) (crtList string) {
+	crtList = path.Join(env.MainDir, "sni.crt-list")
+	lines := make([]string, 0, len(c.sniEntries))
+	for line, entry := range c.sniEntries {
+		if !entry.inUse {
+			delete(c.sniEntries, line)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		if c.sniList != "" {
+			c.sniList = ""
+			// the crt-list is removed from the binds, which triggers a reload
+			fs.AddDelayedFunc(crtList, func() {
+				logger.Error(os.Remove(crtList))
+			})
+		}
+		return ""
+	}
+	slices.Sort(lines)
+	content := strings.Join(lines, "\n") + "\n"
+	if content == c.sniList {
+		return crtList
+	}
+	if err := renameio.WriteFile(crtList, []byte(content), 0o666); err != nil {
+		logger.Error(err)
+		return crtList
+	}
+	previous := strings.Split(strings.TrimSuffix(c.sniList, "\n"), "\n")
+	c.sniList = content
+
+	runtimeUpdate := loaded && !instance.NeedReload()
+	for _, line := range previous {
+		if line != "" && !slices.Contains(lines, line) {
+			runtimeUpdate = false
+		}
+	}
+	if runtimeUpdate {
+		// new certificates must be loaded before being added to the crt-list
+		fs.Writer.WaitUntilWritesDone()
+		for _, line := range lines {
+			if slices.Contains(previous, line) {
+				continue
+			}
+			if err := c.client.CrtListEntryAdd(crtList, c.sniEntries[line].entry); err != nil {
+				instance.Reload("Runtime update of crt-list '%s' failed : %s", crtList, err.Error())
+				return crtList
+			}
+			logger.Debugf("`add ssl crt-list` ok [%s] [%s]", crtList, line)
+		}
+		return crtList
+	}
+	instance.Reload("SNI crt-list updated")
+	return crtList
+}
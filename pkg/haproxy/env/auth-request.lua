@@ -0,0 +1,86 @@
+-- Copyright 2026 HAProxy Technologies LLC
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--    http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+-- auth-request sends a subrequest to an external authentication service before the request is forwarded.
+--
+--   http-request lua.auth-request <url> <request-headers> <response-headers>
+--
+-- Header lists are comma separated, "-" stands for an empty list.
+-- The request headers are forwarded to the authentication service,
+-- the response headers are copied from a successful authentication response onto the request.
+-- The outcome is stored in txn.auth_response_successful and txn.auth_response_code,
+-- http-request rules following the action deny or redirect the request accordingly.
+
+local function split(list)
+	local result = {}
+	if list == nil or list == "-" then
+		return result
+	end
+	for name in string.gmatch(list, "([^,]+)") do
+		table.insert(result, name)
+	end
+	return result
+end
+
+-- header values returned by HAProxy are indexed from 0
+local function values(list)
+	local result = {}
+	for _, value in pairs(list) do
+		table.insert(result, value)
+	end
+	return result
+end
+
+core.register_action("auth-request", { "http-req" }, function(txn, url, request_headers, response_headers)
+	txn:set_var("txn.auth_response_successful", false)
+	txn:set_var("txn.auth_response_code", 0)
+
+	local incoming = txn.http:req_get_headers()
+	local headers = {}
+	for _, name in ipairs(split(request_headers)) do
+		local list = incoming[name:lower()]
+		if list ~= nil then
+			headers[name] = values(list)
+		end
+	end
+	headers["x-original-method"] = { txn.sf:method() }
+	headers["x-original-uri"] = { txn.sf:pathq() }
+	headers["x-forwarded-host"] = { txn.sf:req_hdr("host") }
+	headers["x-forwarded-for"] = { txn.sf:src() }
+
+	-- headers set by the authentication service can't be provided by the client
+	for _, name in ipairs(split(response_headers)) do
+		txn.http:req_del_header(name)
+	end
+
+	local response = core.httpclient():get{ url = url, headers = headers }
+	if response == nil or response.status == nil or response.status == 0 then
+		core.Warning("auth-request: no response from " .. url)
+		return
+	end
+	txn:set_var("txn.auth_response_code", response.status)
+	if response.status < 200 or response.status >= 300 then
+		return
+	end
+
+	for _, name in ipairs(split(response_headers)) do
+		local list = response.headers[name:lower()]
+		if list ~= nil then
+			for _, value in pairs(list) do
+				txn.http:req_add_header(name, value)
+			end
+		end
+	end
+	txn:set_var("txn.auth_response_successful", true)
+end, 3)
@@ -1,6 +1,8 @@
 package env
 
 import (
+	"slices"
+
 	"github.com/haproxytech/client-native/v6/models"
 
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
@@ -75,6 +77,29 @@ func SetGlobal(global *models.Global, logTargets *models.LogTargets, env Env) {
 		Type: "config",
 	}
 	global.LimitedQuic = true
+	// Lua actions used by the auth-url and mirror-service annotations
+	for _, file := range []string{env.AuthLuaFile, env.MirrorLuaFile} {
+		if file == "" {
+			continue
+		}
+		if global.LuaOptions == nil {
+			global.LuaOptions = &models.LuaOptions{}
+		}
+		if !slices.ContainsFunc(global.LuaOptions.Loads, func(load *models.LuaLoad) bool {
+			return load != nil && load.File != nil && *load.File == file
+		}) {
+			global.LuaOptions.Loads = append(global.LuaOptions.Loads, &models.LuaLoad{File: utils.Ptr(file)})
+		}
+	}
+	// address of the mirror frontend, used by the mirror Lua action
+	if env.MirrorSocket != "" && !slices.ContainsFunc(global.SetVars, func(v *models.SetVar) bool {
+		return v != nil && v.Name != nil && *v.Name == "proc.mirror_address"
+	}) {
+		global.SetVars = append(global.SetVars, &models.SetVar{
+			Name: utils.Ptr("proc.mirror_address"),
+			Expr: utils.Ptr("str(unix@" + env.MirrorSocket + ")"),
+		})
+	}
 }
 
 // SetDefaults will set default values for Defaults section config.
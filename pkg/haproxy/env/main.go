@@ -15,6 +15,7 @@
 package env
 
 import (
+	_ "embed"
 	"errors"
 	"fmt"
 	"os"
@@ -26,6 +27,16 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
 
+// authRequestLua is the Lua action used by the auth-url annotation to query an external authentication service.
+//
+//go:embed auth-request.lua
+var authRequestLua []byte
+
+// mirrorLua is the Lua action used by the mirror-service annotation to send a copy of requests to a shadow backend.
+//
+//go:embed mirror.lua
+var mirrorLua []byte
+
 // Env contains Directories and files required by haproxy
 type Env struct {
 	Certs certs.Env
@@ -35,6 +46,9 @@ type Env struct {
 	MasterSocket   string
 	PIDFile        string
 	AuxCFGFile     string
+	AuthLuaFile    string
+	MirrorLuaFile  string
+	MirrorSocket   string
 	RuntimeDir     string
 	StateDir       string
 	PatternDir     string
@@ -71,6 +85,7 @@ func (env *Env) Init(osArgs utils.OSArgs) (err error) {
 	env.PIDFile = filepath.Join(env.RuntimeDir, "haproxy.pid")
 	env.RuntimeSocket = filepath.Join(env.RuntimeDir, "haproxy-runtime-api.sock")
 	env.MasterSocket = filepath.Join(env.RuntimeDir, "haproxy-master.sock")
+	env.MirrorSocket = filepath.Join(env.RuntimeDir, "haproxy-mirror.sock")
 	if osArgs.Test {
 		env.Binary = "echo"
 		env.RuntimeSocket = ""
@@ -82,11 +97,25 @@ func (env *Env) Init(osArgs utils.OSArgs) (err error) {
 	if err != nil {
 		return err
 	}
+	env.AuthLuaFile = filepath.Join(env.CfgDir, "auth-request.lua")
+	err = renameio.WriteFile(env.AuthLuaFile, authRequestLua, 0o644)
+	if err != nil {
+		return err
+	}
+	env.MirrorLuaFile = filepath.Join(env.CfgDir, "mirror.lua")
+	err = renameio.WriteFile(env.MirrorLuaFile, mirrorLua, 0o644)
+	if err != nil {
+		return err
+	}
 	// Directories
 	env.Certs.MainDir = filepath.Join(env.CfgDir, "certs")
 	env.Certs.FrontendDir = filepath.Join(env.Certs.MainDir, "frontend")
 	env.Certs.BackendDir = filepath.Join(env.Certs.MainDir, "backend")
 	env.Certs.TCPCRDir = filepath.Join(env.Certs.MainDir, "tcp")
+	env.Certs.GatewayDir = filepath.Join(env.Certs.MainDir, "gateway")
+	env.Certs.JWTDir = filepath.Join(env.Certs.MainDir, "jwt")
+	env.Certs.SNIDir = filepath.Join(env.Certs.MainDir, "frontend-sni")
+	env.Certs.CRLDir = filepath.Join(env.Certs.MainDir, "crl")
 	env.Certs.CaDir = filepath.Join(env.Certs.MainDir, "ca")
 	env.MapsDir = filepath.Join(env.CfgDir, "maps")
 	env.PatternDir = filepath.Join(env.CfgDir, "patterns")
@@ -98,6 +127,10 @@ func (env *Env) Init(osArgs utils.OSArgs) (err error) {
 		env.Certs.BackendDir,
 		env.Certs.CaDir,
 		env.Certs.TCPCRDir,
+		env.Certs.GatewayDir,
+		env.Certs.JWTDir,
+		env.Certs.SNIDir,
+		env.Certs.CRLDir,
 		env.MapsDir,
 		env.ErrFileDir,
 		env.StateDir,
@@ -0,0 +1,83 @@
+-- Copyright 2026 HAProxy Technologies LLC
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--    http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+
+-- mirror sends a copy of the request to a shadow backend, the response of the backend is discarded.
+--
+--   http-request lua.mirror <backend>
+--
+-- The copy is sent from a separate task so that it never delays the request.
+-- It reaches <backend> through the local mirror frontend, listening on the address stored in
+-- proc.mirror_address, which routes requests according to their x-haproxy-mirror header.
+-- Only GET, HEAD, POST, PUT and DELETE requests are mirrored.
+-- The request is never delayed to wait for its body: requests whose body is not entirely
+-- in the request buffer when the action runs are not mirrored rather than sent truncated.
+
+-- headers that are set by the HTTP client itself
+local skipped = {
+	["host"] = true,
+	["content-length"] = true,
+	["transfer-encoding"] = true,
+	["connection"] = true,
+}
+
+local methods = {
+	GET = "get",
+	HEAD = "head",
+	POST = "post",
+	PUT = "put",
+	DELETE = "delete",
+}
+
+core.register_action("mirror", { "http-req" }, function(txn, backend)
+	local address = txn:get_var("proc.mirror_address")
+	local method = methods[txn.sf:method()]
+	if address == nil or method == nil then
+		return
+	end
+	if (txn.f:req_body_len() or 0) < (txn.f:req_body_size() or 0) then
+		return
+	end
+
+	local headers = {}
+	for name, list in pairs(txn.http:req_get_headers()) do
+		if not skipped[name] then
+			headers[name] = {}
+			-- header values returned by HAProxy are indexed from 0
+			for _, value in pairs(list) do
+				table.insert(headers[name], value)
+			end
+		end
+	end
+	headers["x-haproxy-mirror"] = { backend }
+	if headers["x-forwarded-for"] == nil then
+		headers["x-forwarded-for"] = { txn.sf:src() }
+	end
+
+	local request = {
+		url = "http://" .. (txn.sf:req_hdr("host") or "localhost") .. txn.sf:pathq(),
+		headers = headers,
+		body = txn.sf:req_body(),
+		dst = address,
+	}
+	core.register_task(function()
+		local ok, err = pcall(function()
+			local client = core.httpclient()
+			client[method](client, request)
+		end)
+		if not ok then
+			core.Warning("mirror: request to " .. backend .. " failed: " .. tostring(err))
+		end
+	end)
+end, 1)
@@ -3,12 +3,16 @@ package instance
 import (
 	"fmt"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
 
 var DefaultConfigurationManager = NewConfigurationManager()
 
+// syncRequested is set by background tasks needing a sync while no resource has changed.
+var syncRequested atomic.Bool
+
 func Reload(reason string, args ...any) {
 	DefaultConfigurationManager.SetReload(reason, args...)
 }
@@ -25,6 +29,18 @@ func Reset() {
 	DefaultConfigurationManager.Reset()
 }
 
+// RequestSync requests a sync at the next sync period even if no resource has changed,
+// it can be called from any goroutine.
+func RequestSync(reason string, args ...any) {
+	syncRequested.Store(true)
+	utils.GetLogger().Debugf("sync required : "+reason, args...)
+}
+
+// SyncRequested returns true if a sync has been requested since the previous call.
+func SyncRequested() bool {
+	return syncRequested.Swap(false)
+}
+
 type configurationManagerImpl struct {
 	logger utils.Logger
 	reload bool
@@ -38,6 +38,7 @@ func New(osArgs utils.OSArgs, env env.Env, cfgFile []byte, p process.Process, cl
 		return h, err
 	}
 	h.Env = env
+	h.HAProxyClient = client
 
 	if osArgs.External {
 		cfgFile = []byte(strings.ReplaceAll(string(cfgFile), "/var/run/haproxy-runtime-api.sock", h.RuntimeSocket))
@@ -55,6 +56,7 @@ func New(osArgs utils.OSArgs, env env.Env, cfgFile []byte, p process.Process, cl
 		route.PATH_EXACT,
 		route.PATH_PREFIX_EXACT,
 		route.PATH_PREFIX,
+		route.PATH_REGEX,
 	}
 	if h.Maps, err = maps.New(env.MapsDir, persistentMaps); err != nil {
 		err = fmt.Errorf("failed to initialize haproxy maps: %w", err)
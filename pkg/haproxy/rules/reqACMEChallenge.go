@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ACMEChallengePath is the path prefix of ACME HTTP-01 challenges.
+const ACMEChallengePath = "/.well-known/acme-challenge/"
+
+// ReqACMEChallenge answers the HTTP-01 challenges of an ACME server.
+// The key authorization of a challenge is its token followed by the thumbprint of the account key,
+// so it is built from the request path without knowing the pending challenges.
+type ReqACMEChallenge struct {
+	Thumbprint string
+}
+
+func (r ReqACMEChallenge) GetType() Type {
+	return REQ_ACME_CHALLENGE
+}
+
+func (r ReqACMEChallenge) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("ACME challenges cannot be answered in TCP mode")
+	}
+	httpRule := models.HTTPRequestRule{
+		Type:                "return",
+		ReturnStatusCode:    utils.PtrInt64(http.StatusOK),
+		ReturnContentType:   &MIME_TYPE_TEXT_PLAIN,
+		ReturnContentFormat: "lf-string",
+		ReturnContent:       "%[path,field(4,/)]." + r.Thumbprint,
+		Cond:                "if",
+		CondTest:            `{ path_reg ^/\.well-known/acme-challenge/[-_A-Za-z0-9]+$ }`,
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
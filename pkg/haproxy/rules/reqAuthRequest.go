@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ReqAuthRequest delegates the authentication of requests to an external service via the auth-request Lua action.
+// Requests are denied with the status of the authentication service when it answers 401 or 403,
+// and with 500 when it is unreachable or answers anything else than 2xx.
+// With SigninURL, unauthenticated requests are redirected instead of being denied with 401.
+type ReqAuthRequest struct {
+	URL             string
+	SigninURL       string
+	RequestHeaders  []string
+	ResponseHeaders []string
+}
+
+func (r ReqAuthRequest) GetType() Type {
+	return REQ_AUTH_REQUEST
+}
+
+func (r ReqAuthRequest) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("external authentication cannot be set in TCP mode")
+	}
+	failed := "!{ var(txn.auth_response_successful) -m bool }"
+	statusIs := func(status int) string {
+		return fmt.Sprintf("%s { var(txn.auth_response_code) -m int %d }", failed, status)
+	}
+	httpRules := []models.HTTPRequestRule{{
+		Type:      "lua",
+		LuaAction: "auth-request",
+		LuaParams: fmt.Sprintf("%s %s %s", r.URL, headerList(r.RequestHeaders), headerList(r.ResponseHeaders)),
+	}}
+	if r.SigninURL != "" {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "redirect",
+			RedirType:  "location",
+			RedirValue: r.SigninURL,
+			Cond:       "if",
+			CondTest:   statusIs(http.StatusUnauthorized),
+		})
+	}
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(int64(status)),
+			Cond:       "if",
+			CondTest:   statusIs(status),
+		})
+	}
+	httpRules = append(httpRules, models.HTTPRequestRule{
+		Type:       "deny",
+		DenyStatus: utils.PtrInt64(http.StatusInternalServerError),
+		Cond:       "if",
+		CondTest:   failed,
+	})
+	// Rules are created with index 0, they are created in reverse order to keep the lua action first.
+	for i := len(httpRules) - 1; i >= 0; i-- {
+		if err := client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRules[i], ingressACL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headerList formats a list of headers as an argument of the auth-request Lua action.
+func headerList(headers []string) string {
+	if len(headers) == 0 {
+		return "-"
+	}
+	return strings.Join(headers, ",")
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// fakeClient records the http-request rules of the frontends, a rule created at index 0 being inserted first.
+type fakeClient struct {
+	api.HAProxyClient
+	httpRequestRules []models.HTTPRequestRule
+}
+
+func (c *fakeClient) FrontendHTTPRequestRuleCreate(id int64, frontend string, rule models.HTTPRequestRule, ingressACL string) error {
+	if ingressACL != "" {
+		rule.Cond = "if"
+		rule.CondTest = ingressACL + " " + rule.CondTest
+	}
+	c.httpRequestRules = append(c.httpRequestRules[:id], append([]models.HTTPRequestRule{rule}, c.httpRequestRules[id:]...)...)
+	return nil
+}
+
+func TestReqAuthRequest(t *testing.T) {
+	client := &fakeClient{}
+	rule := ReqAuthRequest{
+		URL:             "http://auth.default.svc:8080/verify",
+		SigninURL:       "https://auth.example.com/signin",
+		RequestHeaders:  []string{"Authorization", "Cookie"},
+		ResponseHeaders: []string{"X-User"},
+	}
+	err := rule.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "http", Mode: "http"}}, "{ var(txn.path_match) -m dom 1234 }")
+	require.NoError(t, err)
+	require.Len(t, client.httpRequestRules, 5)
+
+	auth := client.httpRequestRules[0]
+	assert.Equal(t, "lua", auth.Type)
+	assert.Equal(t, "auth-request", auth.LuaAction)
+	assert.Equal(t, "http://auth.default.svc:8080/verify Authorization,Cookie X-User", auth.LuaParams)
+	assert.Equal(t, "{ var(txn.path_match) -m dom 1234 } ", auth.CondTest)
+
+	redirect := client.httpRequestRules[1]
+	assert.Equal(t, "redirect", redirect.Type)
+	assert.Equal(t, "https://auth.example.com/signin", redirect.RedirValue)
+	assert.Equal(t, "{ var(txn.path_match) -m dom 1234 } !{ var(txn.auth_response_successful) -m bool } { var(txn.auth_response_code) -m int 401 }", redirect.CondTest)
+
+	for i, status := range []int64{401, 403, 500} {
+		deny := client.httpRequestRules[i+2]
+		assert.Equal(t, "deny", deny.Type)
+		assert.Equal(t, status, *deny.DenyStatus)
+	}
+	assert.Equal(t, "{ var(txn.path_match) -m dom 1234 } !{ var(txn.auth_response_successful) -m bool }", client.httpRequestRules[4].CondTest)
+}
+
+func TestReqAuthRequestWithoutHeaders(t *testing.T) {
+	client := &fakeClient{}
+	err := ReqAuthRequest{URL: "http://auth.default.svc/verify"}.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "http", Mode: "http"}}, "")
+	require.NoError(t, err)
+	// without signin URL, unauthenticated requests are denied
+	require.Len(t, client.httpRequestRules, 4)
+	assert.Equal(t, "http://auth.default.svc/verify - -", client.httpRequestRules[0].LuaParams)
+	assert.Equal(t, "deny", client.httpRequestRules[1].Type)
+
+	err = ReqAuthRequest{URL: "http://auth.default.svc/verify"}.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "tcp", Mode: "tcp"}}, "")
+	assert.Error(t, err)
+}
+
+func TestReqAuthRequestWithBasicAuth(t *testing.T) {
+	// external and basic authentications of the same ingress are distinct rules, evaluated in the order of their types.
+	rules := SectionRules{}
+	require.NoError(t, rules.AddRule("http", ReqAuthRequest{URL: "http://auth.default.svc/verify"}, true))
+	require.NoError(t, rules.AddRule("http", ReqBasicAuth{AuthGroup: "default-ingress", AuthRealm: "protected"}, true))
+	assert.Len(t, rules["http"].rules[REQ_AUTH], 1)
+	assert.Len(t, rules["http"].rules[REQ_AUTH_REQUEST], 1)
+	assert.Less(t, REQ_AUTH, REQ_AUTH_REQUEST)
+	assert.NotEqual(t, GetID(ReqAuthRequest{URL: "http://auth.default.svc/verify"}), GetID(ReqBasicAuth{AuthGroup: "default-ingress", AuthRealm: "protected"}))
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ReqClientAuth checks the client certificate of requests to hosts requiring client certificates
+// and forwards the subject DN and serial number of the certificate to the backend.
+// Requests must be sent over TLS with an SNI matching the Host header, so that a connection
+// negotiated for another host can't be used to skip the client certificate verification.
+// Unless Optional is set, requests without client certificate are denied.
+type ReqClientAuth struct {
+	Optional bool
+}
+
+func (r ReqClientAuth) GetType() Type {
+	return REQ_CLIENT_AUTH
+}
+
+func (r ReqClientAuth) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("client certificate authentication cannot be set in TCP mode")
+	}
+	httpRules := []models.HTTPRequestRule{
+		{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(http.StatusForbidden),
+			Cond:       "if",
+			CondTest:   "!{ ssl_fc }",
+		},
+		{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(http.StatusMisdirectedRequest),
+			Cond:       "if",
+			CondTest:   "!{ ssl_fc_sni,lower,strcmp(txn.host) eq 0 }",
+		},
+	}
+	if !r.Optional {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(http.StatusForbidden),
+			Cond:       "if",
+			CondTest:   "!{ ssl_c_used }",
+		})
+	}
+	for _, header := range []struct{ name, format string }{
+		{"X-SSL-Client-DN", "%[ssl_c_s_dn]"},
+		{"X-SSL-Client-Serial", "%[ssl_c_serial,hex]"},
+	} {
+		// headers sent by the client are always removed
+		httpRules = append(httpRules,
+			models.HTTPRequestRule{
+				Type:    "del-header",
+				HdrName: header.name,
+			},
+			models.HTTPRequestRule{
+				Type:      "set-header",
+				HdrName:   header.name,
+				HdrFormat: header.format,
+				Cond:      "if",
+				CondTest:  "{ ssl_c_used }",
+			},
+		)
+	}
+	// Rules are created with index 0, they are created in reverse order to keep their sequence.
+	for i := len(httpRules) - 1; i >= 0; i-- {
+		if err := client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRules[i], ingressACL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
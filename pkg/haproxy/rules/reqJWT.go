@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// JWTAlgorithms lists the JWT signature algorithms supported with public keys.
+var JWTAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// JWTForbiddenChars are not allowed in the key IDs, issuer, audience and required claim values, which are inserted as is in the ACLs:
+// they would split the value, start a comment or be unescaped by the configuration parser.
+const JWTForbiddenChars = " \t\r\n'\"#\\"
+
+// ReqJWT requires requests to carry a valid bearer JSON Web Token.
+// The token signature is verified with one of Keys, then its expiration, issuer, audience and
+// required claims are checked. Tokens without expiration are not accepted. Requests failing any check are denied with DenyStatus.
+// Claims of valid tokens can be copied into request headers with ClaimHeaders.
+type ReqJWT struct {
+	Keys           []JWTKey
+	Algorithms     []string
+	Issuer         string
+	Audience       string
+	RequiredClaims []JWTClaim
+	ClaimHeaders   []JWTClaimHeader
+	DenyStatus     int64
+}
+
+// JWTKey is a public key file with the algorithms it can verify.
+// When Kid is set, the key is only used for tokens with the same key ID.
+type JWTKey struct {
+	Path       string
+	Kid        string
+	Algorithms []string
+}
+
+type JWTClaim struct {
+	Name  string
+	Value string
+}
+
+type JWTClaimHeader struct {
+	Claim  string
+	Header string
+}
+
+func (r ReqJWT) GetType() Type {
+	return REQ_AUTH
+}
+
+func (r ReqJWT) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("JWT validation cannot be set in TCP mode")
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+	denyStatus := r.DenyStatus
+	if denyStatus == 0 {
+		denyStatus = http.StatusUnauthorized
+	}
+	deny := func(condTest string) models.HTTPRequestRule {
+		return models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(denyStatus),
+			Cond:       "if",
+			CondTest:   condTest,
+		}
+	}
+	httpRules := []models.HTTPRequestRule{
+		{
+			Type:     "set-var",
+			VarScope: "txn",
+			VarName:  "jwt_valid",
+			VarExpr:  "bool(false)",
+		},
+		{
+			Type:     "set-var",
+			VarScope: "txn",
+			VarName:  "jwt_alg",
+			VarExpr:  "http_auth_bearer,jwt_header_query('$.alg')",
+		},
+	}
+	for _, key := range r.Keys {
+		kidTest := ""
+		if key.Kid != "" {
+			kidTest = fmt.Sprintf(" { http_auth_bearer,jwt_header_query('$.kid') -m str %s }", key.Kid)
+		}
+		for _, alg := range key.Algorithms {
+			if len(r.Algorithms) > 0 && !slices.Contains(r.Algorithms, alg) {
+				continue
+			}
+			httpRules = append(httpRules, models.HTTPRequestRule{
+				Type:     "set-var",
+				VarScope: "txn",
+				VarName:  "jwt_valid",
+				VarExpr:  "bool(true)",
+				Cond:     "if",
+				CondTest: fmt.Sprintf("!{ var(txn.jwt_valid) -m bool } { var(txn.jwt_alg) -m str %s }%s { http_auth_bearer,jwt_verify(%s,\"%s\") -m int 1 }", alg, kidTest, alg, key.Path),
+			})
+		}
+	}
+	httpRules = append(httpRules,
+		deny("!{ var(txn.jwt_valid) -m bool }"),
+		models.HTTPRequestRule{
+			Type:     "set-var",
+			VarScope: "txn",
+			VarName:  "jwt_exp",
+			VarExpr:  "http_auth_bearer,jwt_payload_query('$.exp','int')",
+		},
+		models.HTTPRequestRule{
+			Type:     "set-var",
+			VarScope: "txn",
+			VarName:  "jwt_now",
+			VarExpr:  "date()",
+		},
+		deny("!{ var(txn.jwt_exp) -m found }"),
+		deny("{ var(txn.jwt_exp),sub(txn.jwt_now) -m int lt 0 }"),
+	)
+	if r.Issuer != "" {
+		httpRules = append(httpRules, deny(fmt.Sprintf("!{ http_auth_bearer,jwt_payload_query('$.iss') -m str %s }", r.Issuer)))
+	}
+	if r.Audience != "" {
+		// aud is either a single string or an array of strings
+		httpRules = append(httpRules, deny(fmt.Sprintf("!{ http_auth_bearer,jwt_payload_query('$.aud') -m str %[1]s } !{ http_auth_bearer,jwt_payload_query('$.aud') -m sub '\"%[1]s\"' }", r.Audience)))
+	}
+	for _, claim := range r.RequiredClaims {
+		httpRules = append(httpRules, deny(fmt.Sprintf("!{ http_auth_bearer,jwt_payload_query('$.%s') -m str %s }", claim.Name, claim.Value)))
+	}
+	for _, claim := range r.ClaimHeaders {
+		httpRules = append(httpRules,
+			models.HTTPRequestRule{
+				Type:    "del-header",
+				HdrName: claim.Header,
+			},
+			models.HTTPRequestRule{
+				Type:      "set-header",
+				HdrName:   claim.Header,
+				HdrFormat: fmt.Sprintf("%%[http_auth_bearer,jwt_payload_query('$.%s')]", claim.Claim),
+				Cond:      "if",
+				CondTest:  fmt.Sprintf("{ http_auth_bearer,jwt_payload_query('$.%s') -m found }", claim.Claim),
+			},
+		)
+	}
+	// Rules are created with index 0, they are created in reverse order to keep their sequence.
+	for i := len(httpRules) - 1; i >= 0; i-- {
+		if err := client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRules[i], ingressACL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that the values inserted as is in the ACLs do not contain JWTForbiddenChars.
+func (r ReqJWT) validate() error {
+	values := []string{r.Issuer, r.Audience}
+	for _, key := range r.Keys {
+		values = append(values, key.Kid)
+	}
+	for _, claim := range r.RequiredClaims {
+		values = append(values, claim.Value)
+	}
+	for _, value := range values {
+		if strings.ContainsAny(value, JWTForbiddenChars) {
+			return fmt.Errorf("invalid JWT value '%s': whitespaces, quotes, # and \\ are not allowed", value)
+		}
+	}
+	return nil
+}
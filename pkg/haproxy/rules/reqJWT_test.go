@@ -0,0 +1,61 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReqJWT(t *testing.T) {
+	client := &fakeClient{}
+	rule := ReqJWT{
+		Keys:       []JWTKey{{Path: "/etc/haproxy/jwt/default_keys.pem", Kid: "key1", Algorithms: []string{"RS256", "ES256"}}},
+		Algorithms: []string{"RS256"},
+		Issuer:     "https://issuer.example.com",
+	}
+	err := rule.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "http", Mode: "http"}}, "")
+	require.NoError(t, err)
+
+	conditions := make([]string, 0, len(client.httpRequestRules))
+	for _, httpRule := range client.httpRequestRules {
+		if httpRule.Type == "deny" {
+			assert.Equal(t, int64(401), *httpRule.DenyStatus)
+		}
+		conditions = append(conditions, httpRule.Type+" "+httpRule.CondTest)
+	}
+	assert.Equal(t, []string{
+		"set-var ",
+		"set-var ",
+		// ES256 is not an allowed algorithm
+		`set-var !{ var(txn.jwt_valid) -m bool } { var(txn.jwt_alg) -m str RS256 } { http_auth_bearer,jwt_header_query('$.kid') -m str key1 } { http_auth_bearer,jwt_verify(RS256,"/etc/haproxy/jwt/default_keys.pem") -m int 1 }`,
+		"deny !{ var(txn.jwt_valid) -m bool }",
+		"set-var ",
+		"set-var ",
+		// tokens without expiration are denied
+		"deny !{ var(txn.jwt_exp) -m found }",
+		"deny { var(txn.jwt_exp),sub(txn.jwt_now) -m int lt 0 }",
+		"deny !{ http_auth_bearer,jwt_payload_query('$.iss') -m str https://issuer.example.com }",
+	}, conditions)
+}
+
+func TestReqJWTInvalidValues(t *testing.T) {
+	frontend := &models.Frontend{FrontendBase: models.FrontendBase{Name: "http", Mode: "http"}}
+	for _, rule := range []ReqJWT{
+		{Issuer: "https://issuer.example.com#"},
+		{Audience: `api\`},
+		{Audience: "api'"},
+		{RequiredClaims: []JWTClaim{{Name: "scope", Value: "read }"}}},
+		{Keys: []JWTKey{{Path: "/etc/haproxy/jwt/default_keys.pem", Kid: `key"1`, Algorithms: []string{"RS256"}}}},
+	} {
+		client := &fakeClient{}
+		require.Error(t, rule.Create(client, frontend, ""))
+		assert.Empty(t, client.httpRequestRules)
+	}
+}
+
+func TestReqJWTTCPMode(t *testing.T) {
+	err := ReqJWT{DenyStatus: 403}.Create(&fakeClient{}, &models.Frontend{FrontendBase: models.FrontendBase{Name: "tcp", Mode: "tcp"}}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// ReqRouteMatch sets txn.match_key to Key when the request matches all the ACLs.
+// The key prefixes the host and path looked up in the routing maps, the first
+// ReqRouteMatch rule matched by the request sets it.
+type ReqRouteMatch struct {
+	Key  string
+	ACLs []string
+}
+
+func (r ReqRouteMatch) GetType() Type {
+	return REQ_ROUTE_MATCH
+}
+
+func (r ReqRouteMatch) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("route-match can not be used in tcp mode")
+	}
+	condTest := "!{ var(txn.match_key) -m found }"
+	for _, acl := range r.ACLs {
+		condTest = fmt.Sprintf("%s { %s }", condTest, acl)
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, models.HTTPRequestRule{
+		Type:     "set-var",
+		VarScope: "txn",
+		VarName:  "match_key",
+		VarExpr:  fmt.Sprintf("str(%s)", r.Key),
+		Cond:     "if",
+		CondTest: condTest,
+	}, ingressACL)
+}
@@ -0,0 +1,32 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReqRouteMatch(t *testing.T) {
+	client := &fakeClient{}
+	rule := ReqRouteMatch{Key: "1234", ACLs: []string{"req.hdr(X-API-Version) -m str v2", "method GET HEAD"}}
+	err := rule.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "http", Mode: "http"}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []models.HTTPRequestRule{{
+		Type:     "set-var",
+		VarScope: "txn",
+		VarName:  "match_key",
+		VarExpr:  "str(1234)",
+		Cond:     "if",
+		CondTest: "!{ var(txn.match_key) -m found } { req.hdr(X-API-Version) -m str v2 } { method GET HEAD }",
+	}}, client.httpRequestRules)
+
+	err = rule.Create(client, &models.Frontend{FrontendBase: models.FrontendBase{Name: "tcp", Mode: "tcp"}}, "")
+	assert.Error(t, err)
+}
+
+func TestReqRouteMatchOrder(t *testing.T) {
+	// the match key is set before the variables used to look up the routing maps
+	assert.Less(t, REQ_ROUTE_MATCH, REQ_SET_VAR)
+}
@@ -17,6 +17,8 @@ type SetHdr struct {
 	Response       bool
 	AfterResponse  bool
 	ForwardedProto bool
+	// HTTPSOnly rules are only added to the HTTPS frontend
+	HTTPSOnly bool
 }
 
 func (r SetHdr) GetType() Type {
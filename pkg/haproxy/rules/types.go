@@ -9,14 +9,18 @@ const (
 	REQ_ACCEPT_CONTENT Type = iota
 	REQ_INSPECT_DELAY
 	REQ_PROXY_PROTOCOL
+	REQ_ROUTE_MATCH
 	REQ_SET_VAR
 	REQ_SET_SRC
+	REQ_ACME_CHALLENGE
 	REQ_DENY
 	REQ_TRACK
 	REQ_AUTH
+	REQ_AUTH_REQUEST
 	REQ_RATELIMIT
 	REQ_CAPTURE
 	REQ_REDIRECT
+	REQ_CLIENT_AUTH
 	REQ_FORWARDED_PROTO
 	REQ_SET_HEADER
 	REQ_SET_HOST
@@ -29,14 +33,18 @@ var constLookup = map[Type]string{
 	REQ_ACCEPT_CONTENT:  "REQ_ACCEPT_CONTENT",
 	REQ_INSPECT_DELAY:   "REQ_INSPECT_DELAY",
 	REQ_PROXY_PROTOCOL:  "REQ_PROXY_PROTOCOL",
+	REQ_ROUTE_MATCH:     "REQ_ROUTE_MATCH",
 	REQ_SET_VAR:         "REQ_SET_VAR",
 	REQ_SET_SRC:         "REQ_SET_SRC",
+	REQ_ACME_CHALLENGE:  "REQ_ACME_CHALLENGE",
 	REQ_DENY:            "REQ_DENY",
 	REQ_TRACK:           "REQ_TRACK",
 	REQ_AUTH:            "REQ_AUTH",
+	REQ_AUTH_REQUEST:    "REQ_AUTH_REQUEST",
 	REQ_RATELIMIT:       "REQ_RATELIMIT",
 	REQ_CAPTURE:         "REQ_CAPTURE",
 	REQ_REDIRECT:        "REQ_REDIRECT",
+	REQ_CLIENT_AUTH:     "REQ_CLIENT_AUTH",
 	REQ_FORWARDED_PROTO: "REQ_FORWARDED_PROTO",
 	REQ_SET_HEADER:      "REQ_SET_HEADER",
 	REQ_SET_HOST:        "REQ_SET_HOST",
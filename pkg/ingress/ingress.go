@@ -15,7 +15,11 @@
 package ingress
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
@@ -31,8 +35,17 @@ type Ingress struct {
 	resource        *store.Ingress
 	controllerClass string
 	ruleIDs         []rules.RuleID
+	// canaryACLs is nil if the ingress is not a canary
+	canaryACLs []string
+	// matchACLs is nil if the ingress has no route-match annotations
+	matchACLs []string
+	// clientAuth has an empty CAFile if the ingress doesn't require client certificates
+	clientAuth certs.ClientAuth
+	// tlsPolicy has the TLS parameters of the ingress hosts, empty ones are taken from the ConfigMap
+	tlsPolicy       certs.TLSPolicy
 	allowEmptyClass bool
 	sslPassthrough  bool
+	pathRegex       bool
 }
 
 // New returns an Ingress instance to handle the k8s ingress resource given in params.
@@ -99,13 +112,20 @@ func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *
 		Path:           path,
 		HAProxyRules:   i.ruleIDs,
 		BackendName:    backendName,
+		MatchACLs:      i.matchACLs,
+		PathRegex:      i.pathRegex,
 		SSLPassthrough: i.sslPassthrough,
 	}
 
 	routeACLAnn := a.String("route-acl", svc.GetResource().Annotations)
-	if routeACLAnn == "" {
+	switch {
+	case i.canaryACLs != nil:
+		err = route.AddCanaryRoute(ingRoute, i.canaryACLs, h)
+	case routeACLAnn == "" && i.matchACLs != nil:
+		err = route.AddMatchRoute(ingRoute, h.Maps, h.Rules)
+	case routeACLAnn == "":
 		err = route.AddHostPathRoute(ingRoute, h.Maps)
-	} else {
+	default:
 		err = route.AddCustomRoute(ingRoute, routeACLAnn, h)
 	}
 	if err != nil {
@@ -116,6 +136,8 @@ func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *
 		svc.HandleHAProxySrvs(k, h)
 		k.BackendsProcessed[backendName] = struct{}{}
 	}
+	// Mirror backend
+	svc.HandleMirrorBackend(k, h, a)
 	return err
 }
 
@@ -126,23 +148,82 @@ func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *
 func (i *Ingress) handleAnnotations(k store.K8s, h haproxy.HAProxy) {
 	var err error
 	result := rules.List{}
-	for _, a := range i.annotations.Frontend(i.resource, &result, h.Maps) {
+	for _, a := range i.annotations.Frontend(i.resource, &result, h.Maps, h.Certificates) {
+		err = a.Process(k, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+		if err != nil {
+			i.annotationError(a.GetName(), err)
+		}
+	}
+	// client auth annotations are only taken from the ingress, the ConfigMap client-ca applies to all hosts.
+	i.clientAuth = certs.ClientAuth{}
+	for _, a := range i.annotations.ClientAuth(&i.clientAuth, i.resource, &result, h.Certificates) {
+		err = a.Process(k, i.resource.Annotations)
+		if err != nil {
+			i.annotationError(a.GetName(), err)
+		}
+	}
+	// security headers annotations are not processed globally so that the ingress values override the ConfigMap ones.
+	for _, a := range i.annotations.SecurityHeaders(&result) {
 		err = a.Process(k, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
 		if err != nil {
-			logger.Errorf("Ingress '%s/%s': annotation %s: %s", i.resource.Namespace, i.resource.Name, a.GetName(), err)
+			i.annotationError(a.GetName(), err)
+		}
+	}
+	// TLS policy annotations are only taken from the ingress, the ConfigMap ones apply to the binds.
+	i.tlsPolicy = certs.TLSPolicy{}
+	for _, a := range i.annotations.TLSPolicy(&i.tlsPolicy) {
+		// tls-alpn has a default value which must not override the ConfigMap one
+		if _, ok := i.resource.Annotations[a.GetName()]; !ok {
+			continue
+		}
+		err = a.Process(k, i.resource.Annotations)
+		if err != nil {
+			i.annotationError(a.GetName(), err)
 		}
 	}
 	i.ruleIDs = addRules(result, h, true)
+	// canary annotations are only taken from the ingress, they can't be set globally.
+	i.canaryACLs = nil
+	for _, a := range i.annotations.Canary(&i.canaryACLs) {
+		err = a.Process(k, i.resource.Annotations)
+		if err != nil {
+			i.annotationError(a.GetName(), err)
+		}
+	}
+	// route-match annotations are only taken from the ingress too.
+	i.matchACLs = nil
+	for _, a := range i.annotations.RouteMatch(&i.matchACLs) {
+		err = a.Process(k, i.resource.Annotations)
+		if err != nil {
+			i.annotationError(a.GetName(), err)
+		}
+	}
+}
+
+// annotationError logs the error of an ingress annotation and reports it on the ingress.
+func (i *Ingress) annotationError(name string, err error) {
+	logger.Errorf("Ingress '%s/%s': annotation %s: %s", i.resource.Namespace, i.resource.Name, name, err)
+	i.reportError(events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation %s: %w", name, err))
+}
+
+// reportError reports an error on the ingress, fake ingresses have no resource to report to.
+func (i *Ingress) reportError(reason string, err error) {
+	if !i.resource.Faked {
+		events.Error(i.resource.Owner(), reason, err)
+	}
 }
 
 func HandleCfgMapAnnotations(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) {
 	var err error
 	result := rules.List{}
 	logger.Tracef("Processing Ingress annotations in ConfigMap")
-	for _, a := range a.Frontend(nil, &result, h.Maps) {
+	for _, a := range a.Frontend(nil, &result, h.Maps, h.Certificates) {
 		err = a.Process(k, k.ConfigMaps.Main.Annotations)
 		if err != nil {
 			logger.Errorf("ConfigMap: annotation %s: %s", a.GetName(), err)
+			if _, ok := k.ConfigMaps.Main.Annotations[a.GetName()]; ok {
+				events.Error(k.ConfigMaps.Main.Owner(), events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation %s: %w", a.GetName(), err))
+			}
 		}
 	}
 	addRules(result, h, false)
@@ -167,6 +248,10 @@ func addRules(list rules.List, h haproxy.HAProxy, ingressRule bool) []rules.Rule
 			if haproxy.SSLPassthrough {
 				frontends = []string{h.FrontHTTP, h.FrontSSL}
 			}
+		case rules.RES_SET_HEADER:
+			if setHdr, ok := rule.(*rules.SetHdr); ok && setHdr.HTTPSOnly {
+				frontends = []string{h.FrontHTTPS}
+			}
 		}
 		for _, frontend := range frontends {
 			logger.Error(h.AddRule(frontend, rule, ingressRule || rule.GetType() == rules.REQ_REDIRECT))
@@ -183,6 +268,9 @@ func addRules(list rules.List, h haproxy.HAProxy, ingressRule bool) []rules.Rule
 // Update processes a Kubernetes ingress resource and configures HAProxy accordingly
 // by creating corresponding backend, route and HTTP rules.
 func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) {
+	if !i.resource.Faked {
+		events.Processed(i.resource.Owner())
+	}
 	// Default Backend
 	if i.resource.DefaultBackend != nil {
 		svc, err := service.New(k, i.resource.DefaultBackend, h.Certificates, false, i.resource, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
@@ -191,6 +279,7 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 		}
 		if err != nil {
 			logger.Errorf("Ingress '%s/%s': default backend: %s", i.resource.Namespace, i.resource.Name, err)
+			i.reportError(events.REASON_INVALID_RESOURCE, fmt.Errorf("default backend: %w", err))
 		} else {
 			backendName, _ := svc.GetBackendName()
 			logger.Infof("Setting http default backend to '%s'", backendName)
@@ -199,8 +288,14 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 	// Ingress secrets
 	logger.Tracef("Ingress '%s/%s': processing secrets...", i.resource.Namespace, i.resource.Name)
 	secretManager := secret.NewManager(k, h)
+	// secrets of ingresses requiring client certificates or having a TLS policy are listed in the SNI crt-list
+	sniOptions := annotations.String("client-ca", i.resource.Annotations) != ""
+	for _, a := range i.annotations.TLSPolicy(&i.tlsPolicy) {
+		_, ok := i.resource.Annotations[a.GetName()]
+		sniOptions = sniOptions || ok
+	}
 	for _, tls := range i.resource.TLS {
-		if tls.SecretName == "" {
+		if tls.SecretName == "" || sniOptions {
 			continue
 		}
 		sec := secret.Secret{
@@ -220,22 +315,67 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 	enabled, err := annotations.Bool("ssl-passthrough", i.resource.Annotations, k.ConfigMaps.Main.Annotations)
 	if err != nil {
 		logger.Error("Ingress '%s/%s': SSL Passthrough parsing: %s", i.resource.Namespace, i.resource.Name, err)
+		i.reportError(events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation ssl-passthrough: %w", err))
 	} else if enabled {
 		i.sslPassthrough = true
 		haproxy.SSLPassthrough = true
 	}
+	i.pathRegex, err = annotations.Bool("path-regex", i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': path-regex parsing: %s", i.resource.Namespace, i.resource.Name, err)
+		i.reportError(events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation path-regex: %w", err))
+	}
 	i.handleAnnotations(k, h)
+	if sniOptions {
+		i.handleSNISecrets(k, h)
+	}
 	// Ingress rules
 	logger.Tracef("ingress '%s/%s': processing rules...", i.resource.Namespace, i.resource.Name)
 	for _, rule := range i.resource.Rules {
 		for _, path := range rule.Paths {
 			if err := i.handlePath(k, h, rule.Host, path, a); err != nil {
 				logger.Errorf("Ingress '%s/%s': %s", i.resource.Namespace, i.resource.Name, err)
+				reason := events.REASON_INVALID_RESOURCE
+				if errors.Is(err, route.ErrInvalidPathRegex) {
+					reason = events.REASON_INVALID_PATH
+				}
+				i.reportError(reason, err)
 			}
 		}
 	}
 }
 
+// handleSNISecrets lists the TLS certificates of the ingress in the SNI crt-list,
+// with the client certificate options and TLS policy of the hosts they are configured for.
+// If client authentication is required but not configured, certificates are not added and
+// requests to the ingress are denied by the client auth rule.
+func (i *Ingress) handleSNISecrets(k store.K8s, h haproxy.HAProxy) {
+	if annotations.String("client-ca", i.resource.Annotations) != "" && i.clientAuth.CAFile == "" {
+		return
+	}
+	hosts := map[string][]string{}
+	for _, tls := range i.resource.TLS {
+		if tls.SecretName != "" {
+			hosts[tls.SecretName] = append(hosts[tls.SecretName], tls.Host)
+		}
+	}
+	for secretName, secretHosts := range hosts {
+		sec, err := k.GetSecret(i.resource.Namespace, secretName)
+		if err != nil {
+			logger.Warningf("Ingress '%s/%s': %s", i.resource.Namespace, i.resource.Name, err)
+			continue
+		}
+		certPath, err := h.AddSecret(sec, certs.FT_SNI_CERT)
+		if err != nil {
+			logger.Errorf("Ingress '%s/%s': %s", i.resource.Namespace, i.resource.Name, err)
+			i.reportError(events.REASON_INVALID_RESOURCE, err)
+			continue
+		}
+		h.AddReference(certs.FT_SNI_CERT, sec.Namespace, sec.Name, fmt.Sprintf("Ingress %s/%s", i.resource.Namespace, i.resource.Name))
+		h.AddSNIEntry(certPath, certs.SNIOptions{ClientAuth: i.clientAuth, TLSPolicy: i.tlsPolicy, SNIs: secretHosts})
+	}
+}
+
 func (i Ingress) GetAddresses() []string {
 	return i.resource.Addresses
 }
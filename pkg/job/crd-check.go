@@ -19,14 +19,35 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/haproxytech/kubernetes-ingress/crs/definition"
 	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiError "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 )
 
+// CRDRefreshWithLease runs CRDRefresh while holding a dedicated Lease,
+// so that jobs started concurrently do not update CRDs at the same time.
+func CRDRefreshWithLease(log utils.Logger, osArgs utils.OSArgs) error {
+	config, err := k8s.GetRestConfig(osArgs)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	leaseConfig := leader.NewConfig(osArgs)
+	leaseConfig.Name += "-crd-refresh"
+	log.Infof("waiting for lease %s/%s", leaseConfig.Namespace, leaseConfig.Name)
+	return leader.RunOnce(clientset, leaseConfig, func() error {
+		return CRDRefresh(log, osArgs)
+	})
+}
+
 func CRDRefresh(log utils.Logger, osArgs utils.OSArgs) error {
 	log.Info("checking CRDs")
 	config, err := k8s.GetRestConfig(osArgs)
@@ -21,7 +21,9 @@ import (
 	k8stransform "github.com/haproxytech/kubernetes-ingress/pkg/k8s/transform"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewaynetworking "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 )
@@ -45,26 +47,7 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 					// detect services that are in terminating state
 					status = store.DELETED
 				}
-
-				item := &store.Namespace{
-					Name:                     data.GetName(),
-					Endpoints:                make(map[string]map[string]*store.Endpoints),
-					Services:                 make(map[string]*store.Service),
-					Ingresses:                make(map[string]*store.Ingress),
-					Secret:                   make(map[string]*store.Secret),
-					HAProxyRuntime:           make(map[string]map[string]*store.RuntimeBackend),
-					HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*store.RuntimeBackend),
-					CRs: &store.CustomResources{
-						Global:   make(map[string]*models.Global),
-						Defaults: make(map[string]*models.Defaults),
-						Backends: make(map[string]*v3.BackendSpec),
-					},
-					Gateways:        make(map[string]*store.Gateway),
-					TCPRoutes:       make(map[string]*store.TCPRoute),
-					ReferenceGrants: make(map[string]*store.ReferenceGrant),
-					Labels:          utils.CopyMap(data.Labels),
-					Status:          status,
-				}
+				item := convertToNamespace(data, status)
 				logIncomingK8sEvent(logger, item, data.UID, data.ResourceVersion)
 				eventChan <- ToSyncDataEvent(item, item, data.UID, data.ResourceVersion)
 			},
@@ -75,25 +58,7 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 					return
 				}
 				status := store.DELETED
-				item := &store.Namespace{
-					Name:                     data.GetName(),
-					Endpoints:                make(map[string]map[string]*store.Endpoints),
-					Services:                 make(map[string]*store.Service),
-					Ingresses:                make(map[string]*store.Ingress),
-					Secret:                   make(map[string]*store.Secret),
-					HAProxyRuntime:           make(map[string]map[string]*store.RuntimeBackend),
-					HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*store.RuntimeBackend),
-					CRs: &store.CustomResources{
-						Global:   make(map[string]*models.Global),
-						Defaults: make(map[string]*models.Defaults),
-						Backends: make(map[string]*v3.BackendSpec),
-					},
-					Gateways:        make(map[string]*store.Gateway),
-					TCPRoutes:       make(map[string]*store.TCPRoute),
-					ReferenceGrants: make(map[string]*store.ReferenceGrant),
-					Labels:          utils.CopyMap(data.Labels),
-					Status:          status,
-				}
+				item := convertToNamespace(data, status)
 				logIncomingK8sEvent(logger, item, data.UID, data.ResourceVersion)
 				eventChan <- ToSyncDataEvent(item, item, data.UID, data.ResourceVersion)
 			},
@@ -128,6 +93,32 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 	return informer
 }
 
+func convertToNamespace(data *corev1.Namespace, status store.Status) *store.Namespace {
+	return &store.Namespace{
+		Name:                     data.GetName(),
+		Endpoints:                make(map[string]map[string]*store.Endpoints),
+		Services:                 make(map[string]*store.Service),
+		Ingresses:                make(map[string]*store.Ingress),
+		Secret:                   make(map[string]*store.Secret),
+		HAProxyRuntime:           make(map[string]map[string]*store.RuntimeBackend),
+		HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*store.RuntimeBackend),
+		CRs: &store.CustomResources{
+			Global:   make(map[string]*models.Global),
+			Defaults: make(map[string]*models.Defaults),
+			Backends: make(map[string]*v3.BackendSpec),
+		},
+		Gateways:           make(map[string]*store.Gateway),
+		TCPRoutes:          make(map[string]*store.TCPRoute),
+		HTTPRoutes:         make(map[string]*store.HTTPRoute),
+		ReferenceGrants:    make(map[string]*store.ReferenceGrant),
+		BackendTLSPolicies: make(map[string]*store.BackendTLSPolicy),
+		CABundles:          make(map[string]*store.ConfigMap),
+		JWKS:               make(map[string]*store.ConfigMap),
+		Labels:             utils.CopyMap(data.Labels),
+		Status:             status,
+	}
+}
+
 func (k k8s) getServiceInformer(eventChan chan k8ssync.SyncDataEvent, factory informers.SharedInformerFactory) cache.SharedIndexInformer { //nolint:ireturn
 	informer := factory.Core().V1().Services().Informer()
 	errW := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
@@ -150,23 +141,7 @@ func (k k8s) getServiceInformer(eventChan chan k8ssync.SyncDataEvent, factory in
 				// detect services that are in terminating state
 				status = store.DELETED
 			}
-			item := &store.Service{
-				Namespace:   data.GetNamespace(),
-				Name:        data.GetName(),
-				Annotations: store.CopyAnnotations(data.ObjectMeta.Annotations),
-				Ports:       []store.ServicePort{},
-				Status:      status,
-			}
-			if data.Spec.Type == corev1.ServiceTypeExternalName {
-				item.DNS = data.Spec.ExternalName
-			}
-			for _, sp := range data.Spec.Ports {
-				item.Ports = append(item.Ports, store.ServicePort{
-					Name:     sp.Name,
-					Protocol: string(sp.Protocol),
-					Port:     int64(sp.Port),
-				})
-			}
+			item := convertToService(data, status)
 			logIncomingK8sEvent(logger, item, data.UID, data.ResourceVersion)
 			eventChan <- ToSyncDataEvent(item, item, data.UID, data.ResourceVersion)
 			if k.publishSvc != nil && k.publishSvc.Namespace == item.Namespace && k.publishSvc.Name == item.Name {
@@ -235,24 +210,7 @@ func (k k8s) getServiceInformer(eventChan chan k8ssync.SyncDataEvent, factory in
 			}
 
 			status := store.MODIFIED
-
-			item2 := &store.Service{
-				Namespace:   data2.GetNamespace(),
-				Name:        data2.GetName(),
-				Annotations: store.CopyAnnotations(data2.ObjectMeta.Annotations),
-				Ports:       []store.ServicePort{},
-				Status:      status,
-			}
-			if data2.Spec.Type == corev1.ServiceTypeExternalName {
-				item2.DNS = data2.Spec.ExternalName
-			}
-			for _, sp := range data2.Spec.Ports {
-				item2.Ports = append(item2.Ports, store.ServicePort{
-					Name:     sp.Name,
-					Protocol: string(sp.Protocol),
-					Port:     int64(sp.Port),
-				})
-			}
+			item2 := convertToService(data2, status)
 
 			logIncomingK8sEvent(logger, item2, data2.UID, data2.ResourceVersion)
 			eventChan <- ToSyncDataEvent(item2, item2, data2.UID, data2.ResourceVersion)
@@ -277,6 +235,27 @@ func (k k8s) getServiceInformer(eventChan chan k8ssync.SyncDataEvent, factory in
 	return informer
 }
 
+func convertToService(data *corev1.Service, status store.Status) *store.Service {
+	item := &store.Service{
+		Namespace:   data.GetNamespace(),
+		Name:        data.GetName(),
+		Annotations: store.CopyAnnotations(data.ObjectMeta.Annotations),
+		Ports:       []store.ServicePort{},
+		Status:      status,
+	}
+	if data.Spec.Type == corev1.ServiceTypeExternalName {
+		item.DNS = data.Spec.ExternalName
+	}
+	for _, sp := range data.Spec.Ports {
+		item.Ports = append(item.Ports, store.ServicePort{
+			Name:     sp.Name,
+			Protocol: string(sp.Protocol),
+			Port:     int64(sp.Port),
+		})
+	}
+	return item
+}
+
 func (k k8s) getSecretInformer(eventChan chan k8ssync.SyncDataEvent, factory informers.SharedInformerFactory) cache.SharedIndexInformer { //nolint:ireturn
 	informer := factory.Core().V1().Secrets().Informer()
 	errW := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
@@ -430,6 +409,14 @@ func (k k8s) getConfigMapInformer(eventChan chan k8ssync.SyncDataEvent, factory
 	return informer
 }
 
+// getCABundleInformer watches the configmaps of the namespace which can hold the CA bundle of a BackendTLSPolicy.
+// Only their ca.crt entry is kept, the store ignoring the ones no policy refers to.
+func (k k8s) getCABundleInformer(eventChan chan k8ssync.SyncDataEvent, factory informers.SharedInformerFactory) cache.SharedIndexInformer { //nolint:ireturn
+	informer := k.getConfigMapInformer(eventChan, factory)
+	logger.Error(informer.SetTransform(k8stransform.TransformCABundle))
+	return informer
+}
+
 func (k k8s) getIngressInformers(eventChan chan k8ssync.SyncDataEvent, factory informers.SharedInformerFactory, osArgs utils.OSArgs) (ii, ici cache.SharedIndexInformer) { //nolint:ireturn
 	apiGroup := "networking.k8s.io/v1"
 
@@ -872,12 +859,14 @@ type InformerGetter interface {
 }
 
 type GatewayRelatedType interface {
-	*gatewayv1beta1.GatewayClass | *gatewayv1beta1.Gateway | *gatewayv1alpha2.TCPRoute | *gatewayv1alpha2.ReferenceGrant
+	*gatewayv1.GatewayClass | *gatewayv1.Gateway | *gatewayv1beta1.GatewayClass | *gatewayv1beta1.Gateway |
+		*gatewayv1alpha2.TCPRoute | *gatewayv1beta1.ReferenceGrant | *gatewayv1alpha2.ReferenceGrant |
+		*gatewayv1.HTTPRoute | *gatewayv1beta1.HTTPRoute | *gatewayv1alpha3.BackendTLSPolicy
 }
 
 type GatewayInformerFunc[GWType GatewayRelatedType] func(gwObj GWType, eventChan chan k8ssync.SyncDataEvent, status store.Status)
 
-func manageGatewayClass(gatewayclass *gatewayv1beta1.GatewayClass, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+func manageGatewayClass(gatewayclass *gatewayv1.GatewayClass, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
 	logger.Infof("gwapi: gatewayclass: informers: got '%s'", gatewayclass.Name)
 	item := store.GatewayClass{
 		Name:           gatewayclass.Name,
@@ -890,7 +879,7 @@ func manageGatewayClass(gatewayclass *gatewayv1beta1.GatewayClass, eventChan cha
 	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.GATEWAYCLASS, Data: &item}
 }
 
-func manageGateway(gateway *gatewayv1beta1.Gateway, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+func manageGateway(gateway *gatewayv1.Gateway, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
 	logger.Infof("gwapi: gateway: informers: got '%s/%s'", gateway.Namespace, gateway.Name)
 	listeners := make([]store.Listener, len(gateway.Spec.Listeners))
 	for i, listener := range gateway.Spec.Listeners {
@@ -924,6 +913,26 @@ func manageGateway(gateway *gatewayv1beta1.Gateway, eventChan chan k8ssync.SyncD
 			}
 			listeners[i].AllowedRoutes.Kinds = rgks
 		}
+		if listener.TLS != nil {
+			listeners[i].TLS = &store.ListenerTLS{
+				Mode:            (*string)(listener.TLS.Mode),
+				CertificateRefs: make([]store.SecretObjectRef, len(listener.TLS.CertificateRefs)),
+			}
+			if len(listener.TLS.Options) != 0 {
+				listeners[i].TLS.Options = make(map[string]string, len(listener.TLS.Options))
+				for key, value := range listener.TLS.Options {
+					listeners[i].TLS.Options[string(key)] = string(value)
+				}
+			}
+			for j, certRef := range listener.TLS.CertificateRefs {
+				listeners[i].TLS.CertificateRefs[j] = store.SecretObjectRef{
+					Namespace: (*string)(certRef.Namespace),
+					Group:     string(utils.PointerDefaultValueIfNil(certRef.Group)),
+					Kind:      string(utils.PointerDefaultValueIfNil(certRef.Kind)),
+					Name:      string(certRef.Name),
+				}
+			}
+		}
 	}
 	item := store.Gateway{
 		Name:             gateway.Name,
@@ -942,23 +951,100 @@ func manageTCPRoute(tcproute *gatewayv1alpha2.TCPRoute, eventChan chan k8ssync.S
 	backendRefs := []store.BackendRef{}
 	for _, rule := range tcproute.Spec.Rules {
 		for _, backendref := range rule.BackendRefs {
-			backendRefs = append(backendRefs, store.BackendRef{
-				Name: string(backendref.Name),
-				Namespace: func() *string {
-					if backendref.Namespace != nil {
-						return (*string)(backendref.Namespace)
-					}
-					return nil
-				}(),
-				Port:   (*int32)(backendref.Port),
-				Group:  (*string)(backendref.Group),
-				Kind:   (*string)(backendref.Kind),
-				Weight: backendref.Weight,
-			})
+			backendRefs = append(backendRefs, convertBackendRef(backendref))
+		}
+	}
+
+	item := store.TCPRoute{
+		Name:         tcproute.Name,
+		Namespace:    tcproute.Namespace,
+		BackendRefs:  backendRefs,
+		ParentRefs:   convertParentRefs("tcproute", tcproute.Namespace, tcproute.Name, tcproute.Spec.ParentRefs),
+		CreationTime: tcproute.CreationTimestamp.Time,
+		Generation:   tcproute.Generation,
+		Status:       status,
+	}
+	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.TCPROUTE, item.Status, item.Name)
+	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.TCPROUTE, Namespace: item.Namespace, Data: &item}
+}
+
+func manageHTTPRoute(httproute *gatewayv1.HTTPRoute, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+	logger.Debugf("gwapi: httproute: informers: got '%s/%s'", httproute.Namespace, httproute.Name)
+	hostnames := make([]string, len(httproute.Spec.Hostnames))
+	for i, hostname := range httproute.Spec.Hostnames {
+		hostnames[i] = string(hostname)
+	}
+	rules := make([]store.HTTPRouteRule, len(httproute.Spec.Rules))
+	for i, rule := range httproute.Spec.Rules {
+		rules[i] = store.HTTPRouteRule{
+			Matches:     []store.HTTPRouteMatch{},
+			BackendRefs: []store.BackendRef{},
+		}
+		if rule.Timeouts != nil {
+			rules[i].Timeouts = &store.HTTPRouteTimeouts{
+				Request:        (*string)(rule.Timeouts.Request),
+				BackendRequest: (*string)(rule.Timeouts.BackendRequest),
+			}
+		}
+		if len(rule.Filters) != 0 {
+			rules[i].Unsupported = append(rules[i].Unsupported, "filters")
+		}
+		for _, match := range rule.Matches {
+			if len(match.Headers) != 0 || len(match.QueryParams) != 0 || match.Method != nil {
+				rules[i].Unsupported = append(rules[i].Unsupported, "header, query parameter or method matches")
+			}
+			routeMatch := store.HTTPRouteMatch{
+				PathType:  string(gatewayv1.PathMatchPathPrefix),
+				PathValue: "/",
+			}
+			if match.Path != nil {
+				if match.Path.Type != nil {
+					routeMatch.PathType = string(*match.Path.Type)
+				}
+				if match.Path.Value != nil {
+					routeMatch.PathValue = *match.Path.Value
+				}
+			}
+			rules[i].Matches = append(rules[i].Matches, routeMatch)
+		}
+		for _, backendref := range rule.BackendRefs {
+			if len(backendref.Filters) != 0 {
+				rules[i].Unsupported = append(rules[i].Unsupported, "backendRef filters")
+			}
+			rules[i].BackendRefs = append(rules[i].BackendRefs, convertBackendRef(backendref.BackendRef))
 		}
 	}
-	parentRefs := make([]store.ParentRef, 0, len(tcproute.Spec.ParentRefs))
-	for _, parentRefSpec := range tcproute.Spec.ParentRefs {
+
+	item := store.HTTPRoute{
+		Name:         httproute.Name,
+		Namespace:    httproute.Namespace,
+		Hostnames:    hostnames,
+		Rules:        rules,
+		ParentRefs:   convertParentRefs("httproute", httproute.Namespace, httproute.Name, httproute.Spec.ParentRefs),
+		CreationTime: httproute.CreationTimestamp.Time,
+		Generation:   httproute.Generation,
+		Status:       status,
+	}
+	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.HTTPROUTE, item.Status, item.Name)
+	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.HTTPROUTE, Namespace: item.Namespace, Data: &item}
+}
+
+// convertBackendRef converts the backendRef of a route into its store counterpart.
+func convertBackendRef(backendref gatewayv1.BackendRef) store.BackendRef {
+	return store.BackendRef{
+		Name:      string(backendref.Name),
+		Namespace: (*string)(backendref.Namespace),
+		Port:      (*int32)(backendref.Port),
+		Group:     (*string)(backendref.Group),
+		Kind:      (*string)(backendref.Kind),
+		Weight:    backendref.Weight,
+	}
+}
+
+// convertParentRefs converts the parentRefs of a route into their store counterparts, only gateways are kept.
+func convertParentRefs(kind, namespace, name string, parentRefSpecs []gatewayv1.ParentReference) []store.ParentRef {
+	parentRefs := make([]store.ParentRef, 0, len(parentRefSpecs))
+	for _, parentRefSpec := range parentRefSpecs {
 		// Ensure ParentRefs is only about Gateway resources.
 		parentRefGroup := "gateway.networking.k8s.io"
 		if parentRefSpec.Group != nil {
@@ -969,12 +1055,12 @@ func manageTCPRoute(tcproute *gatewayv1alpha2.TCPRoute, eventChan chan k8ssync.S
 			parentRefKind = *(*string)(parentRefSpec.Kind)
 		}
 		if parentRefGroup != "gateway.networking.k8s.io" || parentRefKind != "Gateway" {
-			logger.Errorf("invalid parent reference in tcproute '%s/%s': parent reference must of kind 'Gateway' from group 'gateway.networking.k8s.io'", tcproute.Namespace, tcproute.Name)
+			logger.Errorf("invalid parent reference in %s '%s/%s': parent reference must of kind 'Gateway' from group 'gateway.networking.k8s.io'", kind, namespace, name)
 			continue
 		}
 		parentRefNs := (*string)(parentRefSpec.Namespace)
 		if parentRefNs == nil {
-			parentRefNs = &tcproute.Namespace
+			parentRefNs = &namespace
 		}
 		parentRef := store.ParentRef{
 			Namespace:   parentRefNs,
@@ -986,29 +1072,36 @@ func manageTCPRoute(tcproute *gatewayv1alpha2.TCPRoute, eventChan chan k8ssync.S
 		}
 		parentRefs = append(parentRefs, parentRef)
 	}
-
-	item := store.TCPRoute{
-		Name:         tcproute.Name,
-		Namespace:    tcproute.Namespace,
-		BackendRefs:  backendRefs,
-		ParentRefs:   parentRefs,
-		CreationTime: tcproute.CreationTimestamp.Time,
-		Generation:   tcproute.Generation,
-		Status:       status,
-	}
-	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.TCPROUTE, item.Status, item.Name)
-	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.TCPROUTE, Namespace: item.Namespace, Data: &item}
+	return parentRefs
 }
 
-func (k k8s) getGatewayClassesInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
-	informer := factory.Gateway().V1beta1().GatewayClasses()
-	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.GatewayClass](manageGatewayClass))
+func (k k8s) getGatewayClassesInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory, version string) cache.SharedIndexInformer {
+	if version == gatewayv1beta1.GroupVersion.Version {
+		logger.Debug("gwapi: using gateway.networking.k8s.io/v1beta1 gatewayclasses")
+		informer := factory.Gateway().V1beta1().GatewayClasses()
+		PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.GatewayClass](
+			func(gatewayclass *gatewayv1beta1.GatewayClass, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+				manageGatewayClass((*gatewayv1.GatewayClass)(gatewayclass), eventChan, status)
+			}))
+		return informer.Informer()
+	}
+	informer := factory.Gateway().V1().GatewayClasses()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1.GatewayClass](manageGatewayClass))
 	return informer.Informer()
 }
 
-func (k k8s) getGatewayInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
-	informer := factory.Gateway().V1beta1().Gateways()
-	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.Gateway](manageGateway))
+func (k k8s) getGatewayInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory, version string) cache.SharedIndexInformer {
+	if version == gatewayv1beta1.GroupVersion.Version {
+		logger.Debug("gwapi: using gateway.networking.k8s.io/v1beta1 gateways")
+		informer := factory.Gateway().V1beta1().Gateways()
+		PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.Gateway](
+			func(gateway *gatewayv1beta1.Gateway, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+				manageGateway((*gatewayv1.Gateway)(gateway), eventChan, status)
+			}))
+		return informer.Informer()
+	}
+	informer := factory.Gateway().V1().Gateways()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1.Gateway](manageGateway))
 	return informer.Informer()
 }
 
@@ -1018,6 +1111,21 @@ func (k k8s) getTCPRouteInformer(eventChan chan k8ssync.SyncDataEvent, factory g
 	return informer.Informer()
 }
 
+func (k k8s) getHTTPRouteInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory, version string) cache.SharedIndexInformer {
+	if version == gatewayv1beta1.GroupVersion.Version {
+		logger.Debug("gwapi: using gateway.networking.k8s.io/v1beta1 httproutes")
+		informer := factory.Gateway().V1beta1().HTTPRoutes()
+		PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.HTTPRoute](
+			func(httproute *gatewayv1beta1.HTTPRoute, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+				manageHTTPRoute((*gatewayv1.HTTPRoute)(httproute), eventChan, status)
+			}))
+		return informer.Informer()
+	}
+	informer := factory.Gateway().V1().HTTPRoutes()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1.HTTPRoute](manageHTTPRoute))
+	return informer.Informer()
+}
+
 func PopulateInformer[IT InformerGetter, GWType GatewayRelatedType, GWF GatewayInformerFunc[GWType]](eventChan chan k8ssync.SyncDataEvent, informer IT, handler GWF) cache.SharedIndexInformer {
 	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -1037,13 +1145,22 @@ func PopulateInformer[IT InformerGetter, GWType GatewayRelatedType, GWF GatewayI
 	return informer.Informer()
 }
 
-func (k k8s) getReferenceGrantInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
-	informer := factory.Gateway().V1alpha2().ReferenceGrants()
-	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1alpha2.ReferenceGrant](manageReferenceGrant))
+func (k k8s) getReferenceGrantInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory, version string) cache.SharedIndexInformer {
+	if version == gatewayv1alpha2.GroupVersion.Version {
+		logger.Debug("gwapi: using gateway.networking.k8s.io/v1alpha2 referencegrants")
+		informer := factory.Gateway().V1alpha2().ReferenceGrants()
+		PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1alpha2.ReferenceGrant](
+			func(referenceGrant *gatewayv1alpha2.ReferenceGrant, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+				manageReferenceGrant((*gatewayv1beta1.ReferenceGrant)(referenceGrant), eventChan, status)
+			}))
+		return informer.Informer()
+	}
+	informer := factory.Gateway().V1beta1().ReferenceGrants()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.ReferenceGrant](manageReferenceGrant))
 	return informer.Informer()
 }
 
-func manageReferenceGrant(referenceGrant *gatewayv1alpha2.ReferenceGrant, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+func manageReferenceGrant(referenceGrant *gatewayv1beta1.ReferenceGrant, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
 	logger.Debugf("gwapi: referencegrant: informers: got '%s/%s'", referenceGrant.Namespace, referenceGrant.Name)
 	item := store.ReferenceGrant{
 		Name:       referenceGrant.Name,
@@ -1073,3 +1190,75 @@ func manageReferenceGrant(referenceGrant *gatewayv1alpha2.ReferenceGrant, eventC
 	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.REFERENCEGRANT, item.Status, item.Name)
 	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.REFERENCEGRANT, Namespace: item.Namespace, Data: &item}
 }
+
+// getBackendTLSPolicyInformer watches backendtlspolicies, caBundles being the cache of the informer watching CA bundle configmaps.
+func (k k8s) getBackendTLSPolicyInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory, caBundles cache.Store) cache.SharedIndexInformer {
+	informer := factory.Gateway().V1alpha3().BackendTLSPolicies()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1alpha3.BackendTLSPolicy](
+		func(policy *gatewayv1alpha3.BackendTLSPolicy, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+			manageBackendTLSPolicy(policy, eventChan, status)
+			if status != store.DELETED {
+				resendCABundles(policy, caBundles, eventChan)
+			}
+		}))
+	return informer.Informer()
+}
+
+// resendCABundles sends again the configmaps referenced by the policy.
+// The store only keeps the CA bundles referenced by a policy and they may have been received before the policy.
+func resendCABundles(policy *gatewayv1alpha3.BackendTLSPolicy, caBundles cache.Store, eventChan chan k8ssync.SyncDataEvent) {
+	for _, caRef := range policy.Spec.Validation.CACertificateRefs {
+		if caRef.Group != "" || caRef.Kind != "ConfigMap" {
+			continue
+		}
+		obj, exists, err := caBundles.GetByKey(policy.Namespace + "/" + string(caRef.Name))
+		if err != nil || !exists {
+			continue
+		}
+		data, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			continue
+		}
+		item := &store.ConfigMap{
+			Namespace:   data.GetNamespace(),
+			Name:        data.GetName(),
+			Annotations: store.CopyAnnotations(data.Data),
+			Status:      store.MODIFIED,
+		}
+		eventChan <- ToSyncDataEvent(item, item, data.UID, data.ResourceVersion)
+	}
+}
+
+func manageBackendTLSPolicy(policy *gatewayv1alpha3.BackendTLSPolicy, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+	logger.Debugf("gwapi: backendtlspolicy: informers: got '%s/%s'", policy.Namespace, policy.Name)
+	item := store.BackendTLSPolicy{
+		Name:                    policy.Name,
+		Namespace:               policy.Namespace,
+		Hostname:                string(policy.Spec.Validation.Hostname),
+		WellKnownCACertificates: (*string)(policy.Spec.Validation.WellKnownCACertificates),
+		Generation:              policy.Generation,
+		Status:                  status,
+	}
+	item.TargetRefs = make([]store.PolicyTargetRef, len(policy.Spec.TargetRefs))
+	item.CACertificateRefs = make([]store.LocalObjectRef, len(policy.Spec.Validation.CACertificateRefs))
+
+	for i, targetRef := range policy.Spec.TargetRefs {
+		item.TargetRefs[i] = store.PolicyTargetRef{
+			Group:       string(targetRef.Group),
+			Kind:        string(targetRef.Kind),
+			Name:        string(targetRef.Name),
+			SectionName: (*string)(targetRef.SectionName),
+		}
+	}
+
+	for i, caRef := range policy.Spec.Validation.CACertificateRefs {
+		item.CACertificateRefs[i] = store.LocalObjectRef{
+			Group: string(caRef.Group),
+			Kind:  string(caRef.Kind),
+			Name:  string(caRef.Name),
+		}
+	}
+
+	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.BACKENDTLSPOLICY, item.Status, item.Name)
+	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.BACKENDTLSPOLICY, Namespace: item.Namespace, Data: &item}
+}
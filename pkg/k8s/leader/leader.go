@@ -0,0 +1,200 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+var logger = utils.GetK8sLogger()
+
+// Elector tells if the controller replica is the one allowed to write to the Kubernetes API.
+// Every replica configures its own HAProxy but only the leader updates statuses,
+// so that multiple replicas do not fight over the same resources.
+type Elector interface {
+	IsLeader() bool
+	Run(stop chan struct{})
+}
+
+// Config holds the Lease settings used for leader election.
+type Config struct {
+	Namespace     string
+	Name          string
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// NewConfig builds the leader election configuration from controller arguments.
+// The Lease lives in the controller namespace and is identified by the pod name.
+func NewConfig(osArgs utils.OSArgs) Config {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = osArgs.ConfigMap.Namespace
+	}
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	return Config{
+		Namespace:     namespace,
+		Name:          osArgs.LeaderElectionID,
+		Identity:      identity,
+		LeaseDuration: osArgs.LeaderElectionLeaseDuration,
+		RenewDeadline: osArgs.LeaderElectionRenewDeadline,
+		RetryPeriod:   osArgs.LeaderElectionRetryPeriod,
+	}
+}
+
+// New returns the Elector to use according controller arguments.
+// If leader election is disabled the replica always considers itself as leader.
+func New(client kubernetes.Interface, osArgs utils.OSArgs) Elector { //nolint:ireturn
+	if !osArgs.LeaderElection {
+		return alwaysLeader{}
+	}
+	if client == nil {
+		logger.Error("leader election: no kubernetes client available, every replica will update statuses")
+		return alwaysLeader{}
+	}
+	elector, err := NewLeaseElector(client, NewConfig(osArgs))
+	if err != nil {
+		logger.Errorf("leader election: %s, every replica will update statuses", err)
+		return alwaysLeader{}
+	}
+	return elector
+}
+
+type alwaysLeader struct{}
+
+func (alwaysLeader) IsLeader() bool { return true }
+
+func (alwaysLeader) Run(stop chan struct{}) {}
+
+// LeaseElector is an Elector relying on a coordination.k8s.io Lease.
+type LeaseElector struct {
+	leaderElector *leaderelection.LeaderElector
+	leader        atomic.Bool
+}
+
+// NewLeaseElector creates a Lease based Elector, election starts with Run.
+func NewLeaseElector(client kubernetes.Interface, config Config) (*LeaseElector, error) {
+	if config.Namespace == "" || config.Name == "" {
+		return nil, errors.New("lease namespace and name are required")
+	}
+	e := &LeaseElector{}
+	leaderElector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            newLock(client, config),
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            config.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logger.Infof("leader election: '%s' acquired lease %s/%s", config.Identity, config.Namespace, config.Name)
+				e.leader.Store(true)
+			},
+			OnStoppedLeading: func() {
+				logger.Infof("leader election: '%s' lost lease %s/%s", config.Identity, config.Namespace, config.Name)
+				e.leader.Store(false)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != config.Identity {
+					logger.Infof("leader election: '%s' is the leader", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.leaderElector = leaderElector
+	return e, nil
+}
+
+// IsLeader returns true if the replica currently holds the Lease.
+func (e *LeaseElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run takes part in the election until stop is closed.
+// When the Lease is lost the replica goes back to candidate, the Lease is released on stop.
+func (e *LeaseElector) Run(stop chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+	for ctx.Err() == nil {
+		e.leaderElector.Run(ctx)
+	}
+}
+
+// RunOnce waits for the Lease described by config, executes job while holding it and then releases it.
+// It allows one-shot tasks started by several replicas, such as the CRD refresh job, to be executed one at a time.
+func RunOnce(client kubernetes.Interface, config Config, job func() error) error {
+	if config.Namespace == "" || config.Name == "" {
+		return errors.New("lease namespace and name are required")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var jobErr error
+	leaderElector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            newLock(client, config),
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            config.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logger.Infof("leader election: '%s' acquired lease %s/%s", config.Identity, config.Namespace, config.Name)
+				jobErr = job()
+				cancel()
+			},
+			OnStoppedLeading: func() {},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	leaderElector.Run(ctx)
+	return jobErr
+}
+
+func newLock(client kubernetes.Interface, config Config) *resourcelock.LeaseLock {
+	return &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: config.Namespace,
+			Name:      config.Name,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: config.Identity,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testConfig(identity string) Config {
+	return Config{
+		Namespace:     "haproxy-controller",
+		Name:          "haproxy-kubernetes-ingress-leader",
+		Identity:      identity,
+		LeaseDuration: time.Second,
+		RenewDeadline: 500 * time.Millisecond,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+}
+
+func TestDisabledIsAlwaysLeader(t *testing.T) {
+	elector := New(fake.NewSimpleClientset(), utils.OSArgs{})
+	assert.True(t, elector.IsLeader())
+}
+
+func TestSingleLeaderAndTakeOver(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	first, err := NewLeaseElector(client, testConfig("first"))
+	require.NoError(t, err)
+	second, err := NewLeaseElector(client, testConfig("second"))
+	require.NoError(t, err)
+
+	stopFirst := make(chan struct{})
+	stopSecond := make(chan struct{})
+	defer close(stopSecond)
+	go first.Run(stopFirst)
+	assert.Eventually(t, first.IsLeader, 3*time.Second, 50*time.Millisecond)
+
+	go second.Run(stopSecond)
+	assert.Never(t, second.IsLeader, time.Second, 50*time.Millisecond)
+	assert.True(t, first.IsLeader())
+
+	// the Lease is released on stop, second replica takes over
+	close(stopFirst)
+	assert.Eventually(t, second.IsLeader, 3*time.Second, 50*time.Millisecond)
+	assert.Eventually(t, func() bool { return !first.IsLeader() }, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestRunOnce(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	executed := false
+	err := RunOnce(client, testConfig("job"), func() error {
+		executed = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, executed)
+}
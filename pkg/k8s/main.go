@@ -42,6 +42,10 @@ import (
 	errGw "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	scheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gatewaynetworking "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
@@ -53,16 +57,30 @@ var logger = utils.GetK8sLogger()
 const (
 	CRSGroupVersionV1   = "ingress.v1.haproxy.org/v1"
 	CRSGroupVersionV3   = "ingress.v3.haproxy.org/v3"
-	GATEWAY_API_VERSION = "v0.5.1" //nolint:golint,stylecheck
+	GATEWAY_API_VERSION = "v1.2.1" //nolint:golint,stylecheck
 )
 
 var ErrIgnored = errors.New("ignored resource")
 
+// GatewayAPIVersions holds the Gateway API versions served by the cluster
+// which are used to watch resources and update their statuses.
+// An empty Gateway version means Gateway API is not available.
+type GatewayAPIVersions struct {
+	Gateway          string // GatewayClass and Gateway
+	ReferenceGrant   string
+	HTTPRoute        string // optional, empty if not installed
+	BackendTLSPolicy string // optional, empty if not installed
+}
+
+func (v GatewayAPIVersions) Installed() bool {
+	return v.Gateway != ""
+}
+
 type K8s interface {
 	GetRestClientset() client.Client
 	GetClientset() *k8sclientset.Clientset
-	MonitorChanges(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, osArgs utils.OSArgs, gatewayAPIInstalled bool)
-	IsGatewayAPIInstalled(gatewayControllerName string) bool
+	MonitorChanges(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, osArgs utils.OSArgs, gatewayAPIVersions GatewayAPIVersions)
+	GetGatewayAPIVersions(gatewayControllerName string) GatewayAPIVersions
 }
 
 // A Custom Resource interface
@@ -91,8 +109,8 @@ type k8s struct {
 	crClientV3             *crclientsetv3.Clientset
 	apiExtensionsClient    *crdclientset.Clientset
 	publishSvc             *utils.NamespaceValue
-	gatewayClient          *gatewayclientset.Clientset
-	crdClient              *crdclientset.Clientset
+	gatewayClient          gatewayclientset.Interface
+	crdClient              crdclientset.Interface
 	podPrefix              string
 	podNamespace           string
 	whiteListedNS          []string
@@ -113,9 +131,11 @@ func New(osArgs utils.OSArgs, whitelist map[string]struct{}, publishSvc *utils.N
 		logger.Printf("Running on Kubernetes version: %s %s", k8sVersion.String(), k8sVersion.Platform)
 	}
 
-	gatewayClient, err := gatewayclientset.NewForConfig(restconfig)
-	if err != nil {
+	var gatewayClient gatewayclientset.Interface
+	if gatewayClientset, errGw := gatewayclientset.NewForConfig(restconfig); errGw != nil {
 		logger.Print("Gateway API not present")
+	} else {
+		gatewayClient = gatewayClientset
 	}
 	gatewayRestClient, err := client.New(restconfig, client.Options{
 		Scheme: scheme.Scheme,
@@ -124,9 +144,11 @@ func New(osArgs utils.OSArgs, whitelist map[string]struct{}, publishSvc *utils.N
 		logger.Print("Gateway API not present")
 	}
 
-	crdClient, err := crdclientset.NewForConfig(restconfig)
-	if err != nil {
+	var crdClient crdclientset.Interface
+	if crdClientset, errCrd := crdclientset.NewForConfig(restconfig); errCrd != nil {
 		logger.Error("CRD API client not present")
+	} else {
+		crdClient = crdClientset
 	}
 
 	prefix, _ := utils.GetPodPrefix(os.Getenv("POD_NAME"))
@@ -171,14 +193,14 @@ func (k k8s) GetClientset() *k8sclientset.Clientset {
 	return k.builtInClient
 }
 
-func (k k8s) MonitorChanges(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, osArgs utils.OSArgs, gatewayAPIInstalled bool) {
+func (k k8s) MonitorChanges(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, osArgs utils.OSArgs, gatewayAPIVersions GatewayAPIVersions) {
 	informersSynced := &[]cache.InformerSynced{}
 	k.runPodInformer(eventChan, stop, informersSynced)
 	for _, namespace := range k.whiteListedNS {
 		k.runInformers(eventChan, stop, namespace, informersSynced, osArgs)
 		k.runCRInformers(eventChan, stop, namespace, informersSynced, k.crsV1, k.crsV3, osArgs)
-		if gatewayAPIInstalled {
-			k.runInformersGwAPI(eventChan, stop, namespace, informersSynced)
+		if gatewayAPIVersions.Installed() {
+			k.runInformersGwAPI(eventChan, stop, namespace, informersSynced, gatewayAPIVersions)
 		}
 	}
 	// check if we need to also watch CRS creation (in case not all alpha2 definitions are already installed)
@@ -316,14 +338,14 @@ func (k k8s) runInformers(eventChan chan k8ssync.SyncDataEvent, stop chan struct
 	}
 }
 
-func (k k8s) runInformersGwAPI(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, namespace string, informersSynced *[]cache.InformerSynced) {
+func (k k8s) runInformersGwAPI(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, namespace string, informersSynced *[]cache.InformerSynced, versions GatewayAPIVersions) {
 	factory := gatewaynetworking.NewSharedInformerFactoryWithOptions(k.gatewayClient, k.cacheResyncPeriod, gatewaynetworking.WithNamespace(namespace))
-	gwclassInf := k.getGatewayClassesInformer(eventChan, factory)
+	gwclassInf := k.getGatewayClassesInformer(eventChan, factory, versions.Gateway)
 	if gwclassInf != nil {
 		go gwclassInf.Run(stop)
 		*informersSynced = append(*informersSynced, gwclassInf.HasSynced)
 	}
-	gwInf := k.getGatewayInformer(eventChan, factory)
+	gwInf := k.getGatewayInformer(eventChan, factory, versions.Gateway)
 	if gwInf != nil {
 		go gwInf.Run(stop)
 		*informersSynced = append(*informersSynced, gwInf.HasSynced)
@@ -333,11 +355,26 @@ func (k k8s) runInformersGwAPI(eventChan chan k8ssync.SyncDataEvent, stop chan s
 		go tcprouteInf.Run(stop)
 		*informersSynced = append(*informersSynced, tcprouteInf.HasSynced)
 	}
-	referenceGrantInf := k.getReferenceGrantInformer(eventChan, factory)
+	referenceGrantInf := k.getReferenceGrantInformer(eventChan, factory, versions.ReferenceGrant)
 	if referenceGrantInf != nil {
 		go referenceGrantInf.Run(stop)
 		*informersSynced = append(*informersSynced, referenceGrantInf.HasSynced)
 	}
+	if versions.HTTPRoute != "" {
+		httprouteInf := k.getHTTPRouteInformer(eventChan, factory, versions.HTTPRoute)
+		go httprouteInf.Run(stop)
+		*informersSynced = append(*informersSynced, httprouteInf.HasSynced)
+	}
+	if versions.BackendTLSPolicy != "" {
+		// configmaps are watched for the CA bundles policies can refer to.
+		coreFactory := k8sinformers.NewSharedInformerFactoryWithOptions(k.builtInClient, k.cacheResyncPeriod, k8sinformers.WithNamespace(namespace))
+		caBundleInf := k.getCABundleInformer(eventChan, coreFactory)
+		go caBundleInf.Run(stop)
+		*informersSynced = append(*informersSynced, caBundleInf.HasSynced)
+		backendTLSPolicyInf := k.getBackendTLSPolicyInformer(eventChan, factory, caBundleInf.GetStore())
+		go backendTLSPolicyInf.Run(stop)
+		*informersSynced = append(*informersSynced, backendTLSPolicyInf.HasSynced)
+	}
 }
 
 func (k k8s) runPodInformer(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, informersSynced *[]cache.InformerSynced) {
@@ -405,14 +442,18 @@ func getWhitelistedNS(whitelist map[string]struct{}, cfgMapNS string) []string {
 	return namespaces
 }
 
-func (k k8s) IsGatewayAPIInstalled(gatewayControllerName string) (installed bool) {
-	installed = true
+// GetGatewayAPIVersions checks that Gateway API CRDs are installed and returns the versions to use for each resource.
+// v1 is preferred for GatewayClass and Gateway and v1beta1 for ReferenceGrant, older versions being used as fallback.
+func (k k8s) GetGatewayAPIVersions(gatewayControllerName string) (versions GatewayAPIVersions) {
+	if k.crdClient == nil {
+		return versions
+	}
 	gatewayCrd, err := k.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), "gateways.gateway.networking.k8s.io", metav1.GetOptions{})
 	if err != nil {
 		var errStatus *errGw.StatusError
 		if !errors.As(err, &errStatus) || errStatus.ErrStatus.Code != 404 {
 			logger.Error(err)
-			return false
+			return versions
 		}
 	}
 
@@ -420,7 +461,7 @@ func (k k8s) IsGatewayAPIInstalled(gatewayControllerName string) (installed bool
 		if gatewayControllerName != "" {
 			logger.Errorf("No gateway api is installed, please install experimental yaml version %s", GATEWAY_API_VERSION)
 		}
-		return false
+		return versions
 	}
 
 	log := logger.Warningf
@@ -428,17 +469,57 @@ func (k k8s) IsGatewayAPIInstalled(gatewayControllerName string) (installed bool
 		log = logger.Errorf
 	}
 
-	version := gatewayCrd.Annotations["gateway.networking.k8s.io/bundle-version"]
-	if version != GATEWAY_API_VERSION {
-		log("Unsupported version '%s' of gateway api is installed, please install experimental yaml version %s", version, GATEWAY_API_VERSION)
-		installed = false
+	bundleVersion := gatewayCrd.Annotations["gateway.networking.k8s.io/bundle-version"]
+	versions.Gateway = k.gatewayAPIServedVersion("gateways", gatewayv1.GroupVersion.Version, gatewayv1beta1.GroupVersion.Version)
+	if versions.Gateway == "" {
+		log("Unsupported version '%s' of gateway api is installed, please install experimental yaml version %s", bundleVersion, GATEWAY_API_VERSION)
+		return GatewayAPIVersions{}
+	}
+	if versions.Gateway != gatewayv1.GroupVersion.Version {
+		logger.Warningf("Gateway api version '%s' does not serve %s gateways, falling back to %s, please install experimental yaml version %s",
+			bundleVersion, gatewayv1.GroupVersion, versions.Gateway, GATEWAY_API_VERSION)
+	}
+
+	versions.ReferenceGrant = k.gatewayAPIServedVersion("referencegrants", gatewayv1beta1.GroupVersion.Version, gatewayv1alpha2.GroupVersion.Version)
+	if versions.ReferenceGrant == "" {
+		log("No referencegrant crd is installed, please install experimental yaml version %s", GATEWAY_API_VERSION)
+		return GatewayAPIVersions{}
 	}
 
 	// gatewayCrd is not nil so gateway API is present
 	tcprouteCrd, err := k.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), "tcproutes.gateway.networking.k8s.io", metav1.GetOptions{})
 	if tcprouteCrd == nil || err != nil {
 		log("No tcproute crd is installed, please install experimental yaml version %s", GATEWAY_API_VERSION)
-		installed = false
+		return GatewayAPIVersions{}
+	}
+	versions.HTTPRoute = k.gatewayAPIServedVersion("httproutes", gatewayv1.GroupVersion.Version, gatewayv1beta1.GroupVersion.Version)
+	if versions.HTTPRoute == "" {
+		logger.Infof("No httproute crd is installed, httproutes are not available")
+	}
+	versions.BackendTLSPolicy = k.gatewayAPIServedVersion("backendtlspolicies", gatewayv1alpha3.GroupVersion.Version)
+	if versions.BackendTLSPolicy == "" {
+		logger.Infof("No backendtlspolicy crd is installed, backend TLS policies are not available")
+	}
+	logger.Infof("Gateway api version '%s' installed, using %s gateways and %s referencegrants", bundleVersion, versions.Gateway, versions.ReferenceGrant)
+	return versions
+}
+
+// gatewayAPIServedVersion returns the first of the provided versions in which the Gateway API resource is served.
+// Like for EndpointSlices, this allows to run against clusters where only older Gateway API versions are installed.
+func (k k8s) gatewayAPIServedVersion(resource string, versions ...string) string {
+	if k.gatewayClient == nil {
+		return ""
+	}
+	for _, version := range versions {
+		resources, err := k.gatewayClient.Discovery().ServerResourcesForGroupVersion(gatewayv1.GroupName + "/" + version)
+		if err != nil {
+			continue
+		}
+		for _, rs := range resources.APIResources {
+			if rs.Name == resource {
+				return version
+			}
+		}
 	}
-	return installed
+	return ""
 }
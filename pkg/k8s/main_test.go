@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	crdfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+// newGatewayAPITestClient returns a k8s client whose cluster serves the Gateway API resources by version.
+func newGatewayAPITestClient(served map[string][]string) k8s {
+	gatewayClient := gatewayfake.NewSimpleClientset()
+	discovery := gatewayClient.Discovery().(*fakediscovery.FakeDiscovery) //nolint:forcetypeassert
+	for version, resources := range served {
+		list := &metav1.APIResourceList{GroupVersion: "gateway.networking.k8s.io/" + version}
+		for _, resource := range resources {
+			list.APIResources = append(list.APIResources, metav1.APIResource{Name: resource})
+		}
+		discovery.Resources = append(discovery.Resources, list)
+	}
+	crdClient := crdfake.NewSimpleClientset(
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+			Name:        "gateways.gateway.networking.k8s.io",
+			Annotations: map[string]string{"gateway.networking.k8s.io/bundle-version": GATEWAY_API_VERSION},
+		}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "tcproutes.gateway.networking.k8s.io"}},
+	)
+	return k8s{gatewayClient: gatewayClient, crdClient: crdClient}
+}
+
+func TestGatewayAPIServedVersion(t *testing.T) {
+	k := newGatewayAPITestClient(map[string][]string{
+		"v1":       {"gatewayclasses", "gateways"},
+		"v1beta1":  {"gatewayclasses", "gateways", "referencegrants"},
+		"v1alpha2": {"referencegrants", "tcproutes"},
+	})
+	assert.Equal(t, "v1", k.gatewayAPIServedVersion("gateways", "v1", "v1beta1"))
+	assert.Equal(t, "v1beta1", k.gatewayAPIServedVersion("referencegrants", "v1beta1", "v1alpha2"))
+	assert.Equal(t, "v1alpha2", k.gatewayAPIServedVersion("tcproutes", "v1beta1", "v1alpha2"))
+	assert.Empty(t, k.gatewayAPIServedVersion("httproutes", "v1", "v1beta1"))
+	assert.Empty(t, k8s{}.gatewayAPIServedVersion("gateways", "v1"))
+}
+
+func TestGetGatewayAPIVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		served   map[string][]string
+		expected GatewayAPIVersions
+	}{
+		{
+			name: "v1",
+			served: map[string][]string{
+				"v1":       {"gatewayclasses", "gateways", "httproutes"},
+				"v1beta1":  {"gatewayclasses", "gateways", "httproutes", "referencegrants"},
+				"v1alpha2": {"referencegrants", "tcproutes"},
+				"v1alpha3": {"backendtlspolicies"},
+			},
+			expected: GatewayAPIVersions{Gateway: "v1", ReferenceGrant: "v1beta1", HTTPRoute: "v1", BackendTLSPolicy: "v1alpha3"},
+		},
+		{
+			name: "v1beta1 fallback",
+			served: map[string][]string{
+				"v1beta1":  {"gatewayclasses", "gateways", "httproutes"},
+				"v1alpha2": {"referencegrants", "tcproutes"},
+			},
+			expected: GatewayAPIVersions{Gateway: "v1beta1", ReferenceGrant: "v1alpha2", HTTPRoute: "v1beta1"},
+		},
+		{
+			name: "v1 gateways and v1alpha2 referencegrants",
+			served: map[string][]string{
+				"v1":       {"gatewayclasses", "gateways"},
+				"v1alpha2": {"referencegrants", "tcproutes"},
+			},
+			expected: GatewayAPIVersions{Gateway: "v1", ReferenceGrant: "v1alpha2"},
+		},
+		{
+			name: "no served gateways",
+			served: map[string][]string{
+				"v1alpha2": {"gatewayclasses", "gateways", "referencegrants", "tcproutes"},
+			},
+		},
+		{
+			name: "no referencegrants",
+			served: map[string][]string{
+				"v1":       {"gatewayclasses", "gateways"},
+				"v1alpha2": {"tcproutes"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			versions := newGatewayAPITestClient(test.served).GetGatewayAPIVersions("haproxy.org/gateway-controller")
+			assert.Equal(t, test.expected, versions)
+			assert.Equal(t, test.expected.Gateway != "", versions.Installed())
+		})
+	}
+}
+
+func TestGetGatewayAPIVersionsNotInstalled(t *testing.T) {
+	k := k8s{gatewayClient: gatewayfake.NewSimpleClientset(), crdClient: crdfake.NewSimpleClientset()}
+	assert.Equal(t, GatewayAPIVersions{}, k.GetGatewayAPIVersions("haproxy.org/gateway-controller"))
+	assert.Equal(t, GatewayAPIVersions{}, k8s{}.GetGatewayAPIVersions(""))
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
+	k8stransform "github.com/haproxytech/kubernetes-ingress/pkg/k8s/transform"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// LoadManifests reads the resources of Kubernetes manifests, YAML or JSON files and directories,
+// and returns the events the informers would send for them, so that the store can be filled without cluster.
+func LoadManifests(paths []string, osArgs utils.OSArgs) (events []k8ssync.SyncDataEvent, err error) {
+	files, err := manifestFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		objects, errRead := readManifest(file)
+		if errRead != nil {
+			return nil, fmt.Errorf("%s: %w", file, errRead)
+		}
+		for _, object := range objects {
+			event, errConvert := manifestEvent(object, osArgs)
+			if errors.Is(errConvert, ErrIgnored) {
+				logger.Debugf("%s: %s %s/%s ignored", file, object.GetKind(), object.GetNamespace(), object.GetName())
+				continue
+			}
+			if errConvert != nil {
+				return nil, fmt.Errorf("%s: %s %s/%s: %w", file, object.GetKind(), object.GetNamespace(), object.GetName(), errConvert)
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// manifestFiles returns the files of the paths, directories are browsed for YAML and JSON files.
+func manifestFiles(paths []string) (files []string, err error) {
+	for _, path := range paths {
+		info, errStat := os.Stat(path)
+		if errStat != nil {
+			return nil, errStat
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var dirFiles []string
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, errWalk error) error {
+			if errWalk != nil {
+				return errWalk
+			}
+			switch filepath.Ext(file) {
+			case ".yaml", ".yml", ".json":
+				if !d.IsDir() {
+					dirFiles = append(dirFiles, file)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+// readManifest returns the resources of a manifest, the items of lists are returned as resources.
+func readManifest(file string) (objects []*unstructured.Unstructured, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	decoder := yamlutil.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		content := map[string]interface{}{}
+		if err = decoder.Decode(&content); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(content) == 0 {
+			continue
+		}
+		object := &unstructured.Unstructured{Object: content}
+		if !object.IsList() {
+			objects = append(objects, object)
+			continue
+		}
+		err = object.EachListItem(func(item runtime.Object) error {
+			listed, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("invalid item in list %s", object.GetName())
+			}
+			objects = append(objects, listed)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// manifestEvent converts a resource as done by its informer.
+func manifestEvent(object *unstructured.Unstructured, osArgs utils.OSArgs) (event k8ssync.SyncDataEvent, err error) {
+	gvk := object.GroupVersionKind()
+	if object.GetNamespace() == "" {
+		// as with kubectl, resources without namespace are created in the default one, it is ignored for cluster resources
+		object.SetNamespace("default")
+	}
+	switch gvk.GroupKind().String() {
+	case "Namespace":
+		data := &corev1.Namespace{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		item := convertToNamespace(data, store.ADDED)
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "Service":
+		data := &corev1.Service{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		if data.Spec.Type == corev1.ServiceTypeExternalName && osArgs.DisableServiceExternalName {
+			return event, ErrIgnored
+		}
+		item := convertToService(data, store.ADDED)
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "Secret":
+		data := &corev1.Secret{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		if data.Data == nil {
+			data.Data = map[string][]byte{}
+		}
+		// stringData is merged into data by the API server
+		for key, value := range data.StringData {
+			data.Data[key] = []byte(value)
+		}
+		item := &store.Secret{
+			Namespace: data.GetNamespace(),
+			Name:      data.GetName(),
+			Data:      data.Data,
+			Status:    store.ADDED,
+		}
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "ConfigMap":
+		data := &corev1.ConfigMap{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		item := &store.ConfigMap{
+			Namespace:   data.GetNamespace(),
+			Name:        data.GetName(),
+			Annotations: store.CopyAnnotations(data.Data),
+			Status:      store.ADDED,
+		}
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "Endpoints", "EndpointSlice.discovery.k8s.io":
+		var data runtime.Object = &corev1.Endpoints{}
+		if gvk.Kind == "EndpointSlice" {
+			data = &discoveryv1.EndpointSlice{}
+		}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		item, errConvert := k8s{}.convertToEndpoints(data, store.ADDED)
+		if errConvert != nil {
+			return event, errConvert
+		}
+		return ToSyncDataEvent(item, item, object.GetUID(), object.GetResourceVersion()), nil
+	case "Ingress.networking.k8s.io":
+		data := &networkingv1.Ingress{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		if _, err = k8stransform.TransformIngress(data); err != nil {
+			return event, err
+		}
+		item, errConvert := store.ConvertToIngress(data)
+		if errConvert != nil {
+			return event, errConvert
+		}
+		item.Status = store.ADDED
+		if item.Class != "" && item.Class != osArgs.IngressClass {
+			return event, ErrIgnored
+		}
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "IngressClass.networking.k8s.io":
+		data := &networkingv1.IngressClass{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		item, errConvert := store.ConvertToIngressClass(data)
+		if errConvert != nil {
+			return event, errConvert
+		}
+		return ToSyncDataEvent(item, item, data.UID, data.ResourceVersion), nil
+	case "Global.ingress.v3.haproxy.org":
+		data := &v3.Global{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		return k8ssync.SyncDataEvent{SyncType: k8ssync.CR_GLOBAL, Namespace: data.GetNamespace(), Name: data.GetName(), Data: data}, nil
+	case "Defaults.ingress.v3.haproxy.org":
+		data := &v3.Defaults{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		return k8ssync.SyncDataEvent{SyncType: k8ssync.CR_DEFAULTS, Namespace: data.GetNamespace(), Name: data.GetName(), Data: data}, nil
+	case "Backend.ingress.v3.haproxy.org":
+		data := &v3.Backend{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		if _, err = k8stransform.TransformBackend(data); err != nil {
+			return event, err
+		}
+		return k8ssync.SyncDataEvent{SyncType: k8ssync.CR_BACKEND, Namespace: data.GetNamespace(), Name: data.GetName(), Data: data}, nil
+	case "TCP.ingress.v3.haproxy.org":
+		data := &v3.TCP{}
+		if err = fromUnstructured(object, data); err != nil {
+			return event, err
+		}
+		item := convertToStoreTCP(data, store.ADDED)
+		if item == nil || item.IngressClass != "" && item.IngressClass != osArgs.IngressClass {
+			return event, ErrIgnored
+		}
+		return k8ssync.SyncDataEvent{SyncType: k8ssync.CR_TCP, Namespace: item.Namespace, Name: item.Name, Data: item}, nil
+	default:
+		logger.Warningf("%s %s/%s: kind not supported in manifests", gvk, object.GetNamespace(), object.GetName())
+		return event, ErrIgnored
+	}
+}
+
+func fromUnstructured(object *unstructured.Unstructured, data interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, data)
+}
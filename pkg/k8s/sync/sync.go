@@ -34,22 +34,24 @@ type SyncDataEvent struct {
 //nolint:golint,stylecheck
 const (
 	// SyncType values
-	COMMAND         SyncType = "COMMAND"
-	CONFIGMAP       SyncType = "CONFIGMAP"
-	ENDPOINTS       SyncType = "ENDPOINTS"
-	INGRESS         SyncType = "INGRESS"
-	INGRESS_CLASS   SyncType = "INGRESS_CLASS"
-	NAMESPACE       SyncType = "NAMESPACE"
-	POD             SyncType = "POD"
-	SERVICE         SyncType = "SERVICE"
-	SECRET          SyncType = "SECRET"
-	CR_GLOBAL       SyncType = "Global"
-	CR_DEFAULTS     SyncType = "Defaults"
-	CR_BACKEND      SyncType = "Backend"
-	CR_TCP          SyncType = "TCP"
-	PUBLISH_SERVICE SyncType = "PUBLISH_SERVICE"
-	GATEWAYCLASS    SyncType = "GATEWAYCLASS"
-	GATEWAY         SyncType = "GATEWAY"
-	TCPROUTE        SyncType = "TCPROUTE"
-	REFERENCEGRANT  SyncType = "REFERENCEGRANT"
+	COMMAND          SyncType = "COMMAND"
+	CONFIGMAP        SyncType = "CONFIGMAP"
+	ENDPOINTS        SyncType = "ENDPOINTS"
+	INGRESS          SyncType = "INGRESS"
+	INGRESS_CLASS    SyncType = "INGRESS_CLASS"
+	NAMESPACE        SyncType = "NAMESPACE"
+	POD              SyncType = "POD"
+	SERVICE          SyncType = "SERVICE"
+	SECRET           SyncType = "SECRET"
+	CR_GLOBAL        SyncType = "Global"
+	CR_DEFAULTS      SyncType = "Defaults"
+	CR_BACKEND       SyncType = "Backend"
+	CR_TCP           SyncType = "TCP"
+	PUBLISH_SERVICE  SyncType = "PUBLISH_SERVICE"
+	GATEWAYCLASS     SyncType = "GATEWAYCLASS"
+	GATEWAY          SyncType = "GATEWAY"
+	TCPROUTE         SyncType = "TCPROUTE"
+	HTTPROUTE        SyncType = "HTTPROUTE"
+	REFERENCEGRANT   SyncType = "REFERENCEGRANT"
+	BACKENDTLSPOLICY SyncType = "BACKENDTLSPOLICY"
 )
@@ -14,6 +14,25 @@
 
 package k8stransform
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 func TransformConfigmap(obj interface{}) (interface{}, error) {
 	return TransformCommon(obj)
 }
+
+// TransformCABundle keeps only the ca.crt entry of configmaps watched for their CA bundle.
+func TransformCABundle(obj interface{}) (interface{}, error) {
+	configmap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return TransformCommon(obj)
+	}
+	caBundle, found := configmap.Data["ca.crt"]
+	configmap.Data = nil
+	configmap.BinaryData = nil
+	if found {
+		configmap.Data = map[string]string{"ca.crt": caBundle}
+	}
+	return TransformCommon(configmap)
+}
@@ -2,9 +2,12 @@ package metrics
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
 
 const (
@@ -22,6 +25,42 @@ type PrometheusMetricsManager struct {
 
 	// runtime socket
 	runtimeSocketCounterVec *prometheus.CounterVec
+
+	// certificates
+	certificateCollector *certificateCollector
+
+	// caches
+	cacheCollector *cacheCollector
+}
+
+// CacheStats holds the cumulative number of cache lookups and hits of a backend.
+type CacheStats struct {
+	Lookups float64
+	Hits    float64
+}
+
+// cacheCollector collects the cache statistics of the backends from HAProxy at each scrape.
+type cacheCollector struct {
+	lookupsDesc *prometheus.Desc
+	hitsDesc    *prometheus.Desc
+	source      func() (map[string]CacheStats, error)
+	mu          sync.RWMutex
+}
+
+// Certificate is a certificate in use, its expiry is computed at each scrape.
+type Certificate struct {
+	Type     string
+	Secret   string
+	Subject  string
+	NotAfter time.Time
+}
+
+// certificateCollector collects the number of seconds until expiry of the certificates in use at each scrape.
+type certificateCollector struct {
+	expiryDesc   *prometheus.Desc
+	now          func() time.Time
+	certificates []Certificate
+	mu           sync.RWMutex
 }
 
 var (
@@ -49,6 +88,32 @@ func New() PrometheusMetricsManager {
 			[]string{"object", "result"},
 		)
 
+		// certificates
+		certificateCollector := &certificateCollector{
+			expiryDesc: prometheus.NewDesc(
+				"haproxy_certificate_expiry_seconds",
+				"The number of seconds until expiry of certificates in use partitioned by type, secret and subject",
+				[]string{"type", "secret", "subject"}, nil,
+			),
+			now: time.Now,
+		}
+		prometheus.MustRegister(certificateCollector)
+
+		// caches
+		cacheCollector := &cacheCollector{
+			lookupsDesc: prometheus.NewDesc(
+				"haproxy_cache_lookups_total",
+				"The number of cache lookups of the backends using a cache, reset when HAProxy reloads",
+				[]string{"backend"}, nil,
+			),
+			hitsDesc: prometheus.NewDesc(
+				"haproxy_cache_hits_total",
+				"The number of cache hits of the backends using a cache, reset when HAProxy reloads",
+				[]string{"backend"}, nil,
+			),
+		}
+		prometheus.MustRegister(cacheCollector)
+
 		unableToSyncGauge := promauto.NewGauge(prometheus.GaugeOpts{
 			Name: "haproxy_unable_to_sync_configuration",
 			Help: "1 = there's a pending haproxy configuration that is not valid so not applicable, 0 = haproxy configuration applied",
@@ -58,6 +123,8 @@ func New() PrometheusMetricsManager {
 			reloadsCounterVec:       reloadCounter,
 			runtimeSocketCounterVec: runtimeSocketCounter,
 			unableToSyncGauge:       unableToSyncGauge,
+			certificateCollector:    certificateCollector,
+			cacheCollector:          cacheCollector,
 		}
 	})
 	return pmm
@@ -86,3 +153,54 @@ func (pmm PrometheusMetricsManager) SetUnableSyncGauge() {
 func (pmm PrometheusMetricsManager) UnsetUnableSyncGauge() {
 	pmm.unableToSyncGauge.Set(float64(0))
 }
+
+// SetCertificates replaces the certificates in use, their expiry is computed at each scrape.
+func (pmm PrometheusMetricsManager) SetCertificates(certificates []Certificate) {
+	pmm.certificateCollector.mu.Lock()
+	defer pmm.certificateCollector.mu.Unlock()
+	pmm.certificateCollector.certificates = certificates
+}
+
+// SetCacheStatsSource sets the function returning the cache statistics by backend, called at each scrape.
+func (pmm PrometheusMetricsManager) SetCacheStatsSource(source func() (map[string]CacheStats, error)) {
+	pmm.cacheCollector.mu.Lock()
+	defer pmm.cacheCollector.mu.Unlock()
+	pmm.cacheCollector.source = source
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lookupsDesc
+	ch <- c.hitsDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	source := c.source
+	c.mu.RUnlock()
+	if source == nil {
+		return
+	}
+	stats, err := source()
+	if err != nil {
+		// the other metrics are still served when HAProxy can't be reached
+		utils.GetLogger().Errorf("unable to collect cache statistics: %s", err)
+		return
+	}
+	for backend, stat := range stats {
+		ch <- prometheus.MustNewConstMetric(c.lookupsDesc, prometheus.CounterValue, stat.Lookups, backend)
+		ch <- prometheus.MustNewConstMetric(c.hitsDesc, prometheus.CounterValue, stat.Hits, backend)
+	}
+}
+
+func (c *certificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.expiryDesc
+}
+
+func (c *certificateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := c.now()
+	for _, crt := range c.certificates {
+		ch <- prometheus.MustNewConstMetric(c.expiryDesc, prometheus.GaugeValue, crt.NotAfter.Sub(now).Seconds(), crt.Type, crt.Secret, crt.Subject)
+	}
+}
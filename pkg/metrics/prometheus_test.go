@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheCollector(t *testing.T) {
+	pmm := New()
+	pmm.SetCacheStatsSource(func() (map[string]CacheStats, error) {
+		return map[string]CacheStats{"default_svc_http": {Lookups: 120, Hits: 90}}, nil
+	})
+	expected := `
+# HELP haproxy_cache_hits_total The number of cache hits of the backends using a cache, reset when HAProxy reloads
+# TYPE haproxy_cache_hits_total counter
+haproxy_cache_hits_total{backend="default_svc_http"} 90
+# HELP haproxy_cache_lookups_total The number of cache lookups of the backends using a cache, reset when HAProxy reloads
+# TYPE haproxy_cache_lookups_total counter
+haproxy_cache_lookups_total{backend="default_svc_http"} 120
+`
+	assert.NoError(t, testutil.CollectAndCompare(pmm.cacheCollector, strings.NewReader(expected)))
+
+	// statistics are not reported when HAProxy can't be reached
+	pmm.SetCacheStatsSource(func() (map[string]CacheStats, error) {
+		return nil, errors.New("runtime socket unavailable")
+	})
+	assert.Equal(t, 0, testutil.CollectAndCount(pmm.cacheCollector))
+}
+
+func TestCertificateCollector(t *testing.T) {
+	pmm := New()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pmm.certificateCollector.now = func() time.Time { return now }
+	t.Cleanup(func() { pmm.certificateCollector.now = time.Now })
+	pmm.SetCertificates([]Certificate{
+		{Type: "frontend", Secret: "default/site", Subject: "CN=example.com", NotAfter: now.Add(48 * time.Hour)},
+		{Type: "backend", Secret: "default/expired", Subject: "CN=expired.example.com", NotAfter: now.Add(-time.Hour)},
+	})
+	expected := `
+# HELP haproxy_certificate_expiry_seconds The number of seconds until expiry of certificates in use partitioned by type, secret and subject
+# TYPE haproxy_certificate_expiry_seconds gauge
+haproxy_certificate_expiry_seconds{secret="default/expired",subject="CN=expired.example.com",type="backend"} -3600
+haproxy_certificate_expiry_seconds{secret="default/site",subject="CN=example.com",type="frontend"} 172800
+`
+	assert.NoError(t, testutil.CollectAndCompare(pmm.certificateCollector, strings.NewReader(expected)))
+
+	// the expiry is computed at each scrape
+	now = now.Add(24 * time.Hour)
+	expected = `
+# HELP haproxy_certificate_expiry_seconds The number of seconds until expiry of certificates in use partitioned by type, secret and subject
+# TYPE haproxy_certificate_expiry_seconds gauge
+haproxy_certificate_expiry_seconds{secret="default/expired",subject="CN=expired.example.com",type="backend"} -90000
+haproxy_certificate_expiry_seconds{secret="default/site",subject="CN=example.com",type="frontend"} 86400
+`
+	assert.NoError(t, testutil.CollectAndCompare(pmm.certificateCollector, strings.NewReader(expected)))
+
+	pmm.SetCertificates(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(pmm.certificateCollector))
+}
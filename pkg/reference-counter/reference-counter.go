@@ -30,6 +30,9 @@ const (
 	// ResourceType values
 	TCP_CR        ResourceType = "tcp-cr"
 	TCP_CONFIGMAP ResourceType = "tcp-configmap"
+	INGRESS       ResourceType = "ingress"
+	SERVICE       ResourceType = "service"
+	CONFIGMAP     ResourceType = "configmap"
 )
 
 type (
@@ -64,6 +67,28 @@ func NewOwner(rtype ResourceType, namespace, name string) Owner {
 	}
 }
 
+func (o Owner) Type() ResourceType {
+	return o.resourceType
+}
+
+func (o Owner) Namespace() string {
+	return o.namespace
+}
+
+func (o Owner) Name() string {
+	return o.name
+}
+
+// Owner returns the owner of the key, namespaced owners are assumed.
+func (k OwnerKey) Owner() Owner {
+	rtype, name, _ := strings.Cut(string(k), ":")
+	namespace, nsName, found := strings.Cut(name, "/")
+	if !found {
+		return NewOwner(ResourceType(rtype), "", name)
+	}
+	return NewOwner(ResourceType(rtype), namespace, nsName)
+}
+
 func (o *Owner) Key() OwnerKey {
 	var sb strings.Builder
 	sb.WriteString(string(o.resourceType))
@@ -17,6 +17,7 @@ package route
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/haproxytech/client-native/v6/models"
@@ -25,6 +26,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
 
 //nolint:golint,stylecheck
@@ -38,6 +40,9 @@ const (
 	PATH_EXACT        maps.Name = "path-exact"
 	PATH_PREFIX_EXACT maps.Name = "path-prefix-exact"
 	PATH_PREFIX       maps.Name = "path-prefix"
+	PATH_REGEX        maps.Name = "path-regex"
+	// MATCH_KEY_SEPARATOR separates the match key from the host in the routing maps
+	MATCH_KEY_SEPARATOR = "@"
 )
 
 var (
@@ -45,16 +50,28 @@ var (
 	CustomRoutes       = make([]string, 0)
 )
 
+// ErrInvalidPathRegex is returned for the regex paths rejected by pathPattern.
+var ErrInvalidPathRegex = errors.New("invalid path regular expression")
+
 type Route struct {
-	Path           *store.IngressPath
-	Host           string
-	BackendName    string
-	HAProxyRules   []rules.RuleID
+	Path         *store.IngressPath
+	Host         string
+	BackendName  string
+	HAProxyRules []rules.RuleID
+	// MatchACLs are the conditions, besides host and path, a request must all match to be routed
+	MatchACLs []string
+	// PathRegex makes ImplementationSpecific paths regular expressions
+	PathRegex      bool
 	SSLPassthrough bool
 }
 
 // AddHostPathRoute adds Host/Path ingress route to haproxy Map files used for backend switching.
 func AddHostPathRoute(route Route, mapFiles maps.Maps) error {
+	return addMapRoute(route, mapFiles, "")
+}
+
+// addMapRoute adds the Host/Path route to the map files, the keys of its paths being prefixed with matchKey.
+func addMapRoute(route Route, mapFiles maps.Maps, matchKey string) error {
 	if route.BackendName == "" {
 		return errors.New("backendName missing")
 	}
@@ -80,29 +97,91 @@ func AddHostPathRoute(route Route, mapFiles maps.Maps) error {
 		return fmt.Errorf("neither Host nor Path are provided for backend %v,", route.BackendName)
 	}
 
+	host := matchKey + route.Host
 	path := route.Path.Path
 	switch {
 	case route.Path.PathTypeMatch == store.PATH_TYPE_EXACT:
-		mapFiles.MapAppend(PATH_EXACT, route.Host+path+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_EXACT, host+path+"\t\t\t"+value)
 	case path == "" || path == "/":
-		mapFiles.MapAppend(PATH_PREFIX, route.Host+"/"+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_PREFIX, host+"/"+"\t\t\t"+value)
 	case route.Path.PathTypeMatch == store.PATH_TYPE_PREFIX:
 		path = strings.TrimSuffix(path, "/")
-		mapFiles.MapAppend(PATH_PREFIX_EXACT, route.Host+path+"\t\t\t"+value)
-		mapFiles.MapAppend(PATH_PREFIX, route.Host+path+"/"+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_PREFIX_EXACT, host+path+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_PREFIX, host+path+"/"+"\t\t\t"+value)
+	case route.isPathRegex():
+		pattern, err := pathPattern(path)
+		if err != nil {
+			return fmt.Errorf("backend '%s': %w", route.BackendName, err)
+		}
+		mapFiles.MapAppend(PATH_REGEX, "^"+regexp.QuoteMeta(host)+pattern+"\t\t\t"+value)
 	case route.Path.PathTypeMatch == store.PATH_TYPE_IMPLEMENTATION_SPECIFIC:
 		path = strings.TrimSuffix(path, "/")
-		mapFiles.MapAppend(PATH_PREFIX_EXACT, route.Host+path+"\t\t\t"+value)
-		mapFiles.MapAppend(PATH_PREFIX, route.Host+path+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_PREFIX_EXACT, host+path+"\t\t\t"+value)
+		mapFiles.MapAppend(PATH_PREFIX, host+path+"\t\t\t"+value)
 	default:
 		return fmt.Errorf("unknown path type '%s' with backend '%s'", route.Path.PathTypeMatch, route.BackendName)
 	}
 	return nil
 }
 
+// AddMatchRoute adds an ingress route with match conditions to haproxy Map files used for backend switching.
+// A ReqRouteMatch rule sets the match key of the requests matching all the match ACLs, the host and path of
+// the route are added to the maps prefixed with this key, so they are only found for these requests.
+// Other requests follow the standard routing of the ingress rules.
+func AddMatchRoute(route Route, mapFiles maps.Maps, r rules.Rules) error {
+	if len(route.MatchACLs) == 0 {
+		return fmt.Errorf("no match condition for backend '%s'", route.BackendName)
+	}
+	if route.SSLPassthrough {
+		return fmt.Errorf("match conditions of backend '%s' can't be used with SSL passthrough", route.BackendName)
+	}
+	rule := rules.ReqRouteMatch{
+		Key:  MatchKey(route.MatchACLs),
+		ACLs: route.MatchACLs,
+	}
+	for _, frontend := range []string{FrontendHTTP, FrontendHTTPS} {
+		if err := r.AddRule(frontend, rule, false); err != nil {
+			return err
+		}
+	}
+	return addMapRoute(route, mapFiles, rule.Key+MATCH_KEY_SEPARATOR)
+}
+
+// MatchKey returns the key of a list of match ACLs.
+func MatchKey(acls []string) string {
+	return utils.Hash([]byte(strings.Join(acls, "\n")))
+}
+
 // AddCustomRoute adds an ingress route with specific ACL via use_backend haproxy directive
 func AddCustomRoute(route Route, routeACLAnn string, api api.HAProxyClient) (err error) {
-	var routeCond string
+	routeCond, err := routeCondition(route)
+	if err != nil {
+		return err
+	}
+	return addSwitchingRule(route, fmt.Sprintf("%s { %s } ", routeCond, routeACLAnn), api)
+}
+
+// AddCanaryRoute adds a canary ingress route via use_backend haproxy directive.
+// The route is used when the request matches the ingress host and path and any of the canary ACLs,
+// other requests follow the standard routing of the ingress rules.
+func AddCanaryRoute(route Route, canaryACLs []string, api api.HAProxyClient) (err error) {
+	if len(canaryACLs) == 0 {
+		return fmt.Errorf("no valid canary condition for backend '%s'", route.BackendName)
+	}
+	base, err := routeCondition(route)
+	if err != nil {
+		return err
+	}
+	conds := make([]string, len(canaryACLs))
+	for i, acl := range canaryACLs {
+		conds[i] = fmt.Sprintf("%s { %s }", base, acl)
+	}
+	return addSwitchingRule(route, strings.Join(conds, " || ")+" ", api)
+}
+
+// routeCondition returns the anonymous ACLs matching the host and path of the route.
+// An error wrapping ErrInvalidPathRegex is returned if the path is an invalid regular expression.
+func routeCondition(route Route) (routeCond string, err error) {
 	if route.Host != "" {
 		if route.Host[0] == '*' {
 			// Wildcard host - use suffix matching
@@ -113,7 +192,13 @@ func AddCustomRoute(route Route, routeACLAnn string, api api.HAProxyClient) (err
 		}
 	}
 	if route.Path.Path != "" {
-		if route.Path.PathTypeMatch == store.PATH_TYPE_EXACT {
+		if route.isPathRegex() {
+			pattern, err := pathPattern(route.Path.Path)
+			if err != nil {
+				return "", fmt.Errorf("backend '%s': %w", route.BackendName, err)
+			}
+			routeCond = fmt.Sprintf("%s{ path -m reg ^%s }", routeCond, pattern)
+		} else if route.Path.PathTypeMatch == store.PATH_TYPE_EXACT {
 			routeCond = fmt.Sprintf("%s{ path %s }", routeCond, route.Path.Path)
 		} else {
 			if route.Path.Path == "/" {
@@ -124,8 +209,31 @@ func AddCustomRoute(route Route, routeACLAnn string, api api.HAProxyClient) (err
 			}
 		}
 	}
-	routeCond = fmt.Sprintf("%s { %s } ", routeCond, routeACLAnn)
+	return routeCond, nil
+}
+
+// pathPattern validates a path regular expression and returns it without its leading anchor,
+// paths are always matched from their beginning.
+// Expressions are validated with the Go RE2 syntax, a subset of the PCRE syntax of HAProxy:
+// PCRE only constructs such as lookarounds and backreferences are rejected.
+// As they are inserted as is in the ACLs, whitespaces, comments, quotes and escapes are rejected too.
+func pathPattern(path string) (string, error) {
+	if strings.ContainsAny(path, " \t\r\n#\"'\\") {
+		return "", fmt.Errorf("%w '%s': whitespaces and #\"'\\ are not allowed", ErrInvalidPathRegex, path)
+	}
+	pattern := strings.TrimPrefix(path, "^")
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("%w '%s': %w", ErrInvalidPathRegex, path, err)
+	}
+	return pattern, nil
+}
+
+// isPathRegex returns true if the path of the route is a regular expression.
+func (route Route) isPathRegex() bool {
+	return route.PathRegex && route.Path.PathTypeMatch == store.PATH_TYPE_IMPLEMENTATION_SPECIFIC
+}
 
+func addSwitchingRule(route Route, routeCond string, api api.HAProxyClient) (err error) {
 	for _, frontend := range []string{FrontendHTTP, FrontendHTTPS} {
 		err = api.BackendSwitchingRuleCreate(0, frontend, models.BackendSwitchingRule{
 			Cond:     "if",
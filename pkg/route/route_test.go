@@ -0,0 +1,206 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// fakeMaps records the rows of the maps.
+type fakeMaps struct {
+	maps.Maps
+	rows map[maps.Name][]string
+}
+
+func (m *fakeMaps) MapAppend(name maps.Name, row string) {
+	if m.rows == nil {
+		m.rows = map[maps.Name][]string{}
+	}
+	m.rows[name] = append(m.rows[name], row)
+}
+
+// fakeRules records the rules of the frontends.
+type fakeRules struct {
+	rules.Rules
+	rules map[string][]rules.Rule
+}
+
+func (r *fakeRules) AddRule(frontend string, rule rules.Rule, ingressRule bool) error {
+	if r.rules == nil {
+		r.rules = map[string][]rules.Rule{}
+	}
+	r.rules[frontend] = append(r.rules[frontend], rule)
+	return nil
+}
+
+// fakeClient records the backend switching rules conditions.
+type fakeClient struct {
+	api.HAProxyClient
+	conditions []string
+}
+
+func (c *fakeClient) BackendSwitchingRuleCreate(id int64, frontend string, rule models.BackendSwitchingRule) error {
+	c.conditions = append(c.conditions, frontend+" "+rule.CondTest)
+	return nil
+}
+
+func TestAddMatchRoute(t *testing.T) {
+	acls := []string{"req.hdr(X-API-Version) -m str v2", "method GET HEAD"}
+	key := MatchKey(acls)
+	tests := []struct {
+		name string
+		host string
+		path *store.IngressPath
+		want map[maps.Name][]string
+	}{
+		{
+			name: "prefix",
+			host: "api.example.com",
+			path: &store.IngressPath{Path: "/v2/", PathTypeMatch: store.PATH_TYPE_PREFIX},
+			want: map[maps.Name][]string{
+				HOST:              {"api.example.com\t\t\tapi.example.com"},
+				PATH_PREFIX_EXACT: {key + "@api.example.com/v2\t\t\tdefault_svc_v2_http.rule1"},
+				PATH_PREFIX:       {key + "@api.example.com/v2/\t\t\tdefault_svc_v2_http.rule1"},
+			},
+		},
+		{
+			name: "exact wildcard host",
+			host: "*.example.com",
+			path: &store.IngressPath{Path: "/status", PathTypeMatch: store.PATH_TYPE_EXACT},
+			want: map[maps.Name][]string{
+				HOST:       {".example.com\t\t\t.example.com"},
+				PATH_EXACT: {key + "@.example.com/status\t\t\tdefault_svc_v2_http.rule1"},
+			},
+		},
+		{
+			name: "regex without host",
+			path: &store.IngressPath{Path: "^/v[0-9]+/users", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC},
+			want: map[maps.Name][]string{
+				PATH_REGEX: {"^" + key + "@/v[0-9]+/users\t\t\tdefault_svc_v2_http.rule1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapFiles := &fakeMaps{}
+			frontendRules := &fakeRules{}
+			err := AddMatchRoute(Route{
+				Host:         tt.host,
+				Path:         tt.path,
+				BackendName:  "default_svc_v2_http",
+				HAProxyRules: []rules.RuleID{"rule1"},
+				MatchACLs:    acls,
+				PathRegex:    true,
+			}, mapFiles, frontendRules)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mapFiles.rows)
+			// no anonymous ACL on use_backend, the match key is set by a rule of the frontends
+			rule := rules.ReqRouteMatch{Key: key, ACLs: acls}
+			assert.Equal(t, map[string][]rules.Rule{FrontendHTTP: {rule}, FrontendHTTPS: {rule}}, frontendRules.rules)
+		})
+	}
+}
+
+func TestAddMatchRouteErrors(t *testing.T) {
+	path := &store.IngressPath{Path: "/", PathTypeMatch: store.PATH_TYPE_PREFIX}
+	for _, route := range []Route{
+		{Host: "example.com", Path: path, BackendName: "default_svc_http"},
+		{Host: "example.com", Path: path, BackendName: "default_svc_http", MatchACLs: []string{"method GET"}, SSLPassthrough: true},
+		{Host: "example.com", Path: &store.IngressPath{Path: "/(", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}, BackendName: "default_svc_http", MatchACLs: []string{"method GET"}, PathRegex: true},
+	} {
+		assert.Error(t, AddMatchRoute(route, &fakeMaps{}, &fakeRules{}), "%+v", route)
+	}
+}
+
+func TestMatchKey(t *testing.T) {
+	assert.Equal(t, MatchKey([]string{"method GET"}), MatchKey([]string{"method GET"}))
+	assert.NotEqual(t, MatchKey([]string{"method GET", "req.cook(beta) -m found"}), MatchKey([]string{"req.cook(beta) -m found", "method GET"}))
+	assert.NotContains(t, MatchKey([]string{"method GET"}), MATCH_KEY_SEPARATOR)
+}
+
+func TestPathPattern(t *testing.T) {
+	tests := []struct {
+		path    string
+		pattern string
+		wantErr bool
+	}{
+		{path: "/api/v[0-9]+/users", pattern: "/api/v[0-9]+/users"},
+		// paths are always matched from their beginning
+		{path: "^/api/(users|groups)$", pattern: "/api/(users|groups)$"},
+		{path: "/api/[0-9]{2,}[.]json", pattern: "/api/[0-9]{2,}[.]json"},
+		{path: "/api/ users", wantErr: true},
+		// comments, quotes and escapes are not inserted in the ACLs
+		{path: "/api/v1#", wantErr: true},
+		{path: "/api/v1\"", wantErr: true},
+		{path: "/api/v1'", wantErr: true},
+		{path: "/api/\\d{2,}", wantErr: true},
+		{path: "/api/(", wantErr: true},
+		// PCRE only constructs are rejected
+		{path: "/api/(?!internal)", wantErr: true},
+		{path: "/(a)\\1", wantErr: true},
+	}
+	for _, test := range tests {
+		pattern, err := pathPattern(test.path)
+		if test.wantErr {
+			assert.ErrorIs(t, err, ErrInvalidPathRegex, test.path)
+			continue
+		}
+		require.NoError(t, err, test.path)
+		assert.Equal(t, test.pattern, pattern, test.path)
+	}
+}
+
+func TestIsPathRegex(t *testing.T) {
+	for _, test := range []struct {
+		route    Route
+		expected bool
+	}{
+		{route: Route{PathRegex: true, Path: &store.IngressPath{PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}}, expected: true},
+		{route: Route{PathRegex: true, Path: &store.IngressPath{PathTypeMatch: store.PATH_TYPE_PREFIX}}},
+		{route: Route{PathRegex: true, Path: &store.IngressPath{PathTypeMatch: store.PATH_TYPE_EXACT}}},
+		{route: Route{Path: &store.IngressPath{PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}}},
+	} {
+		assert.Equal(t, test.expected, test.route.isPathRegex(), "%+v", test.route.Path)
+	}
+}
+
+func TestAddHostPathRouteRegex(t *testing.T) {
+	mapFiles := &fakeMaps{}
+	route := Route{Host: "api.example.com", BackendName: "default_svc_http", PathRegex: true}
+	route.Path = &store.IngressPath{Path: "^/v[0-9]+/users", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}
+	require.NoError(t, AddHostPathRoute(route, mapFiles))
+	assert.Equal(t, []string{"^api\\.example\\.com/v[0-9]+/users\t\t\tdefault_svc_http"}, mapFiles.rows[PATH_REGEX])
+
+	route.Path = &store.IngressPath{Path: "/v[0-9", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}
+	assert.ErrorIs(t, AddHostPathRoute(route, mapFiles), ErrInvalidPathRegex)
+	assert.Len(t, mapFiles.rows[PATH_REGEX], 1)
+}
+
+func TestSwitchingRuleRouteRegex(t *testing.T) {
+	client := &fakeClient{}
+	route := Route{Host: "api.example.com", BackendName: "default_svc_http", PathRegex: true}
+	route.Path = &store.IngressPath{Path: "^/v[0-9]+/users", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}
+	require.NoError(t, AddCanaryRoute(route, []string{"req.hdr(X-Canary) -m str always"}, client))
+	require.NoError(t, AddCustomRoute(route, "method GET", client))
+	assert.Equal(t, []string{
+		"http { var(txn.host) -m str api.example.com } { path -m reg ^/v[0-9]+/users } { req.hdr(X-Canary) -m str always } ",
+		"https { var(txn.host) -m str api.example.com } { path -m reg ^/v[0-9]+/users } { req.hdr(X-Canary) -m str always } ",
+		"http { var(txn.host) -m str api.example.com } { path -m reg ^/v[0-9]+/users } { method GET } ",
+		"https { var(txn.host) -m str api.example.com } { path -m reg ^/v[0-9]+/users } { method GET } ",
+	}, client.conditions)
+
+	// invalid regular expressions are not inserted in the ACLs
+	client = &fakeClient{}
+	route.Path = &store.IngressPath{Path: "/v1 } #", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}
+	assert.ErrorIs(t, AddCanaryRoute(route, []string{"req.hdr(X-Canary) -m str always"}, client), ErrInvalidPathRegex)
+	route.Path = &store.IngressPath{Path: "/v1\\d", PathTypeMatch: store.PATH_TYPE_IMPLEMENTATION_SPECIFIC}
+	assert.ErrorIs(t, AddCustomRoute(route, "method GET", client), ErrInvalidPathRegex)
+	assert.Empty(t, client.conditions)
+}
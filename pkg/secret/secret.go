@@ -15,6 +15,8 @@
 package secret
 
 import (
+	"fmt"
+
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
@@ -53,6 +55,7 @@ func NewManager(store store.K8s, h haproxy.HAProxy) *Manager {
 }
 
 func (s Manager) Store(sec Secret) {
+	defer s.haproxy.AddReference(sec.SecretType, sec.Name.Namespace, sec.Name.Name, fmt.Sprintf("%s %s/%s", sec.OwnerType, sec.Name.Namespace, sec.OwnerName))
 	if _, ok := s.store.SecretsProcessed[sec.Name.String()]; ok {
 		return
 	}
@@ -17,6 +17,8 @@ package service
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/haproxytech/client-native/v6/models"
@@ -130,8 +132,8 @@ func (s *Service) scaleHAProxySrvs(backend *store.RuntimeBackend) {
 	// ... copy the existing servers into ...
 	copy(slots, backend.HAProxySrvs)
 	i := len(backend.HAProxySrvs)
-	// ... then add the new slots ...
-	for addr := range backend.Endpoints.Addresses {
+	// ... then add the new slots, sorted so that a same set of endpoints gives the same servers ...
+	for _, addr := range slices.Sorted(maps.Keys(backend.Endpoints.Addresses)) {
 		srv := &store.HAProxySrv{
 			Name:     fmt.Sprintf("SRV_%d", i+1),
 			Address:  addr,
@@ -0,0 +1,148 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// handleMirror processes the mirror-service and mirror-percentage annotations of the service and its ingress.
+// Rules sending a copy of a sample of the requests of backend to the backend of the mirror Service are added
+// to backend, the mirror backend itself is created by HandleMirrorBackend once backend is handled.
+// Responses of the mirror backend are discarded, mirroring never affects the responses sent to clients.
+func (s *Service) handleMirror(k store.K8s, a annotations.Annotations, backend *models.Backend) error {
+	s.mirrorService = nil
+	// mirror Services don't mirror their own requests
+	if s.mirror || s.modeTCP {
+		return nil
+	}
+	annList := []map[string]string{s.resource.Annotations}
+	namespace := s.resource.Namespace
+	if s.ingress != nil {
+		annList = append(annList, s.ingress.Annotations)
+		namespace = s.ingress.Namespace
+	}
+	input := a.String("mirror-service", annList...)
+	if input == "" {
+		return nil
+	}
+	path, err := mirrorPath(input, namespace)
+	if err != nil {
+		return err
+	}
+	percentage := int64(100)
+	if input = a.String("mirror-percentage", annList...); input != "" {
+		percentage, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || percentage < 0 || percentage > 100 {
+			return fmt.Errorf("invalid mirror percentage '%s': an integer between 0 and 100 is expected", input)
+		}
+	}
+	if percentage == 0 {
+		return nil
+	}
+
+	mirror, err := New(k, path, s.certs, false, nil, k.ConfigMaps.Main.Annotations)
+	if err != nil {
+		return err
+	}
+	mirror.mirror = true
+	name, err := mirror.GetBackendName()
+	if err != nil {
+		return err
+	}
+	if name == backend.Name {
+		return errors.New("requests can't be mirrored to their own backend")
+	}
+	s.mirrorService = mirror
+
+	sampled := "{ var(txn.mirror) -m bool }"
+	sample := &models.HTTPRequestRule{
+		Type:     "set-var",
+		VarScope: "txn",
+		VarName:  "mirror",
+		VarExpr:  "bool(true)",
+	}
+	if percentage < 100 {
+		sample.Cond = "if"
+		sample.CondTest = fmt.Sprintf("{ rand(100) -m int lt %d }", percentage)
+	}
+	backend.HTTPRequestRuleList = append(backend.HTTPRequestRuleList,
+		sample,
+		&models.HTTPRequestRule{
+			Type:      "lua",
+			LuaAction: "mirror",
+			LuaParams: name,
+			Cond:      "if",
+			CondTest:  sampled,
+		},
+	)
+	return nil
+}
+
+// HandleMirrorBackend creates the backend of the mirror Service of the service, if any, and its servers.
+// It is called once the backend of the service is handled.
+func (s *Service) HandleMirrorBackend(k store.K8s, client api.HAProxyClient, a annotations.Annotations) {
+	mirror := s.mirrorService
+	if mirror == nil {
+		return
+	}
+	name, err := mirror.GetBackendName()
+	if err != nil {
+		s.annotationError(k, "mirror-service", err)
+		return
+	}
+	// the backend may already be handled as the backend of an ingress path
+	if !client.BackendUsed(name) {
+		if err = mirror.HandleBackend(k, client, a); err != nil {
+			s.annotationError(k, "mirror-service", err)
+			return
+		}
+	}
+	if _, ok := k.BackendsProcessed[name]; !ok {
+		if mirror.backend == nil {
+			mirror.backend = &models.Backend{BackendBase: models.BackendBase{Name: name}}
+		}
+		mirror.HandleHAProxySrvs(k, client)
+		k.BackendsProcessed[name] = struct{}{}
+	}
+}
+
+// mirrorPath parses a mirror Service given as "name:port", port being a port name or number.
+// The mirror Service must be in namespace, the namespace of the ingress or service being mirrored.
+func mirrorPath(input, namespace string) (*store.IngressPath, error) {
+	service, port, found := strings.Cut(input, ":")
+	if !found || service == "" || port == "" {
+		return nil, fmt.Errorf("invalid mirror service '%s': 'name:port' is expected", input)
+	}
+	if strings.Contains(service, "/") {
+		return nil, fmt.Errorf("invalid mirror service '%s': the mirror service must be in namespace '%s' and given as 'name:port'", input, namespace)
+	}
+	path := &store.IngressPath{SvcNamespace: namespace, SvcName: service}
+	if portInt, err := strconv.ParseInt(port, 10, 64); err == nil {
+		path.SvcPortInt = portInt
+	} else {
+		path.SvcPortString = port
+	}
+	return path, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func TestMirrorPath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *store.IngressPath
+	}{
+		{input: "shadow:http", expected: &store.IngressPath{SvcNamespace: "default", SvcName: "shadow", SvcPortString: "http"}},
+		{input: "shadow:8080", expected: &store.IngressPath{SvcNamespace: "default", SvcName: "shadow", SvcPortInt: 8080}},
+		{input: "shadow"},
+		{input: ":http"},
+		{input: "shadow:"},
+		// mirror Services of other namespaces are not allowed
+		{input: "other/shadow:http"},
+		{input: "default/shadow:http"},
+	}
+	for _, test := range tests {
+		path, err := mirrorPath(test.input, "default")
+		if test.expected == nil {
+			assert.Error(t, err, test.input)
+			continue
+		}
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, path, test.input)
+	}
+}
+
+func newMirrorTestService(t *testing.T, serviceAnnotations, ingressAnnotations map[string]string) (*Service, store.K8s) {
+	t.Helper()
+	k := store.NewK8sStore(utils.OSArgs{})
+	for namespace, names := range map[string][]string{"default": {"app", "shadow"}, "other": {"shadow"}} {
+		ns := k.GetNamespace(namespace)
+		for _, name := range names {
+			ns.Services[name] = &store.Service{Namespace: namespace, Name: name, Ports: []store.ServicePort{{Name: "http", Port: 80}}}
+		}
+	}
+	k.Namespaces["default"].Services["app"].Annotations = serviceAnnotations
+	ingress := &store.Ingress{IngressCore: store.IngressCore{Namespace: "default", Name: "ingress", Annotations: ingressAnnotations}}
+	s, err := New(k, &store.IngressPath{SvcNamespace: "default", SvcName: "app", SvcPortString: "http"}, nil, false, ingress, ingressAnnotations)
+	require.NoError(t, err)
+	return s, k
+}
+
+func TestHandleMirror(t *testing.T) {
+	s, k := newMirrorTestService(t, map[string]string{"mirror-percentage": "25"}, map[string]string{"mirror-service": "shadow:http"})
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_app_http"}}
+	require.NoError(t, s.handleMirror(k, annotations.New(), backend))
+
+	require.NotNil(t, s.mirrorService)
+	name, err := s.mirrorService.GetBackendName()
+	require.NoError(t, err)
+	assert.Equal(t, "default_svc_shadow_http", name)
+	assert.True(t, s.mirrorService.mirror)
+	// the request is forwarded without waiting for its body
+	assert.Equal(t, models.HTTPRequestRules{
+		{Type: "set-var", VarScope: "txn", VarName: "mirror", VarExpr: "bool(true)", Cond: "if", CondTest: "{ rand(100) -m int lt 25 }"},
+		{Type: "lua", LuaAction: "mirror", LuaParams: "default_svc_shadow_http", Cond: "if", CondTest: "{ var(txn.mirror) -m bool }"},
+	}, backend.HTTPRequestRuleList)
+}
+
+func TestHandleMirrorAllRequests(t *testing.T) {
+	s, k := newMirrorTestService(t, map[string]string{"mirror-service": "shadow:80"}, nil)
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_app_http"}}
+	require.NoError(t, s.handleMirror(k, annotations.New(), backend))
+	require.Len(t, backend.HTTPRequestRuleList, 2)
+	assert.Empty(t, backend.HTTPRequestRuleList[0].CondTest)
+}
+
+func TestHandleMirrorNotMirrored(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "no mirror service"},
+		{name: "no request mirrored", annotations: map[string]string{"mirror-service": "shadow:http", "mirror-percentage": "0"}},
+		{name: "invalid percentage", annotations: map[string]string{"mirror-service": "shadow:http", "mirror-percentage": "101"}, wantErr: true},
+		{name: "other namespace", annotations: map[string]string{"mirror-service": "other/shadow:http"}, wantErr: true},
+		{name: "missing service", annotations: map[string]string{"mirror-service": "missing:http"}, wantErr: true},
+		{name: "missing port", annotations: map[string]string{"mirror-service": "shadow:https"}, wantErr: true},
+		{name: "own backend", annotations: map[string]string{"mirror-service": "app:http"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, k := newMirrorTestService(t, nil, tt.annotations)
+			backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_app_http"}}
+			err := s.handleMirror(k, annotations.New(), backend)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Nil(t, s.mirrorService)
+			assert.Empty(t, backend.HTTPRequestRuleList)
+		})
+	}
+
+	// mirror Services and TCP backends don't mirror requests
+	for _, modeTCP := range []bool{true, false} {
+		s, k := newMirrorTestService(t, nil, map[string]string{"mirror-service": "shadow:http"})
+		s.modeTCP = modeTCP
+		s.mirror = !modeTCP
+		backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_app_http"}}
+		require.NoError(t, s.handleMirror(k, annotations.New(), backend))
+		assert.Empty(t, backend.HTTPRequestRuleList)
+	}
+}
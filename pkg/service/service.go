@@ -23,10 +23,12 @@ import (
 
 	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/events"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
 	"github.com/haproxytech/kubernetes-ingress/pkg/rules/acls"
 	"github.com/haproxytech/kubernetes-ingress/pkg/rules/httprequests"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
@@ -42,11 +44,14 @@ type Service struct {
 	path     *store.IngressPath
 	resource *store.Service
 	backend  *models.Backend
+	cache    models.Cache
 	// ingressName      string
 	// ingressNamespace string
 	ingress       *store.Ingress
 	annotations   []map[string]string
 	modeTCP       bool
+	mirror        bool
+	mirrorService *Service
 	newBackend    bool
 	standalone    bool
 	serversToEdit bool
@@ -147,7 +152,18 @@ func (s *Service) HandleBackend(storeK8s store.K8s, client api.HAProxyClient, a
 		return err
 	}
 	s.backend = &newBackend.Backend
+	// resources owning the backend, the errors of the backend section are reported to them
+	if s.isResource() {
+		events.Processed(s.resource.Owner())
+		storeK8s.BackendRC.AddOwner(rc.HaproxyCfgResourceName(newBackend.BackendBase.Name), s.resource.Owner())
+	}
+	if s.ingress != nil && !s.ingress.Faked {
+		storeK8s.BackendRC.AddOwner(rc.HaproxyCfgResourceName(newBackend.BackendBase.Name), s.ingress.Owner())
+	}
 	backend, _ := client.BackendGet(newBackend.BackendBase.Name)
+	if s.cache.Name != nil {
+		instance.ReloadIf(client.CacheCreateOrUpdate(s.cache), "Service '%s/%s': cache '%s' upserted", s.resource.Namespace, s.resource.Name, *s.cache.Name)
+	}
 	// Get/Create Backend
 	diff, created := client.BackendCreateOrUpdate(newBackend.Backend)
 	instance.ReloadIf(len(diff) > 0 || created, "Service '%s/%s': backend '%s' upserted: %v", s.resource.Namespace, s.resource.Name, newBackend.BackendBase.Name, diff)
@@ -174,6 +190,31 @@ func (s *Service) HandleBackend(storeK8s store.K8s, client api.HAProxyClient, a
 	return err
 }
 
+// annotationError logs the error of a backend annotation and reports it on the resource it is set in.
+func (s *Service) annotationError(k store.K8s, name string, err error) {
+	logger.Errorf("service '%s/%s': annotation '%s': %s", s.resource.Namespace, s.resource.Name, name, err)
+	s.reportError(k, name, events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation %s: %w", name, err))
+}
+
+// reportError reports an error on the service, ingress or ConfigMap the annotation is taken from,
+// in this order of precedence.
+func (s *Service) reportError(k store.K8s, name, reason string, err error) {
+	switch {
+	case s.isResource() && s.resource.Annotations[name] != "":
+		events.Error(s.resource.Owner(), reason, err)
+	case s.ingress != nil && !s.ingress.Faked && s.ingress.Annotations[name] != "":
+		events.Error(s.ingress.Owner(), reason, err)
+	case k.ConfigMaps.Main.Annotations[name] != "":
+		events.Error(k.ConfigMaps.Main.Owner(), reason, err)
+	}
+}
+
+// isResource returns true if the service is a Kubernetes resource,
+// the local default service and fake services are created by the controller.
+func (s *Service) isResource() bool {
+	return s.resource.Name != "" && !s.resource.Faked && s.resource.Name != store.DefaultLocalBackend
+}
+
 func isServersToEdit(oldBackend models.Backend, newBackend models.Backend) bool {
 	// Detect if we have a diff on the server line
 	newCookie := newBackend.Cookie
@@ -198,7 +239,10 @@ func (s *Service) getBackendModel(store store.K8s, a annotations.Annotations, cl
 	}
 	// get/create backend Model
 	backend, err = annotations.ModelBackend("cr-backend", s.resource.Namespace, store, s.annotations...)
-	logger.Warning(err)
+	if err != nil {
+		logger.Warning(err)
+		s.reportError(store, "cr-backend", events.REASON_INVALID_ANNOTATION, fmt.Errorf("annotation cr-backend: %w", err))
+	}
 	if backend == nil {
 		backend = &v3.BackendSpec{
 			Backend: models.Backend{
@@ -210,7 +254,7 @@ func (s *Service) getBackendModel(store store.K8s, a annotations.Annotations, cl
 		for _, a := range a.Backend(&backend.Backend, store, s.certs) {
 			err = a.Process(store, s.annotations...)
 			if err != nil {
-				logger.Errorf("service '%s/%s': annotation '%s': %s", s.resource.Namespace, s.resource.Name, a.GetName(), err)
+				s.annotationError(store, a.GetName(), err)
 			}
 		}
 	}
@@ -222,6 +266,21 @@ func (s *Service) getBackendModel(store store.K8s, a annotations.Annotations, cl
 		return nil, err
 	}
 
+	// cache annotations need the backend name, the cache section is named after the backend.
+	s.cache = models.Cache{}
+	if mode == "http" {
+		for _, a := range a.Cache(&backend.Backend, &s.cache) {
+			err = a.Process(store, s.annotations...)
+			if err != nil {
+				s.annotationError(store, a.GetName(), err)
+			}
+		}
+	}
+
+	if err = s.handleMirror(store, a, &backend.Backend); err != nil {
+		s.annotationError(store, "mirror-service", err)
+	}
+
 	servers, err := client.BackendServersGet(backend.BackendBase.Name)
 	if err == nil {
 		for _, server := range servers {
@@ -284,6 +343,7 @@ func (s *Service) SetDefaultBackend(k store.K8s, h haproxy.HAProxy, frontends []
 		}
 	}
 	s.HandleHAProxySrvs(k, h)
+	s.HandleMirrorBackend(k, h, a)
 	return err
 }
 
@@ -4,6 +4,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/k8s/leader"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 	"k8s.io/client-go/kubernetes"
@@ -21,13 +22,18 @@ type UpdateStatusManagerImpl struct {
 	ingressClass      string
 	updateIngresses   []*ingress.Ingress
 	emptyIngressClass bool
+	leaderElector     leader.Elector
+	wasLeader         bool
 }
 
-func New(client *kubernetes.Clientset, ingressClass string, emptyIngressClass bool) UpdateStatusManager {
+// New returns the ingress status manager, statuses are only written when leaderElector reports the replica as leader.
+func New(client *kubernetes.Clientset, ingressClass string, emptyIngressClass bool, leaderElector leader.Elector) UpdateStatusManager {
 	return &UpdateStatusManagerImpl{
 		client:            client,
 		ingressClass:      ingressClass,
 		emptyIngressClass: emptyIngressClass,
+		leaderElector:     leaderElector,
+		wasLeader:         leaderElector.IsLeader(),
 	}
 }
 
@@ -41,9 +47,19 @@ func (m *UpdateStatusManagerImpl) Update(k store.K8s, h haproxy.HAProxy, a annot
 		err = errs.Result()
 	}()
 
+	// Only the leader writes statuses, a newly elected leader checks all ingresses
+	// as their statuses may have been left behind by the previous leader.
+	isLeader := m.leaderElector.IsLeader()
+	newLeader := isLeader && !m.wasLeader
+	m.wasLeader = isLeader
+	if !isLeader {
+		m.updateIngresses = nil
+		return nil
+	}
+
 	ingresses := m.updateIngresses
 
-	if k.UpdateAllIngresses {
+	if k.UpdateAllIngresses || newLeader {
 		ingresses = nil
 		for _, namespace := range k.Namespaces {
 			if !namespace.Relevant {
@@ -124,6 +124,42 @@ func (k *K8s) EventTCPRoute(ns *Namespace, data *TCPRoute) (updateRequired bool)
 	return updateRequired
 }
 
+func (k *K8s) EventHTTPRoute(ns *Namespace, data *HTTPRoute) (updateRequired bool) {
+	switch data.Status {
+	case ADDED:
+		if previous := ns.HTTPRoutes[data.Name]; previous != nil {
+			logger.Warningf("Replacing existing httproute %s", data.Name)
+		}
+		ns.HTTPRoutes[data.Name] = data
+		updateRequired = true
+	case DELETED:
+		if previous := ns.HTTPRoutes[data.Name]; previous == nil {
+			logger.Warningf("Trying to delete unexisting httproute %s", data.Name)
+			return updateRequired
+		}
+		// We can't remove directly because we need the listener attached to this route to be updated.
+		ns.HTTPRoutes[data.Name] = data
+		updateRequired = true
+	case MODIFIED:
+		newHTTPRoute := data
+		oldHTTPRoute, ok := ns.HTTPRoutes[data.Name]
+		if !ok {
+			// It can happen (resync) that we receive an UPDATE on a item that is not yet registered
+			// We should treat it as a CREATE.
+			logger.Warningf("Modification of unexisting httproute %s", data.Name)
+			data.Status = ADDED
+			return k.EventHTTPRoute(ns, data)
+		}
+		if ok && newHTTPRoute.Generation == oldHTTPRoute.Generation ||
+			newHTTPRoute.Equal(oldHTTPRoute) {
+			return false
+		}
+		ns.HTTPRoutes[data.Name] = newHTTPRoute
+		updateRequired = true
+	}
+	return updateRequired
+}
+
 func (k *K8s) EventReferenceGrant(ns *Namespace, data *ReferenceGrant) (updateRequired bool) {
 	switch data.Status {
 	case ADDED:
@@ -158,3 +194,62 @@ func (k *K8s) EventReferenceGrant(ns *Namespace, data *ReferenceGrant) (updateRe
 	}
 	return updateRequired
 }
+
+func (k *K8s) EventBackendTLSPolicy(ns *Namespace, data *BackendTLSPolicy) (updateRequired bool) {
+	switch data.Status {
+	case ADDED:
+		if previous := ns.BackendTLSPolicies[data.Name]; previous != nil {
+			logger.Warningf("Replacing existing backendtlspolicy %s", data.Name)
+		}
+		ns.BackendTLSPolicies[data.Name] = data
+		updateRequired = true
+	case DELETED:
+		if previous := ns.BackendTLSPolicies[data.Name]; previous == nil {
+			logger.Warningf("Trying to delete unexisting backendtlspolicy %s", data.Name)
+			return updateRequired
+		}
+		delete(ns.BackendTLSPolicies, data.Name)
+		updateRequired = true
+	case MODIFIED:
+		newBackendTLSPolicy := data
+		oldBackendTLSPolicy, ok := ns.BackendTLSPolicies[data.Name]
+		if !ok {
+			// It can happen (resync) that we receive an UPDATE on a item that is not yet registered
+			// We should treat it as a CREATE.
+			logger.Warningf("Modification of unexisting backendtlspolicy %s", data.Name)
+			data.Status = ADDED
+			return k.EventBackendTLSPolicy(ns, data)
+		}
+		if ok && newBackendTLSPolicy.Generation == oldBackendTLSPolicy.Generation ||
+			newBackendTLSPolicy.Equal(oldBackendTLSPolicy) {
+			return updateRequired
+		}
+		ns.BackendTLSPolicies[data.Name] = newBackendTLSPolicy
+		updateRequired = true
+	}
+	if updateRequired {
+		ns.pruneCABundles()
+	}
+	return updateRequired
+}
+
+// isCABundleReferenced tells if a backendtlspolicy of the namespace refers to the configmap as CA certificate.
+func (ns *Namespace) isCABundleReferenced(name string) bool {
+	for _, policy := range ns.BackendTLSPolicies {
+		for _, caRef := range policy.CACertificateRefs {
+			if caRef.Group == "" && caRef.Kind == "ConfigMap" && caRef.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pruneCABundles removes the CA bundles no backendtlspolicy of the namespace refers to anymore.
+func (ns *Namespace) pruneCABundles() {
+	for name := range ns.CABundles {
+		if !ns.isCABundleReferenced(name) {
+			delete(ns.CABundles, name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventCABundleReferencedOnly(t *testing.T) {
+	k := NewK8sStore(utils.OSArgs{})
+	ns := k.GetNamespace("default")
+	caBundle := func(name string, status Status) *ConfigMap {
+		return &ConfigMap{Namespace: "default", Name: name, Status: status, Annotations: map[string]string{CABundleKey: "-----BEGIN CERTIFICATE-----"}}
+	}
+
+	// configmaps received before any policy refers to them are ignored.
+	assert.False(t, k.EventConfigMap(ns, caBundle("kube-root-ca.crt", ADDED)))
+	assert.False(t, k.EventConfigMap(ns, caBundle("backend-ca", ADDED)))
+	assert.Empty(t, ns.CABundles)
+
+	policy := &BackendTLSPolicy{
+		Namespace:         "default",
+		Name:              "backend-tls",
+		Status:            ADDED,
+		CACertificateRefs: []LocalObjectRef{{Kind: "ConfigMap", Name: "backend-ca"}},
+	}
+	assert.True(t, k.EventBackendTLSPolicy(ns, policy))
+	// the informer sends again the referenced configmap once the policy is received.
+	assert.True(t, k.EventConfigMap(ns, caBundle("backend-ca", MODIFIED)))
+	assert.False(t, k.EventConfigMap(ns, caBundle("kube-root-ca.crt", MODIFIED)))
+	assert.Contains(t, ns.CABundles, "backend-ca")
+	assert.NotContains(t, ns.CABundles, "kube-root-ca.crt")
+	assert.False(t, k.EventConfigMap(ns, caBundle("backend-ca", MODIFIED)), "unchanged CA bundle")
+
+	// a configmap referenced by a policy with another kind is not a CA bundle.
+	assert.True(t, k.EventBackendTLSPolicy(ns, &BackendTLSPolicy{
+		Namespace:         "default",
+		Name:              "backend-tls",
+		Status:            MODIFIED,
+		Generation:        2,
+		CACertificateRefs: []LocalObjectRef{{Kind: "Secret", Name: "backend-ca"}},
+	}))
+	assert.Empty(t, ns.CABundles, "CA bundle no longer referenced")
+	assert.False(t, k.EventConfigMap(ns, caBundle("backend-ca", MODIFIED)))
+
+	assert.True(t, k.EventBackendTLSPolicy(ns, policy))
+	assert.True(t, k.EventConfigMap(ns, caBundle("backend-ca", MODIFIED)))
+	assert.True(t, k.EventConfigMap(ns, caBundle("backend-ca", DELETED)))
+	assert.Empty(t, ns.CABundles)
+
+	assert.True(t, k.EventConfigMap(ns, caBundle("backend-ca", ADDED)))
+	policy.Status = DELETED
+	assert.True(t, k.EventBackendTLSPolicy(ns, policy))
+	assert.Empty(t, ns.CABundles, "policy deleted")
+}
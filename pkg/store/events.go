@@ -280,7 +280,9 @@ func (k *K8s) EventConfigMap(ns *Namespace, data *ConfigMap) (updateRequired boo
 	case k.ConfigMaps.PatternFiles.Namespace == ns.Name && k.ConfigMaps.PatternFiles.Name == data.Name:
 		cm = k.ConfigMaps.PatternFiles
 	default:
-		return false
+		caBundleUpdated := k.eventCABundle(ns, data)
+		jwksUpdated := k.eventJWKS(ns, data)
+		return caBundleUpdated || jwksUpdated
 	}
 	switch data.Status {
 	case ADDED:
@@ -312,6 +314,44 @@ func (k *K8s) EventConfigMap(ns *Namespace, data *ConfigMap) (updateRequired boo
 	return updateRequired
 }
 
+// eventCABundle keeps track of configmaps holding a CA bundle in their ca.crt entry
+// which are referenced by Gateway API BackendTLSPolicies of the namespace.
+func (k *K8s) eventCABundle(ns *Namespace, data *ConfigMap) (updateRequired bool) {
+	old, known := ns.CABundles[data.Name]
+	_, isCABundle := data.Annotations[CABundleKey]
+	if data.Status == DELETED || !isCABundle || !ns.isCABundleReferenced(data.Name) {
+		if known {
+			delete(ns.CABundles, data.Name)
+			updateRequired = true
+		}
+		return updateRequired
+	}
+	if known && old.Equal(data) {
+		return false
+	}
+	ns.CABundles[data.Name] = data
+	return true
+}
+
+// eventJWKS keeps track of configmaps holding a JSON Web Key Set in their jwks.json entry,
+// they can be referenced by the jwt-jwks annotation.
+func (k *K8s) eventJWKS(ns *Namespace, data *ConfigMap) (updateRequired bool) {
+	old, known := ns.JWKS[data.Name]
+	_, isJWKS := data.Annotations[JWKSKey]
+	if data.Status == DELETED || !isJWKS {
+		if known {
+			delete(ns.JWKS, data.Name)
+			updateRequired = true
+		}
+		return updateRequired
+	}
+	if known && old.Equal(data) {
+		return false
+	}
+	ns.JWKS[data.Name] = data
+	return true
+}
+
 func (k *K8s) EventSecret(ns *Namespace, data *Secret) (updateRequired bool) {
 	updateRequired = false
 	switch data.Status {
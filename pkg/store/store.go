@@ -27,6 +27,8 @@ import (
 const (
 	DefaultLocalBackend = "default-local-service"
 	CONTROLLER          = "haproxy.org/ingress-controller"
+	CABundleKey         = "ca.crt"
+	JWKSKey             = "jwks.json"
 )
 
 type K8s struct {
@@ -40,6 +42,7 @@ type K8s struct {
 	HaProxyPods                  map[string]struct{}
 	BackendsWithNoConfigSnippets map[string]struct{}
 	FrontendRC                   *rc.ResourceCounter
+	BackendRC                    *rc.ResourceCounter // resources owning the backends of the running sync
 	GatewayControllerName        string
 	PublishServiceAddresses      []string
 	UpdateAllIngresses           bool
@@ -87,6 +90,7 @@ func NewK8sStore(args utils.OSArgs) K8s {
 		BackendsWithNoConfigSnippets: map[string]struct{}{},
 		HaProxyPods:                  map[string]struct{}{},
 		FrontendRC:                   rc.NewResourceCounter(),
+		BackendRC:                    rc.NewResourceCounter(),
 		IngressesByService:           map[string]*utils.OrderedSet[string, *Ingress]{},
 	}
 	for _, namespace := range args.NamespaceWhitelist {
@@ -139,6 +143,12 @@ func (k *K8s) Clean() {
 				data.Status = EMPTY
 			}
 		}
+		for _, data := range namespace.CABundles {
+			data.Status = EMPTY
+		}
+		for _, data := range namespace.JWKS {
+			data.Status = EMPTY
+		}
 		for _, cr := range namespace.CRs.TCPsPerCR {
 			switch cr.Status {
 			case DELETED:
@@ -184,11 +194,15 @@ func (k K8s) GetNamespace(name string) *Namespace {
 			Backends:  make(map[string]*v3.BackendSpec),
 			TCPsPerCR: make(map[string]*TCPs),
 		},
-		Gateways:        make(map[string]*Gateway),
-		TCPRoutes:       make(map[string]*TCPRoute),
-		ReferenceGrants: make(map[string]*ReferenceGrant),
-		Labels:          make(map[string]string),
-		Status:          ADDED,
+		Gateways:           make(map[string]*Gateway),
+		TCPRoutes:          make(map[string]*TCPRoute),
+		HTTPRoutes:         make(map[string]*HTTPRoute),
+		ReferenceGrants:    make(map[string]*ReferenceGrant),
+		BackendTLSPolicies: make(map[string]*BackendTLSPolicy),
+		CABundles:          make(map[string]*ConfigMap),
+		JWKS:               make(map[string]*ConfigMap),
+		Labels:             make(map[string]string),
+		Status:             ADDED,
 	}
 	k.Namespaces[name] = newNamespace
 	return newNamespace
@@ -215,7 +215,18 @@ func (listener *Listener) Equal(other *Listener) bool {
 		listener.Port == other.Port &&
 		listener.Protocol == other.Protocol &&
 		utils.EqualPointers(listener.Hostname, other.Hostname) &&
-		listener.AllowedRoutes.Equal(other.AllowedRoutes))
+		listener.AllowedRoutes.Equal(other.AllowedRoutes) &&
+		listener.TLS.Equal(other.TLS))
+}
+
+func (tls *ListenerTLS) Equal(other *ListenerTLS) bool {
+	return tls == nil && other == nil ||
+		(NoNilPointer(tls, other) && utils.EqualPointers(tls.Mode, other.Mode) && utils.EqualMap(tls.Options, other.Options) &&
+			utils.EqualSlice(tls.CertificateRefs, other.CertificateRefs))
+}
+
+func (ref SecretObjectRef) Equal(other SecretObjectRef, opt ...models.Options) bool {
+	return ref.Group == other.Group && ref.Kind == other.Kind && ref.Name == other.Name && utils.EqualPointers(ref.Namespace, other.Namespace)
 }
 
 func (ar *AllowedRoutes) Equal(other *AllowedRoutes) bool {
@@ -240,6 +251,26 @@ func (tcp *TCPRoute) Equal(other *TCPRoute) bool {
 		ParentRefs(tcp.ParentRefs).Equal(other.ParentRefs)
 }
 
+func (http *HTTPRoute) Equal(other *HTTPRoute) bool {
+	return http == nil && other == nil || (NoNilPointer(http, other) &&
+		http.Name == other.Name && http.Namespace == other.Namespace &&
+		utils.EqualSliceComparable(http.Hostnames, other.Hostnames) &&
+		ParentRefs(http.ParentRefs).Equal(other.ParentRefs) &&
+		utils.EqualSlice(http.Rules, other.Rules))
+}
+
+func (rule HTTPRouteRule) Equal(other HTTPRouteRule, opt ...models.Options) bool {
+	return rule.Timeouts.Equal(other.Timeouts) &&
+		utils.EqualSliceComparable(rule.Matches, other.Matches) &&
+		BackendRefs(rule.BackendRefs).Equal(other.BackendRefs) &&
+		utils.EqualSliceComparable(rule.Unsupported, other.Unsupported)
+}
+
+func (timeouts *HTTPRouteTimeouts) Equal(other *HTTPRouteTimeouts) bool {
+	return timeouts == nil && other == nil || (NoNilPointer(timeouts, other) &&
+		utils.EqualPointers(timeouts.Request, other.Request) && utils.EqualPointers(timeouts.BackendRequest, other.BackendRequest))
+}
+
 type BackendRefs []BackendRef
 
 func (refs BackendRefs) Equal(other BackendRefs) bool {
@@ -303,6 +334,17 @@ func (rf *ReferenceGrant) Equal(other *ReferenceGrant) bool {
 		(NoNilPointer(rf, other) && rf.Namespace == other.Namespace && rf.Name == other.Name && utils.EqualSliceComparable(rf.From, other.From) && utils.EqualSlice(rf.To, other.To))
 }
 
+func (ref PolicyTargetRef) Equal(other PolicyTargetRef, opt ...models.Options) bool {
+	return ref.Group == other.Group && ref.Kind == other.Kind && ref.Name == other.Name && utils.EqualPointers(ref.SectionName, other.SectionName)
+}
+
+func (policy *BackendTLSPolicy) Equal(other *BackendTLSPolicy) bool {
+	return policy == nil && other == nil ||
+		(NoNilPointer(policy, other) && policy.Namespace == other.Namespace && policy.Name == other.Name &&
+			policy.Hostname == other.Hostname && utils.EqualPointers(policy.WellKnownCACertificates, other.WellKnownCACertificates) &&
+			utils.EqualSlice(policy.TargetRefs, other.TargetRefs) && utils.EqualSliceComparable(policy.CACertificateRefs, other.CACertificateRefs))
+}
+
 type ParentRefs []ParentRef
 
 func (refs ParentRefs) Equal(other ParentRefs) bool {
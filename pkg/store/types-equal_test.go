@@ -152,6 +152,18 @@ func TestListenerEquality(t *testing.T) {
 			listener1: &Listener{Name: "listener", Port: 1048, Protocol: "", AllowedRoutes: &AllowedRoutes{Namespaces: &RouteNamespaces{From: &all}}},
 			listener2: &Listener{Name: "listener", Port: 1048, Protocol: TCPProtocolType}, description: "Two different Listener by AllowedRoutes",
 		},
+		{
+			expected:    false,
+			listener1:   &Listener{Name: "listener", Port: 1048, Protocol: "TLS", TLS: &ListenerTLS{CertificateRefs: []SecretObjectRef{{Kind: "Secret", Name: "cert1"}}}},
+			listener2:   &Listener{Name: "listener", Port: 1048, Protocol: "TLS", TLS: &ListenerTLS{CertificateRefs: []SecretObjectRef{{Kind: "Secret", Name: "cert2"}}}},
+			description: "Two different Listener by TLS certificateRefs",
+		},
+		{
+			expected:    false,
+			listener1:   &Listener{Name: "listener", Port: 1048, Protocol: "TLS", TLS: &ListenerTLS{Options: map[string]string{"haproxy.org/ssl-min-ver": "TLSv1.2"}}},
+			listener2:   &Listener{Name: "listener", Port: 1048, Protocol: "TLS", TLS: &ListenerTLS{Options: map[string]string{"haproxy.org/ssl-min-ver": "TLSv1.3"}}},
+			description: "Two different Listener by TLS options",
+		},
 	}
 
 	for _, test := range tests {
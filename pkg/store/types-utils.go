@@ -34,3 +34,12 @@ func (tcproutes TCPRoutes) Less(i, j int) bool {
 	}
 	return tcprouteI.Namespace+tcprouteI.Name < tcprouteJ.Namespace+tcprouteJ.Name
 }
+
+func (httproutes HTTPRoutes) Less(i, j int) bool {
+	httprouteI := httproutes[i]
+	httprouteJ := httproutes[j]
+	if !httprouteI.CreationTime.Equal(httprouteJ.CreationTime) {
+		return httprouteI.CreationTime.Before(httprouteJ.CreationTime)
+	}
+	return httprouteI.Namespace+httprouteI.Name < httprouteJ.Namespace+httprouteJ.Name
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/haproxytech/client-native/v6/models"
 	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	rc "github.com/haproxytech/kubernetes-ingress/pkg/reference-counter"
 )
 
 // ServicePort describes port of a service
@@ -76,6 +77,10 @@ type Service struct {
 	Faked       bool
 }
 
+func (s Service) Owner() rc.Owner {
+	return rc.NewOwner(rc.SERVICE, s.Namespace, s.Name)
+}
+
 // RuntimeBackend holds the runtime state of an HAProxy backend
 type RuntimeBackend struct {
 	Endpoints       PortEndpoints
@@ -96,7 +101,11 @@ type Namespace struct {
 	CRs                      *CustomResources
 	Gateways                 map[string]*Gateway
 	TCPRoutes                map[string]*TCPRoute
+	HTTPRoutes               map[string]*HTTPRoute
 	ReferenceGrants          map[string]*ReferenceGrant
+	BackendTLSPolicies       map[string]*BackendTLSPolicy
+	CABundles                map[string]*ConfigMap // configmaps holding a ca.crt entry
+	JWKS                     map[string]*ConfigMap // configmaps holding a jwks.json entry
 	Labels                   map[string]string
 	Name                     string
 	Status                   Status
@@ -147,6 +156,10 @@ type Ingress struct {
 	Faked        bool
 }
 
+func (i Ingress) Owner() rc.Owner {
+	return rc.NewOwner(rc.INGRESS, i.Namespace, i.Name)
+}
+
 // IngressTLS describes the transport layer security associated with an Ingress.
 type IngressTLS struct {
 	Host       string
@@ -169,6 +182,10 @@ type ConfigMap struct {
 	Loaded      bool
 }
 
+func (c ConfigMap) Owner() rc.Owner {
+	return rc.NewOwner(rc.CONFIGMAP, c.Namespace, c.Name)
+}
+
 // Secret is useful data from k8s structures about secret
 type Secret struct {
 	Namespace string
@@ -208,6 +225,7 @@ type Gateway struct {
 type Listener struct {
 	Hostname      *string
 	AllowedRoutes *AllowedRoutes
+	TLS           *ListenerTLS
 	Name          string
 	Protocol      string
 	GwNamespace   string
@@ -215,6 +233,19 @@ type Listener struct {
 	Port          int32
 }
 
+type ListenerTLS struct {
+	Mode            *string
+	Options         map[string]string
+	CertificateRefs []SecretObjectRef
+}
+
+type SecretObjectRef struct {
+	Namespace *string
+	Group     string
+	Kind      string
+	Name      string
+}
+
 type AllowedRoutes struct {
 	Namespaces *RouteNamespaces
 	Kinds      []RouteGroupKind
@@ -240,6 +271,37 @@ type TCPRoute struct {
 	Generation   int64
 }
 
+type HTTPRoute struct {
+	CreationTime time.Time
+	Name         string
+	Namespace    string
+	Status       Status
+	Hostnames    []string
+	ParentRefs   []ParentRef
+	Rules        []HTTPRouteRule
+	Generation   int64
+}
+
+type HTTPRouteRule struct {
+	Timeouts    *HTTPRouteTimeouts
+	Matches     []HTTPRouteMatch
+	BackendRefs []BackendRef
+	// Unsupported lists the features of the rule the controller can't translate (filters, header matches, ...).
+	Unsupported []string
+}
+
+type HTTPRouteMatch struct {
+	PathType  string
+	PathValue string
+}
+
+type HTTPRouteTimeouts struct {
+	Request        *string
+	BackendRequest *string
+}
+
+type HTTPRoutes []HTTPRoute
+
 type BackendRef struct {
 	Namespace *string
 	Port      *int32
@@ -279,6 +341,30 @@ type ReferenceGrant struct {
 	Generation int64
 }
 
+type BackendTLSPolicy struct {
+	WellKnownCACertificates *string
+	Namespace               string
+	Name                    string
+	Hostname                string
+	Status                  Status
+	TargetRefs              []PolicyTargetRef
+	CACertificateRefs       []LocalObjectRef
+	Generation              int64
+}
+
+type PolicyTargetRef struct {
+	SectionName *string
+	Group       string
+	Kind        string
+	Name        string
+}
+
+type LocalObjectRef struct {
+	Group string
+	Kind  string
+	Name  string
+}
+
 type ReferenceGrantFrom struct {
 	Group     string
 	Kind      string
@@ -117,9 +117,19 @@ type OSArgs struct {
 	DisableIPV6                       bool           `long:"disable-ipv6" description:"toggle to disable the IPv6 protocol from all frontends"`
 	UseWithPebble                     bool           `long:"with-pebble" description:"use pebble to start/stop/reload HAProxy"`
 	JobCheckCRD                       bool           `long:"job-check-crd" description:"does not execute IC, but adds/updates CRDs"`
+	LeaderElection                    bool           `long:"leader-election" description:"enable Lease based leader election so that only one replica updates statuses and refreshes CRDs"`
+	LeaderElectionID                  string         `long:"leader-election-id" default:"haproxy-kubernetes-ingress-leader" description:"name of the Lease used for leader election, created in the controller namespace"`
+	LeaderElectionLeaseDuration       time.Duration  `long:"leader-election-lease-duration" default:"15s" description:"duration non-leader replicas wait before trying to acquire an unrenewed Lease"`
+	LeaderElectionRenewDeadline       time.Duration  `long:"leader-election-renew-deadline" default:"10s" description:"duration the leader retries refreshing the Lease before giving up leadership"`
+	LeaderElectionRetryPeriod         time.Duration  `long:"leader-election-retry-period" default:"2s" description:"duration replicas wait between Lease actions"`
 	Experimental                      Experimental   `long:"experimental" description:"comma separated list of experimental features to activate"`
 	DisableQuic                       bool           `long:"disable-quic" description:"disable quic protocol in http frontend bindings"`
 	DisableDelayedWritingOnlyIfReload bool           `long:"disable-writing-only-if-reload" description:"disable the delayed writing of files to disk only in case of haproxy reload (=write files to disk even if no reload)"`
 	CRDInputFile                      string         `long:"input-file" description:"The file path of a CRD manifest to convert"`
 	CRDOutputFile                     string         `long:"output-file" description:"The file path of the converted (to the most recent version) CRD manifest"`
+	RenderManifests                   []string       `long:"render-manifests" description:"render the HAProxy configuration of Kubernetes manifest files or directories without cluster, repeat the flag for several paths"`
+	RenderOutputDir                   string         `long:"render-output-dir" default:"haproxy-render" description:"directory where haproxy.cfg, maps and certificates are written when rendering manifests"`
+	WebhookPort                       int            `long:"webhook-port" description:"port to listen on for the validating admission webhook of Ingresses, Services, ConfigMaps and custom resources, disabled if not set"`
+	WebhookCertFile                   string         `long:"webhook-cert-file" default:"/etc/haproxy-webhook/tls.crt" description:"TLS certificate of the validating admission webhook, reloaded when modified"`
+	WebhookKeyFile                    string         `long:"webhook-key-file" default:"/etc/haproxy-webhook/tls.key" description:"TLS private key of the validating admission webhook, reloaded when modified"`
 }
@@ -0,0 +1,189 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// alertLine matches the location of an HAProxy alert, like "parsing [/etc/haproxy/haproxy.cfg:42] : ..."
+var alertLine = regexp.MustCompile(`\[([^\[\]]+):(\d+)\]\s*:?\s*(.*)`)
+
+// snippet is a config snippet annotation to check in its section of the configuration.
+type snippet struct {
+	annotation string
+	value      string
+	section    annotations.CfgSnippetType
+	// frontends the snippet is inserted in, for frontend snippets
+	frontends []string
+}
+
+// snippetRange is the position of a snippet in the checked configuration, lines start at 1.
+type snippetRange struct {
+	annotation string
+	first      int
+	last       int
+}
+
+// checkSnippets inserts the snippets in a copy of the current configuration and checks it with HAProxy,
+// the alerts HAProxy reports in the lines of a snippet are returned with the line of the annotation.
+func (w *Webhook) checkSnippets(snippets ...snippet) error {
+	headers := map[string][]snippet{}
+	var backendSnippets []snippet
+	for _, s := range snippets {
+		if strings.TrimSpace(s.value) == "" || annotations.IsConfigSnippetDisabled(s.section) {
+			continue
+		}
+		switch s.section {
+		case annotations.ConfigSnippetGlobal:
+			headers["global"] = append(headers["global"], s)
+		case annotations.ConfigSnippetFrontend:
+			for _, frontend := range s.frontends {
+				headers["frontend "+frontend] = append(headers["frontend "+frontend], s)
+			}
+		case annotations.ConfigSnippetBackend:
+			backendSnippets = append(backendSnippets, s)
+		}
+	}
+	if len(headers) == 0 && len(backendSnippets) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(w.env.MainCFGFile)
+	if err != nil {
+		return fmt.Errorf("unable to check config snippets: %w", err)
+	}
+	var lines []string
+	var ranges []snippetRange
+	insert := func(s snippet) {
+		r := snippetRange{annotation: s.annotation, first: len(lines) + 1}
+		for _, line := range strings.Split(strings.Trim(s.value, "\n"), "\n") {
+			lines = append(lines, "  "+strings.TrimSpace(line))
+		}
+		r.last = len(lines)
+		ranges = append(ranges, r)
+	}
+
+	// the snippets replace the ones of their section in the current configuration
+	var inserted bool
+	var inSnippet bool
+	for _, line := range strings.Split(string(content), "\n") {
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "#") {
+			header := strings.Join(strings.Fields(line), " ")
+			lines = append(lines, line)
+			sectionSnippets, ok := headers[header]
+			inserted = ok
+			for _, s := range sectionSnippets {
+				insert(s)
+			}
+			delete(headers, header)
+			continue
+		}
+		if inserted {
+			switch line {
+			case annotations.COMMENT_CFG_SNIPPET_BEGIN:
+				inSnippet = true
+				continue
+			case annotations.COMMENT_CFG_SNIPPET_END:
+				inSnippet = false
+				continue
+			}
+			if inSnippet {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	// sections missing from the current configuration
+	for header, sectionSnippets := range headers {
+		lines = append(lines, header, "  mode http")
+		for _, s := range sectionSnippets {
+			insert(s)
+		}
+	}
+	if len(backendSnippets) > 0 {
+		lines = append(lines, "backend webhook-check", "  mode http")
+		for _, s := range backendSnippets {
+			insert(s)
+		}
+	}
+
+	file, err := os.CreateTemp(w.env.CfgDir, "webhook-*.cfg")
+	if err != nil {
+		return fmt.Errorf("unable to check config snippets: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(strings.Join(lines, "\n") + "\n")
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("unable to check config snippets: %w", err)
+	}
+
+	args := []string{"-c", "-f", file.Name()}
+	if _, errStat := os.Stat(w.env.AuxCFGFile); errStat == nil {
+		args = append(args, "-f", w.env.AuxCFGFile)
+	}
+	//nolint:gosec // the binary is the HAProxy binary of the controller
+	output, err := exec.Command(w.env.Binary, args...).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	return snippetErrors(string(output), file.Name(), ranges, err)
+}
+
+// snippetErrors returns the alerts of the HAProxy check, located in their annotation when possible.
+func snippetErrors(output, fileName string, ranges []snippetRange, checkErr error) error {
+	var errs utils.Errors
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "[ALERT]") || strings.Contains(line, "Fatal errors found") {
+			continue
+		}
+		match := alertLine.FindStringSubmatch(line)
+		if match == nil || match[1] != fileName {
+			if i := strings.Index(line, " : "); i >= 0 {
+				line = line[i+3:]
+			}
+			errs.Add(errors.New(strings.TrimSpace(line)))
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(match[2])
+		located := false
+		for _, r := range ranges {
+			if lineNumber >= r.first && lineNumber <= r.last {
+				errs.Add(fmt.Errorf("annotation '%s' line %d: %s", r.annotation, lineNumber-r.first+1, match[3]))
+				located = true
+				break
+			}
+		}
+		if !located {
+			errs.Add(errors.New(strings.TrimSpace(match[3])))
+		}
+	}
+	if errs.Result() == nil {
+		return fmt.Errorf("config snippets check failed: %w: %s", checkErr, strings.TrimSpace(output))
+	}
+	return errs.Result()
+}
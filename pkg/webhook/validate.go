@@ -0,0 +1,286 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/haproxytech/client-native/v6/models"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// referenceAnnotations reference resources which may be created after the validated one,
+// only the format of their references is checked.
+var referenceAnnotations = map[string]struct{}{
+	"auth-secret": {},
+	"jwt-secret":  {},
+	"jwt-jwks":    {},
+	"client-ca":   {},
+	"client-crl":  {},
+	"server-ca":   {},
+	"server-crt":  {},
+}
+
+// validate returns the errors of a resource, resources not handled by the controller are valid.
+func (w *Webhook) validate(kind metav1.GroupVersionKind, namespace string, raw []byte) error {
+	switch kind.Group + "/" + kind.Kind {
+	case "networking.k8s.io/Ingress":
+		data := &networkingv1.Ingress{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		if data.Namespace == "" {
+			data.Namespace = namespace
+		}
+		return w.validateIngress(data)
+	case "/Service":
+		data := &corev1.Service{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		return w.validateService(data)
+	case "/ConfigMap":
+		data := &corev1.ConfigMap{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		if data.Namespace == "" {
+			data.Namespace = namespace
+		}
+		if data.Namespace != w.osArgs.ConfigMap.Namespace || data.Name != w.osArgs.ConfigMap.Name {
+			return nil
+		}
+		return w.validateConfigMap(data)
+	case "ingress.v3.haproxy.org/Global":
+		data := &v3.Global{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		return data.Spec.Global.Validate(nil)
+	case "ingress.v3.haproxy.org/Defaults":
+		data := &v3.Defaults{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		return data.Spec.Defaults.Validate(nil)
+	case "ingress.v3.haproxy.org/Backend":
+		data := &v3.Backend{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		return data.Spec.Backend.Validate(nil)
+	case "ingress.v3.haproxy.org/TCP":
+		data := &v3.TCP{}
+		if err := json.Unmarshal(raw, data); err != nil {
+			return err
+		}
+		return validateTCP(data)
+	}
+	return nil
+}
+
+func (w *Webhook) validateIngress(data *networkingv1.Ingress) error {
+	ingress, err := store.ConvertToIngress(data)
+	if err != nil {
+		return err
+	}
+	k := store.NewK8sStore(w.osArgs)
+	if w.client != nil {
+		// the IngressClasses are needed to know if the Ingress is handled by the controller
+		ingressClasses, errList := w.client.NetworkingV1().IngressClasses().List(context.Background(), metav1.ListOptions{})
+		if errList != nil {
+			return fmt.Errorf("unable to check the class of the Ingress: %w", errList)
+		}
+		for i := range ingressClasses.Items {
+			ingressClass, errConvert := store.ConvertToIngressClass(&ingressClasses.Items[i])
+			if errConvert == nil {
+				k.IngressClasses[ingressClass.Name] = ingressClass
+			}
+		}
+	}
+	if !k.IsIngressClassSupported(ingress.Class, w.osArgs.IngressClass, w.osArgs.EmptyIngressClass) {
+		return nil
+	}
+	v := newValidation(k, ingress.Annotations)
+	result := rules.List{}
+	v.process(w.annotations.Frontend(ingress, &result, discardMaps{}, nil))
+	v.process(w.annotations.ClientAuth(&certs.ClientAuth{}, ingress, &result, nil))
+	v.process(w.annotations.SecurityHeaders(&result))
+	v.process(w.annotations.TLSPolicy(&certs.TLSPolicy{}))
+	v.process(w.annotations.Canary(&[]string{}))
+	v.process(w.annotations.RouteMatch(&[]string{}))
+	// the annotations of an Ingress apply to the backends of its Services
+	v.backend(w.annotations)
+	v.errs.Add(w.checkSnippets(snippet{
+		annotation: "backend-config-snippet",
+		value:      ingress.Annotations["backend-config-snippet"],
+		section:    annotations.ConfigSnippetBackend,
+	}))
+	return v.errs.Result()
+}
+
+func (w *Webhook) validateService(data *corev1.Service) error {
+	v := newValidation(store.NewK8sStore(w.osArgs), store.CopyAnnotations(data.Annotations))
+	v.backend(w.annotations)
+	v.errs.Add(w.checkSnippets(snippet{
+		annotation: "backend-config-snippet",
+		value:      v.resource["backend-config-snippet"],
+		section:    annotations.ConfigSnippetBackend,
+	}))
+	return v.errs.Result()
+}
+
+func (w *Webhook) validateConfigMap(data *corev1.ConfigMap) error {
+	v := newValidation(store.NewK8sStore(w.osArgs), store.CopyAnnotations(data.Data))
+	global := &models.Global{}
+	logTargets := models.LogTargets{}
+	v.process(w.annotations.Global(global, &logTargets))
+	v.validateModel("global", global)
+	defaults := &models.Defaults{}
+	v.process(w.annotations.Defaults(defaults))
+	v.validateModel("defaults", defaults)
+	result := rules.List{}
+	v.process(w.annotations.Frontend(nil, &result, discardMaps{}, nil))
+	v.process(w.annotations.SecurityHeaders(&result))
+	v.process(w.annotations.TLSPolicy(&certs.TLSPolicy{}))
+	v.backend(w.annotations)
+	v.errs.Add(w.checkSnippets(
+		snippet{
+			annotation: "global-config-snippet",
+			value:      v.resource["global-config-snippet"],
+			section:    annotations.ConfigSnippetGlobal,
+		},
+		snippet{
+			annotation: "frontend-config-snippet",
+			value:      v.resource["frontend-config-snippet"],
+			section:    annotations.ConfigSnippetFrontend,
+			frontends:  []string{w.env.FrontHTTP, w.env.FrontHTTPS},
+		},
+		snippet{
+			annotation: "stats-config-snippet",
+			value:      v.resource["stats-config-snippet"],
+			section:    annotations.ConfigSnippetFrontend,
+			frontends:  []string{"stats"},
+		},
+		snippet{
+			annotation: "backend-config-snippet",
+			value:      v.resource["backend-config-snippet"],
+			section:    annotations.ConfigSnippetBackend,
+		},
+	))
+	return v.errs.Result()
+}
+
+func validateTCP(data *v3.TCP) error {
+	var errs utils.Errors
+	names := map[string]struct{}{}
+	for _, tcp := range data.Spec {
+		if _, ok := names[tcp.Name]; ok {
+			errs.Add(fmt.Errorf("TCP '%s': duplicate name", tcp.Name))
+		}
+		names[tcp.Name] = struct{}{}
+		if tcp.Service.Name == "" {
+			errs.Add(fmt.Errorf("TCP '%s': service name is required", tcp.Name))
+		}
+		if err := tcp.Frontend.Validate(nil); err != nil {
+			errs.Add(fmt.Errorf("TCP '%s': frontend: %w", tcp.Name, err))
+		}
+	}
+	return errs.Result()
+}
+
+// validation collects the errors of the annotations of a resource.
+type validation struct {
+	store    store.K8s
+	resource map[string]string
+	reported map[string]struct{}
+	errs     utils.Errors
+}
+
+func newValidation(k store.K8s, resource map[string]string) *validation {
+	return &validation{
+		store:    k,
+		resource: resource,
+		reported: map[string]struct{}{},
+	}
+}
+
+// process runs the parsers of a set of annotations, all of them are run as they may depend on each other
+// but only the errors of the annotations set in the resource are reported, the other ones come from default values.
+func (v *validation) process(list []annotations.Annotation) {
+	for _, a := range list {
+		name := a.GetName()
+		err := a.Process(v.store, v.resource)
+		if _, ok := v.resource[name]; !ok {
+			continue
+		}
+		if _, ok := v.reported[name]; ok {
+			continue
+		}
+		if _, ok := referenceAnnotations[name]; ok {
+			_, _, err = common.GetK8sPath(name, v.resource)
+		}
+		if err != nil {
+			v.reported[name] = struct{}{}
+			v.errs.Add(fmt.Errorf("annotation '%s': %w", name, err))
+		}
+	}
+}
+
+// backend runs the parsers of the backend annotations and validates the resulting backend.
+func (v *validation) backend(a annotations.Annotations) {
+	backend := &models.Backend{
+		BackendBase: models.BackendBase{
+			Name: "webhook",
+			Mode: "http",
+		},
+	}
+	v.process(a.Backend(backend, v.store, nil))
+	v.process(a.Cache(backend, &models.Cache{Name: utils.Ptr("webhook")}))
+	v.validateModel("backend", backend)
+}
+
+// validateModel reports the settings of a model HAProxy would not accept.
+func (v *validation) validateModel(section string, model interface{ Validate(strfmt.Registry) error }) {
+	if err := model.Validate(nil); err != nil {
+		v.errs.Add(fmt.Errorf("%s: %w", section, err))
+	}
+}
+
+// discardMaps ignores the map files of annotations, nothing is written when validating.
+type discardMaps struct{}
+
+func (discardMaps) MapAppend(maps.Name, string) {}
+
+func (discardMaps) MapExists(maps.Name) bool { return false }
+
+func (discardMaps) RefreshMaps(api.HAProxyClient) {}
+
+func (discardMaps) CleanMaps() {}
@@ -0,0 +1,168 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/env"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+//nolint:golint, stylecheck
+const (
+	VALIDATE_URL_PATH = "/validate"
+)
+
+var logger = utils.GetLogger()
+
+// Webhook rejects the Ingresses, Services, ConfigMaps and custom resources the controller would fail to apply.
+// Annotations are parsed with the parsers used at sync time and config snippets are checked by HAProxy
+// in a copy of the current configuration, so that errors are reported to the user instead of the controller logs.
+type Webhook struct {
+	client      kubernetes.Interface
+	annotations annotations.Annotations
+	keyPair     *keyPair
+	env         env.Env
+	osArgs      utils.OSArgs
+}
+
+// New returns the validating webhook, client is used to resolve IngressClasses and can be nil.
+func New(client kubernetes.Interface, a annotations.Annotations, haproxyEnv env.Env, osArgs utils.OSArgs) *Webhook {
+	return &Webhook{
+		client:      client,
+		annotations: a,
+		env:         haproxyEnv,
+		osArgs:      osArgs,
+		keyPair: &keyPair{
+			certFile: osArgs.WebhookCertFile,
+			keyFile:  osArgs.WebhookKeyFile,
+		},
+	}
+}
+
+// Start serves the webhook over TLS on the webhook port until chShutdown is closed.
+func (w *Webhook) Start(chShutdown chan struct{}) {
+	if _, err := w.keyPair.getCertificate(nil); err != nil {
+		logger.Errorf("validating webhook: %s", err)
+	}
+	rtr := router.New()
+	rtr.POST(VALIDATE_URL_PATH, w.handler)
+	go func() {
+		server := fasthttp.Server{
+			Handler:               rtr.Handler,
+			NoDefaultServerHeader: true,
+			TLSConfig: &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: w.keyPair.getCertificate,
+			},
+		}
+		go func() {
+			<-chShutdown
+			if err := server.Shutdown(); err != nil {
+				logger.Errorf("Could not gracefully shutdown validating webhook server: %v\n", err)
+			} else {
+				logger.Info("Gracefully shuting down validating webhook server")
+			}
+		}()
+		logger.Infof("running validating webhook server on :%d", w.osArgs.WebhookPort)
+		err := server.ListenAndServeTLS(":"+strconv.Itoa(w.osArgs.WebhookPort), "", "")
+		logger.Error(err)
+	}()
+}
+
+func (w *Webhook) handler(ctx *fasthttp.RequestCtx) {
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(ctx.PostBody(), &review); err != nil || review.Request == nil {
+		ctx.Error("invalid AdmissionReview", fasthttp.StatusBadRequest)
+		return
+	}
+	review.Response = w.Review(review.Request)
+	review.Request = nil
+	body, err := json.Marshal(review)
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(body)
+}
+
+// Review returns the admission response of a request, resources are rejected with the list of their errors.
+func (w *Webhook) Review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+	if req.Operation == admissionv1.Delete {
+		return response
+	}
+	err := w.validate(req.Kind, req.Namespace, req.Object.Raw)
+	if err == nil {
+		return response
+	}
+	message := strings.TrimSpace(err.Error())
+	logger.Debugf("validating webhook: %s %s/%s rejected: %s", req.Kind.Kind, req.Namespace, req.Name, message)
+	response.Allowed = false
+	response.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonInvalid,
+		Code:    http.StatusUnprocessableEntity,
+		Message: message,
+	}
+	return response
+}
+
+// keyPair loads the webhook certificate again when its file is modified, for instance when it is renewed.
+type keyPair struct {
+	certificate *tls.Certificate
+	modTime     time.Time
+	certFile    string
+	keyFile     string
+	mu          sync.Mutex
+}
+
+func (k *keyPair) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	info, err := os.Stat(k.certFile)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.certificate != nil && info.ModTime().Equal(k.modTime) {
+		return k.certificate, nil
+	}
+	certificate, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	k.certificate = &certificate
+	k.modTime = info.ModTime()
+	return k.certificate, nil
+}
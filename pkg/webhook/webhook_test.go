@@ -0,0 +1,189 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/env"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// fakeHAProxy rejects the configurations containing the "bogus" keyword as HAProxy does.
+const fakeHAProxy = `#!/bin/sh
+line=$(grep -n bogus "$3" | cut -d: -f1 | head -n 1)
+if [ -n "$line" ]; then
+  echo "[ALERT]    (1) : config : parsing [$3:$line] : unknown keyword 'bogus' in 'backend' section"
+  echo "[ALERT]    (1) : config : Fatal errors found in configuration."
+  exit 1
+fi
+`
+
+const haproxyConfig = `global
+  maxconn 1000
+
+frontend http
+  mode http
+
+backend default
+  mode http
+`
+
+func newTestWebhook(t *testing.T) *Webhook {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "haproxy")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeHAProxy), 0o755))
+	mainCFGFile := filepath.Join(dir, "haproxy.cfg")
+	require.NoError(t, os.WriteFile(mainCFGFile, []byte(haproxyConfig), 0o644))
+
+	client := fake.NewSimpleClientset(&networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "haproxy"},
+		Spec:       networkingv1.IngressClassSpec{Controller: store.CONTROLLER},
+	})
+	haproxyEnv := env.Env{
+		Binary:      binary,
+		CfgDir:      dir,
+		MainCFGFile: mainCFGFile,
+		AuxCFGFile:  filepath.Join(dir, "haproxy-aux.cfg"),
+		Proxies:     env.Proxies{FrontHTTP: "http", FrontHTTPS: "https"},
+	}
+	osArgs := utils.OSArgs{ConfigMap: utils.NamespaceValue{Namespace: "haproxy-controller", Name: "haproxy-kubernetes-ingress"}}
+	return New(client, annotations.New(), haproxyEnv, osArgs)
+}
+
+func review(t *testing.T, w *Webhook, kind metav1.GroupVersionKind, object runtime.Object) *admissionv1.AdmissionResponse {
+	t.Helper()
+	raw, err := json.Marshal(object)
+	require.NoError(t, err)
+	return w.Review(&admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Kind:      kind,
+		Namespace: "app",
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	})
+}
+
+func ingress(class string, ann map[string]string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app", Annotations: ann},
+		Spec:       networkingv1.IngressSpec{IngressClassName: &class},
+	}
+}
+
+var (
+	ingressKind   = metav1.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	configMapKind = metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+)
+
+func TestReviewIngress(t *testing.T) {
+	w := newTestWebhook(t)
+
+	response := review(t, w, ingressKind, ingress("haproxy", map[string]string{
+		"haproxy.org/rate-limit-requests": "10",
+		"haproxy.org/rate-limit-period":   "1s",
+		"haproxy.org/timeout-server":      "5s",
+	}))
+	assert.True(t, response.Allowed)
+	assert.Equal(t, "uid", string(response.UID))
+
+	response = review(t, w, ingressKind, ingress("haproxy", map[string]string{
+		"haproxy.org/rate-limit-requests": "10",
+		"haproxy.org/rate-limit-period":   "one-second",
+	}))
+	require.False(t, response.Allowed)
+	assert.Contains(t, response.Result.Message, "annotation 'rate-limit-period'")
+
+	response = review(t, w, ingressKind, ingress("haproxy", map[string]string{
+		"haproxy.org/auth-type":   "basic-auth",
+		"haproxy.org/auth-secret": "app/not-created-yet",
+	}))
+	assert.True(t, response.Allowed, "referenced resources can be created later")
+
+	response = review(t, w, ingressKind, ingress("nginx", map[string]string{
+		"haproxy.org/rate-limit-requests": "ten",
+	}))
+	assert.True(t, response.Allowed, "Ingresses of other controllers are ignored")
+}
+
+func TestReviewSnippets(t *testing.T) {
+	w := newTestWebhook(t)
+
+	response := review(t, w, ingressKind, ingress("haproxy", map[string]string{
+		"haproxy.org/backend-config-snippet": "http-request deny if { path /admin }\nbogus on",
+	}))
+	require.False(t, response.Allowed)
+	assert.Equal(t, "annotation 'backend-config-snippet' line 2: unknown keyword 'bogus' in 'backend' section", response.Result.Message)
+
+	response = review(t, w, ingressKind, ingress("haproxy", map[string]string{
+		"haproxy.org/backend-config-snippet": "http-request deny if { path /admin }",
+	}))
+	assert.True(t, response.Allowed)
+}
+
+func TestReviewConfigMap(t *testing.T) {
+	w := newTestWebhook(t)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "haproxy-kubernetes-ingress", Namespace: "haproxy-controller"},
+		Data:       map[string]string{"timeout-client": "forever"},
+	}
+	response := review(t, w, configMapKind, configMap)
+	require.False(t, response.Allowed)
+	assert.Contains(t, response.Result.Message, "annotation 'timeout-client'")
+
+	configMap.Name = "other"
+	response = review(t, w, configMapKind, configMap)
+	assert.True(t, response.Allowed, "only the controller ConfigMap is validated")
+}
+
+func TestReviewCustomResource(t *testing.T) {
+	w := newTestWebhook(t)
+	kind := metav1.GroupVersionKind{Group: "ingress.v3.haproxy.org", Version: "v3", Kind: "Backend"}
+	backend := &v3.Backend{Spec: v3.BackendSpec{Backend: models.Backend{BackendBase: models.BackendBase{
+		Name:    "web",
+		Mode:    "http",
+		Balance: &models.Balance{Algorithm: utils.Ptr("fastest")},
+	}}}}
+	response := review(t, w, kind, backend)
+	require.False(t, response.Allowed)
+	assert.Contains(t, response.Result.Message, "algorithm")
+
+	backend.Spec.Balance.Algorithm = utils.Ptr("leastconn")
+	response = review(t, w, kind, backend)
+	assert.True(t, response.Allowed)
+}
+
+func TestReviewDelete(t *testing.T) {
+	w := newTestWebhook(t)
+	response := w.Review(&admissionv1.AdmissionRequest{UID: "uid", Kind: ingressKind, Operation: admissionv1.Delete})
+	assert.True(t, response.Allowed)
+}
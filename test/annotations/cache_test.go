@@ -0,0 +1,94 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func Test_Cache(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+	cache := &models.Cache{}
+	service := map[string]string{
+		"cache-enable":          "true",
+		"cache-size":            "16",
+		"cache-max-object-size": "1048576",
+		"cache-max-age":         "300",
+		"cache-vary":            "true",
+	}
+	for _, ann := range annotations.New().Cache(backend, cache) {
+		assert.NoError(t, ann.Process(k, service), ann.GetName())
+	}
+
+	vary := true
+	assert.Equal(t, &models.Cache{
+		Name:          utils.PtrString("default_svc_http"),
+		TotalMaxSize:  16,
+		MaxObjectSize: 1048576,
+		MaxAge:        300,
+		ProcessVary:   &vary,
+	}, cache)
+	assert.Equal(t, models.Filters{{Type: "cache", CacheName: "default_svc_http"}}, backend.FilterList)
+	assert.Equal(t, models.HTTPRequestRules{{Type: "cache-use", CacheName: "default_svc_http"}}, backend.HTTPRequestRuleList)
+	assert.Equal(t, models.HTTPResponseRules{{Type: "cache-store", CacheName: "default_svc_http"}}, backend.HTTPResponseRuleList)
+}
+
+func Test_CacheDefaults(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+	cache := &models.Cache{}
+	for _, ann := range annotations.New().Cache(backend, cache) {
+		assert.NoError(t, ann.Process(k, map[string]string{"cache-enable": "true"}), ann.GetName())
+	}
+	assert.Equal(t, int64(64), cache.TotalMaxSize)
+	assert.Equal(t, int64(60), cache.MaxAge)
+	assert.Nil(t, cache.ProcessVary)
+	assert.Len(t, backend.FilterList, 1)
+}
+
+func Test_CacheInvalid(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     []string
+	}{
+		{
+			name:        "disabled",
+			annotations: map[string]string{"cache-enable": "false"},
+		},
+		{
+			name:        "invalid size",
+			annotations: map[string]string{"cache-enable": "true", "cache-size": "4096", "cache-max-age": "-1"},
+			wantErr:     []string{"cache-size", "cache-max-age", "cache-enable"},
+		},
+		{
+			name:        "object bigger than half of the cache",
+			annotations: map[string]string{"cache-enable": "true", "cache-size": "1", "cache-max-object-size": "600000"},
+			wantErr:     []string{"cache-enable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+			cache := &models.Cache{}
+			var errs []string
+			for _, ann := range annotations.New().Cache(backend, cache) {
+				if err := ann.Process(k, tt.annotations); err != nil {
+					errs = append(errs, ann.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErr, errs)
+			// the cache is not used unless it is valid and enabled
+			assert.Nil(t, cache.Name)
+			assert.Empty(t, backend.FilterList)
+			assert.Empty(t, backend.HTTPRequestRuleList)
+		})
+	}
+}
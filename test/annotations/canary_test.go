@@ -0,0 +1,107 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func Test_Canary(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "not a canary",
+			annotations: map[string]string{},
+		},
+		{
+			name: "header value, cookie and weight",
+			annotations: map[string]string{
+				"canary-by-header":       "X-Canary",
+				"canary-by-header-value": "v2.1_beta",
+				"canary-by-cookie":       "canary",
+				"canary-weight":          "10",
+			},
+			want: []string{"req.hdr(X-Canary) -m str v2.1_beta", "req.cook(canary) -m str always", "rand(100) lt 10"},
+		},
+		{
+			name:        "default header value",
+			annotations: map[string]string{"canary-by-header": "X-Canary"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+		},
+		{
+			name:        "header pattern",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-value": "v2", "canary-by-header-pattern": "^v[23]$"},
+			want:        []string{"req.hdr(X-Canary) -m reg ^v[23]$"},
+		},
+		{
+			name:        "header value injection",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-value": "v2}"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header value with a comment",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-value": "v2\"#"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header pattern with a comment",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-pattern": "^v2$#"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header pattern with a double quote",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-pattern": `^v2"$`},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header pattern with a single quote",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-pattern": "^v2'$"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header pattern with a backslash",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-pattern": `^v\d$`},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "header pattern with a whitespace",
+			annotations: map[string]string{"canary-by-header": "X-Canary", "canary-by-header-pattern": "^v2 }$"},
+			want:        []string{"req.hdr(X-Canary) -m str always"},
+			wantErr:     true,
+		},
+		{
+			name:        "no valid annotation",
+			annotations: map[string]string{"canary-weight": "101", "canary-by-cookie": "canary;"},
+			want:        []string{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var acls []string
+			var failed bool
+			for _, a := range annotations.New().Canary(&acls) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					failed = true
+				}
+			}
+			assert.Equal(t, tt.wantErr, failed)
+			assert.Equal(t, tt.want, acls)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// secretCerts returns the path of the secrets instead of writing them on disk.
+type secretCerts struct {
+	certs.Certificates
+	refs map[string]string
+	crls map[string]string
+}
+
+func (c secretCerts) AddSecret(secret *store.Secret, secretType certs.SecretType) (string, error) {
+	return fmt.Sprintf("/%d/%s_%s.pem", secretType, secret.Namespace, secret.Name), nil
+}
+
+func (c secretCerts) AddReference(secretType certs.SecretType, namespace, name, reference string) {
+	c.refs[fmt.Sprintf("/%d/%s_%s.pem", secretType, namespace, name)] = reference
+}
+
+func (c secretCerts) CRLFile(caFile string) string {
+	return c.crls[caFile]
+}
+
+func Test_ClientAuth(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	ns := k.GetNamespace("default")
+	ns.Secret["ca"] = &store.Secret{Namespace: "default", Name: "ca", Data: map[string][]byte{"tls.crt": []byte("ca")}}
+	ns.Secret["ca-crl"] = &store.Secret{Namespace: "default", Name: "ca-crl", Data: map[string][]byte{"tls.crt": []byte("ca"), "crl.pem": []byte("crl")}}
+	ns.Secret["crl"] = &store.Secret{Namespace: "default", Name: "crl", Data: map[string][]byte{"crl.pem": []byte("crl")}}
+	ingress := &store.Ingress{IngressCore: store.IngressCore{Namespace: "default", Name: "api"}}
+	caFile := fmt.Sprintf("/%d/default_ca.pem", certs.CA_CERT)
+	crlFile := fmt.Sprintf("/%d/default_crl.pem", certs.CRL_FILE)
+	caCRLFile := fmt.Sprintf("/%d/default_ca-crl.pem", certs.CA_CERT)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantAuth    certs.ClientAuth
+		wantRule    *rules.ReqClientAuth
+		wantRefs    map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "not enabled",
+			annotations: map[string]string{"client-crt-optional": "true", "client-crl": "crl"},
+		},
+		{
+			name:        "required",
+			annotations: map[string]string{"client-ca": "ca"},
+			wantAuth:    certs.ClientAuth{CAFile: caFile, Verify: "required"},
+			wantRule:    &rules.ReqClientAuth{},
+			wantRefs:    map[string]string{caFile: "Ingress default/api"},
+		},
+		{
+			name:        "optional with crl",
+			annotations: map[string]string{"client-ca": "default/ca", "client-crt-optional": "true", "client-crl": "crl"},
+			wantAuth:    certs.ClientAuth{CAFile: caFile, CRLFile: crlFile, Verify: "optional"},
+			wantRule:    &rules.ReqClientAuth{Optional: true},
+			wantRefs:    map[string]string{caFile: "Ingress default/api"},
+		},
+		{
+			name:        "CRL of the CA secret",
+			annotations: map[string]string{"client-ca": "ca-crl"},
+			wantAuth:    certs.ClientAuth{CAFile: caCRLFile, CRLFile: caCRLFile + ".crl", Verify: "required"},
+			wantRule:    &rules.ReqClientAuth{},
+		},
+		{
+			name:        "client-crl overrides the CRL of the CA secret",
+			annotations: map[string]string{"client-ca": "ca-crl", "client-crl": "crl"},
+			wantAuth:    certs.ClientAuth{CAFile: caCRLFile, CRLFile: crlFile, Verify: "required"},
+			wantRule:    &rules.ReqClientAuth{},
+		},
+		{
+			name:        "missing CA",
+			annotations: map[string]string{"client-ca": "missing", "client-crt-optional": "true"},
+			wantRule:    &rules.ReqClientAuth{},
+			wantErr:     true,
+		},
+		{
+			name:        "missing CRL",
+			annotations: map[string]string{"client-ca": "ca", "client-crt-optional": "true", "client-crl": "missing"},
+			wantRule:    &rules.ReqClientAuth{},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid optional",
+			annotations: map[string]string{"client-ca": "ca", "client-crt-optional": "maybe"},
+			wantRule:    &rules.ReqClientAuth{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auth certs.ClientAuth
+			list := rules.List{}
+			var failed bool
+			c := secretCerts{refs: map[string]string{}, crls: map[string]string{caCRLFile: caCRLFile + ".crl"}}
+			for _, a := range annotations.New().ClientAuth(&auth, ingress, &list, c) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					failed = true
+				}
+			}
+			assert.Equal(t, tt.wantErr, failed)
+			assert.Equal(t, tt.wantAuth, auth)
+			if tt.wantRefs != nil {
+				assert.Equal(t, tt.wantRefs, c.refs)
+			}
+			if tt.wantRule == nil {
+				assert.Empty(t, list)
+				return
+			}
+			if assert.Len(t, list, 1) {
+				assert.Equal(t, tt.wantRule, list[0])
+			}
+		})
+	}
+}
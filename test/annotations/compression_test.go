@@ -0,0 +1,60 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func Test_Compression(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	configmap := map[string]string{
+		"compression-algorithms": "gzip deflate",
+		"compression-min-size":   "1024",
+	}
+	service := map[string]string{
+		"compression-enable": "true",
+		"compression-types":  "text/html,application/json",
+	}
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+	for _, ann := range annotations.New().Backend(backend, k, nil) {
+		assert.NoError(t, ann.Process(k, service, configmap), ann.GetName())
+	}
+
+	assert.Equal(t, &models.Compression{
+		Algorithms: []string{"gzip", "deflate"},
+		Types:      []string{"text/html", "application/json"},
+		MinsizeRes: 1024,
+	}, backend.Compression)
+	assert.Equal(t, models.Filters{{Type: models.FilterTypeCompression}}, backend.FilterList)
+}
+
+func Test_CompressionDisabled(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+	for _, ann := range annotations.New().Backend(backend, k, nil) {
+		assert.NoError(t, ann.Process(k, map[string]string{"compression-enable": "false", "compression-algorithms": "gzip"}), ann.GetName())
+	}
+	assert.Nil(t, backend.Compression)
+	assert.Empty(t, backend.FilterList)
+}
+
+func Test_CompressionInvalid(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	backend := &models.Backend{BackendBase: models.BackendBase{Name: "default_svc_http", Mode: "http"}}
+	var errs []string
+	for _, ann := range annotations.New().Backend(backend, k, nil) {
+		if err := ann.Process(k, map[string]string{"compression-enable": "true", "compression-algorithms": "brotli"}); err != nil {
+			errs = append(errs, ann.GetName())
+		}
+	}
+	assert.Equal(t, []string{"compression-algorithms", "compression-enable"}, errs)
+	// an invalid parameter turns compression off
+	assert.Nil(t, backend.Compression)
+	assert.Empty(t, backend.FilterList)
+}
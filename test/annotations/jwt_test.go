@@ -0,0 +1,145 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// fakeCerts records the JWT keys instead of writing them on disk.
+type fakeCerts struct {
+	certs.Certificates
+	keys map[string][]byte
+}
+
+func (c *fakeCerts) AddJWTKey(name string, key []byte) (string, error) {
+	c.keys[name] = key
+	return "/jwt/" + name + ".pem", nil
+}
+
+func b64(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func Test_JWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-384", "x": b64(ecKey.X), "y": b64(ecKey.Y)},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+	}})
+	require.NoError(t, err)
+
+	k := store.NewK8sStore(utils.OSArgs{})
+	ns := k.GetNamespace("default")
+	ns.JWKS["jwks"] = &store.ConfigMap{Namespace: "default", Name: "jwks", Annotations: map[string]string{store.JWKSKey: string(jwks)}}
+	ingress := &store.Ingress{IngressCore: store.IngressCore{Namespace: "default", Name: "api"}}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *rules.ReqJWT
+		wantErr     []string
+	}{
+		{
+			name:        "no keys",
+			annotations: map[string]string{"jwt-issuer": "https://issuer"},
+		},
+		{
+			name: "jwks",
+			annotations: map[string]string{
+				"jwt-jwks":            "jwks",
+				"jwt-algorithms":      "RS256,ES384",
+				"jwt-issuer":          "https://issuer",
+				"jwt-audience":        "api",
+				"jwt-required-claims": "scope=read,org.id=42",
+				"jwt-claim-headers":   "sub:X-User",
+				"jwt-deny-status":     "403",
+			},
+			want: &rules.ReqJWT{
+				Keys: []rules.JWTKey{
+					{Path: "/jwt/default_jwks_rsa-1.pem", Kid: "rsa-1", Algorithms: []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}},
+					{Path: "/jwt/default_jwks_ec-1.pem", Kid: "ec-1", Algorithms: []string{"ES384"}},
+				},
+				Algorithms:     []string{"RS256", "ES384"},
+				Issuer:         "https://issuer",
+				Audience:       "api",
+				RequiredClaims: []rules.JWTClaim{{Name: "scope", Value: "read"}, {Name: "org.id", Value: "42"}},
+				ClaimHeaders:   []rules.JWTClaimHeader{{Claim: "sub", Header: "X-User"}},
+				DenyStatus:     403,
+			},
+		},
+		{
+			name: "invalid values",
+			annotations: map[string]string{
+				"jwt-jwks":          "missing",
+				"jwt-algorithms":    "HS256",
+				"jwt-claim-headers": "sub=X-User",
+			},
+			want:    &rules.ReqJWT{},
+			wantErr: []string{"jwt-jwks", "jwt-algorithms", "jwt-claim-headers"},
+		},
+		{
+			name: "comments and backslashes",
+			annotations: map[string]string{
+				"jwt-jwks":            "missing",
+				"jwt-issuer":          "https://issuer#",
+				"jwt-audience":        `api\`,
+				"jwt-required-claims": "scope=read#,org.id=42",
+			},
+			want:    &rules.ReqJWT{},
+			wantErr: []string{"jwt-jwks", "jwt-issuer", "jwt-audience", "jwt-required-claims"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := rules.List{}
+			c := &fakeCerts{keys: map[string][]byte{}}
+			var errs []string
+			for _, a := range annotations.New().Frontend(ingress, &list, nil, c) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					errs = append(errs, a.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErr, errs)
+			if tt.want == nil {
+				assert.Empty(t, list)
+				return
+			}
+			require.Len(t, list, 1)
+			assert.Equal(t, tt.want, list[0])
+			assert.Len(t, c.keys, len(tt.want.Keys))
+		})
+	}
+}
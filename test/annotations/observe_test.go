@@ -0,0 +1,75 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func TestObserveAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		annotations map[string]string
+		want        *models.DefaultServer
+		wantErrs    []string
+	}{
+		{
+			name:        "layer7",
+			mode:        "http",
+			annotations: map[string]string{"check": "true", "observe": "layer7", "error-limit": "5", "on-error": "mark-down", "pod-maxqueue": "10"},
+			want: &models.DefaultServer{ServerParams: models.ServerParams{
+				Check:      "enabled",
+				Observe:    "layer7",
+				ErrorLimit: 5,
+				OnError:    "mark-down",
+				Maxqueue:   utils.PtrInt64(10),
+			}},
+		},
+		{
+			name:        "layer7 in TCP mode",
+			mode:        "tcp",
+			annotations: map[string]string{"check": "true", "observe": "layer7"},
+			want:        &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled"}},
+			wantErrs:    []string{"observe"},
+		},
+		{
+			name:        "without health checks",
+			mode:        "http",
+			annotations: map[string]string{"check": "false", "observe": "layer4"},
+		},
+		{
+			name:        "invalid",
+			mode:        "http",
+			annotations: map[string]string{"check": "true", "observe": "layer4", "error-limit": "0", "on-error": "shutdown", "pod-maxqueue": "-1"},
+			want:        &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled", Observe: "layer4"}},
+			wantErrs:    []string{"error-limit", "on-error", "pod-maxqueue"},
+		},
+		{
+			name:        "pod-maxqueue with several HAProxy instances",
+			mode:        "http",
+			annotations: map[string]string{"pod-maxqueue": "10"},
+			want:        &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled", Maxqueue: utils.PtrInt64(10)}},
+		},
+	}
+	k := store.NewK8sStore(utils.OSArgs{})
+	k.HaProxyPods = map[string]struct{}{"haproxy-0": {}, "haproxy-1": {}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &models.Backend{BackendBase: models.BackendBase{Mode: tt.mode}}
+			var errs []string
+			for _, a := range annotations.New().Backend(backend, k, nil) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					errs = append(errs, a.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.Equal(t, tt.want, backend.DefaultServer)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func TestRetryAnnotations(t *testing.T) {
+	disableL7Retry := &models.HTTPRequestRule{
+		Type:     "disable-l7-retry",
+		Cond:     "if",
+		CondTest: "!{ method GET HEAD OPTIONS PUT DELETE TRACE }",
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        models.Backend
+		wantErrs    []string
+	}{
+		{
+			name:        "retries",
+			annotations: map[string]string{"retries": "5", "retry-on": "conn-failure"},
+			want:        models.Backend{BackendBase: models.BackendBase{Retries: utils.PtrInt64(5), RetryOn: "conn-failure"}},
+		},
+		{
+			name:        "idempotent requests only",
+			annotations: map[string]string{"retry-on": "conn-failure,503 response-timeout"},
+			want: models.Backend{
+				BackendBase:         models.BackendBase{RetryOn: "conn-failure 503 response-timeout"},
+				HTTPRequestRuleList: models.HTTPRequestRules{disableL7Retry},
+			},
+		},
+		{
+			name:        "non idempotent requests",
+			annotations: map[string]string{"retry-on": "all-retryable-errors", "retry-non-idempotent": "true"},
+			want:        models.Backend{BackendBase: models.BackendBase{RetryOn: "all-retryable-errors"}},
+		},
+		{
+			name:        "redispatch",
+			annotations: map[string]string{"redispatch": "true", "redispatch-interval": "-1"},
+			want: models.Backend{BackendBase: models.BackendBase{Redispatch: &models.Redispatch{
+				Enabled:  utils.PtrString("enabled"),
+				Interval: utils.PtrInt64(-1),
+			}}},
+		},
+		{
+			name:        "invalid",
+			annotations: map[string]string{"retries": "-1", "retry-on": "none 503", "redispatch-interval": "0", "redispatch": "true"},
+			want:        models.Backend{BackendBase: models.BackendBase{Redispatch: &models.Redispatch{Enabled: utils.PtrString("enabled")}}},
+			wantErrs:    []string{"retries", "retry-on", "redispatch-interval"},
+		},
+	}
+	k := store.NewK8sStore(utils.OSArgs{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &models.Backend{BackendBase: models.BackendBase{Mode: "http"}}
+			var errs []string
+			for _, a := range annotations.New().Backend(backend, k, nil) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					errs = append(errs, a.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.Equal(t, tt.want.Retries, backend.Retries)
+			assert.Equal(t, tt.want.RetryOn, backend.RetryOn)
+			assert.Equal(t, tt.want.Redispatch, backend.Redispatch)
+			assert.Equal(t, tt.want.HTTPRequestRuleList, backend.HTTPRequestRuleList)
+		})
+	}
+}
+
+func TestRetryAnnotationsTCP(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        models.BackendBase
+		wantErrs    []string
+	}{
+		{
+			name:        "connection failures",
+			annotations: map[string]string{"retries": "3", "retry-on": "conn-failure", "retry-non-idempotent": "false", "redispatch": "true", "redispatch-interval": "2"},
+			want: models.BackendBase{Retries: utils.PtrInt64(3), RetryOn: "conn-failure", Redispatch: &models.Redispatch{
+				Enabled:  utils.PtrString("enabled"),
+				Interval: utils.PtrInt64(2),
+			}},
+		},
+		{
+			name:        "http conditions",
+			annotations: map[string]string{"retries": "3", "retry-on": "conn-failure 503", "retry-non-idempotent": "true", "redispatch": "false"},
+			want:        models.BackendBase{Retries: utils.PtrInt64(3), Redispatch: &models.Redispatch{Enabled: utils.PtrString("disabled")}},
+			wantErrs:    []string{"retry-on"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &models.Backend{BackendBase: models.BackendBase{Mode: "tcp"}}
+			var errs []string
+			for _, a := range annotations.New().Backend(backend, k, nil) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					errs = append(errs, a.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.Equal(t, tt.want.Retries, backend.Retries)
+			assert.Equal(t, tt.want.RetryOn, backend.RetryOn)
+			assert.Equal(t, tt.want.Redispatch, backend.Redispatch)
+			// no http-request rule in TCP mode
+			assert.Empty(t, backend.HTTPRequestRuleList)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func TestRouteMatchAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+		wantErrs    []string
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name: "all conditions",
+			annotations: map[string]string{
+				"route-match-header": "X-API-Version=v2, X-Debug",
+				"route-match-query":  "tenant=acme",
+				"route-match-method": "GET,HEAD",
+				"route-match-cookie": "beta",
+			},
+			want: []string{
+				"req.hdr(X-API-Version) -m str v2",
+				"req.hdr(X-Debug) -m found",
+				"urlp(tenant) -m str acme",
+				"method GET HEAD",
+				"req.cook(beta) -m found",
+			},
+		},
+		{
+			name: "invalid condition",
+			annotations: map[string]string{
+				"route-match-header": "X-API-Version=v2",
+				"route-match-query":  "tenant=a#b",
+				"route-match-method": "get",
+			},
+			want:     []string{"always_false"},
+			wantErrs: []string{"route-match-query", "route-match-method"},
+		},
+	}
+	k := store.NewK8sStore(utils.OSArgs{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var acls []string
+			var errs []string
+			for _, a := range annotations.New().RouteMatch(&acls) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					errs = append(errs, a.GetName())
+				}
+			}
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.Equal(t, tt.want, acls)
+		})
+	}
+}
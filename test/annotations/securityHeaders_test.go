@@ -0,0 +1,114 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func securityHeader(name, value string) rules.Rule {
+	return &rules.SetHdr{HdrName: name, HdrFormat: `"` + value + `"`, Response: true, HTTPSOnly: true}
+}
+
+func Test_SecurityHeaders(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	configMap := map[string]string{
+		"hsts":                    "true",
+		"hsts-include-subdomains": "true",
+		"x-frame-options":         "deny",
+		"x-content-type-options":  "nosniff",
+	}
+	tests := []struct {
+		name      string
+		ingress   map[string]string
+		configMap map[string]string
+		want      rules.List
+		wantErr   bool
+	}{
+		{
+			name:      "ConfigMap policy",
+			configMap: configMap,
+			want: rules.List{
+				securityHeader("Strict-Transport-Security", "max-age=31536000; includeSubDomains"),
+				securityHeader("X-Frame-Options", "DENY"),
+				securityHeader("X-Content-Type-Options", "nosniff"),
+			},
+		},
+		{
+			name: "Ingress overrides",
+			ingress: map[string]string{
+				"hsts-max-age":            "63072000",
+				"hsts-preload":            "true",
+				"x-frame-options":         "",
+				"referrer-policy":         "no-referrer, strict-origin-when-cross-origin",
+				"content-security-policy": "default-src 'self'; img-src * data:",
+			},
+			configMap: configMap,
+			want: rules.List{
+				securityHeader("Strict-Transport-Security", "max-age=63072000; includeSubDomains; preload"),
+				securityHeader("X-Content-Type-Options", "nosniff"),
+				securityHeader("Referrer-Policy", "no-referrer, strict-origin-when-cross-origin"),
+				securityHeader("Content-Security-Policy", "default-src 'self'; img-src * data:"),
+			},
+		},
+		{
+			name:      "HSTS disabled by the Ingress",
+			ingress:   map[string]string{"hsts": "false"},
+			configMap: map[string]string{"hsts": "true"},
+		},
+		{
+			name:    "preload without includeSubDomains",
+			ingress: map[string]string{"hsts": "true", "hsts-preload": "true"},
+			want: rules.List{
+				securityHeader("Strict-Transport-Security", "max-age=31536000"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid values",
+			ingress: map[string]string{
+				"x-frame-options":         "ALLOW-FROM https://example.com",
+				"x-content-type-options":  "sniff",
+				"referrer-policy":         "everywhere",
+				"content-security-policy": `default-src "self"`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := rules.List{}
+			var failed bool
+			for _, a := range annotations.New().SecurityHeaders(&list) {
+				if err := a.Process(k, tt.ingress, tt.configMap); err != nil {
+					failed = true
+				}
+			}
+			assert.Equal(t, tt.wantErr, failed)
+			if tt.want == nil {
+				assert.Empty(t, list)
+				return
+			}
+			assert.Equal(t, tt.want, list)
+		})
+	}
+}
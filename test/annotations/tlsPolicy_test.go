@@ -0,0 +1,89 @@
+// Copyright 2026 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func Test_TLSPolicy(t *testing.T) {
+	k := store.NewK8sStore(utils.OSArgs{})
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        certs.TLSPolicy
+		wantErr     bool
+	}{
+		{
+			name: "all parameters",
+			annotations: map[string]string{
+				"ssl-min-ver":      "TLSv1.2",
+				"ssl-ciphers":      "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256",
+				"ssl-ciphersuites": "TLS_AES_256_GCM_SHA384",
+				"ssl-curves":       "X25519:P-256",
+				"tls-alpn":         "h2,http/1.1",
+			},
+			want: certs.TLSPolicy{
+				MinVer:       "TLSv1.2",
+				Ciphers:      "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256",
+				Ciphersuites: "TLS_AES_256_GCM_SHA384",
+				Curves:       "X25519:P-256",
+				ALPN:         "h2,http/1.1",
+			},
+		},
+		{
+			name:        "default alpn",
+			annotations: map[string]string{"ssl-min-ver": "TLSv1.3"},
+			want:        certs.TLSPolicy{MinVer: "TLSv1.3", ALPN: "h2,http/1.1"},
+		},
+		{
+			name:        "invalid version",
+			annotations: map[string]string{"ssl-min-ver": "TLSv1.4", "tls-alpn": "http/1.1"},
+			want:        certs.TLSPolicy{ALPN: "http/1.1"},
+			wantErr:     true,
+		},
+		{
+			name:        "crt-list option injection",
+			annotations: map[string]string{"ssl-ciphers": "AES] verify none [", "ssl-curves": "P-256 verify none"},
+			want:        certs.TLSPolicy{ALPN: "h2,http/1.1"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid alpn",
+			annotations: map[string]string{"tls-alpn": "h2, http/1.1"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy certs.TLSPolicy
+			var failed bool
+			for _, a := range annotations.New().TLSPolicy(&policy) {
+				if err := a.Process(k, tt.annotations); err != nil {
+					failed = true
+				}
+			}
+			assert.Equal(t, tt.wantErr, failed)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}